@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/bcurnow/zonemgr/dns"
+	"github.com/bcurnow/zonemgr/dns/serial"
 	"github.com/bcurnow/zonemgr/plugins"
 	"github.com/bcurnow/zonemgr/plugins/plugin_manager"
 	"github.com/bcurnow/zonemgr/utils"
@@ -37,6 +38,10 @@ var (
 	mockZoneFileGenerator *dns.MockZoneFileGenerator
 	mockNormalizer        *dns.MockNormalizer
 	mockCatalogGenerator  *dns.MockCatalogGenerator
+	mockZoneImporter      *dns.MockZoneImporter
+	mockZoneDiffer        *dns.MockZoneDiffer
+	mockSerialManager     *serial.MockSerialManager
+	mockFileTransaction   *utils.MockFileTransaction
 	testPlugin            *plugins.MockZoneMgrPlugin
 	testPlugins           map[plugins.Type]plugins.ZoneMgrPlugin
 	testMetadata          map[plugins.Type]*plugins.Metadata
@@ -61,10 +66,23 @@ func setup(t *testing.T) {
 
 	mockNormalizer = dns.NewMockNormalizer(mockController)
 	normalizer = mockNormalizer
+	// Only the validate command reports the warnings, everything else logs whatever there are
+	mockNormalizer.EXPECT().Warnings().AnyTimes()
 
 	mockCatalogGenerator = dns.NewMockCatalogGenerator(mockController)
 	catalogGenerator = mockCatalogGenerator
 
+	mockZoneImporter = dns.NewMockZoneImporter(mockController)
+	zoneImporter = mockZoneImporter
+
+	mockZoneDiffer = dns.NewMockZoneDiffer(mockController)
+	zoneDiffer = mockZoneDiffer
+
+	mockFileTransaction = utils.NewMockFileTransaction(mockController)
+
+	mockSerialManager = serial.NewMockSerialManager(mockController)
+	newSerialManager = func(string) serial.SerialManager { return mockSerialManager }
+
 	testPlugin = plugins.NewMockZoneMgrPlugin(mockController)
 	testPlugins = make(map[plugins.Type]plugins.ZoneMgrPlugin)
 	testMetadata = make(map[plugins.Type]*plugins.Metadata)
@@ -79,5 +97,6 @@ func teardown(_ *testing.T) {
 	defer func() { fs = &utils.FileSystem{} }()
 	defer func() { pluginManager = plugin_manager.Manager() }()
 	defer func() { v = nil }()
+	defer func() { newSerialManager = serial.FileSerialManager }()
 	defer mockController.Finish()
 }
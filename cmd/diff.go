@@ -0,0 +1,99 @@
+/*
+Copyright © 2025 Brian Curnow
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bcurnow/zonemgr/dns"
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/hashicorp/go-hclog"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffCmd = &cobra.Command{
+		Use:   "diff",
+		Short: "Shows the resource records generate would change in the existing BIND zone file(s)",
+		Long: `Runs the same pipeline as generate but, instead of writing the zone files, compares each zone with the
+existing zone file in the output directory. Whitespace, comments and the SOA serial number are ignored.
+Exits with a non-zero exit code when there are differences.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			changedZones, err := diffZoneFiles(diffOutput)
+			if err != nil {
+				return err
+			}
+			if changedZones > 0 {
+				// Differences aren't a usage problem, don't print the usage
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d zone(s) differ from the zone files in %s", changedZones, outputDir)
+			}
+			return nil
+		},
+		PreRunE: generatePreRunE,
+	}
+
+	zoneDiffer dns.ZoneDiffer = dns.MasterFileZoneDiffer()
+	diffOutput io.Writer      = os.Stdout
+)
+
+// diffZoneFiles prints the differences for every zone and returns the number of zones with differences
+func diffZoneFiles(out io.Writer) (int, error) {
+	hclog.L().Info("comparing BIND zone file(s)", "outputDir", outputDir, "inputFiles", inputFiles)
+	zones, reverseZones, catalogZones, err := buildZones()
+	if err != nil {
+		return 0, err
+	}
+
+	changedZones := 0
+	err = withEveryZone(zones, reverseZones, catalogZones, func(name string, zone *models.Zone) error {
+		path, err := dns.ZoneFilePath(name, outputDir)
+		if err != nil {
+			return err
+		}
+
+		rendered, err := zoneFileGenerator.RenderZone(name, zone)
+		if err != nil {
+			return err
+		}
+
+		changes, err := zoneDiffer.Diff(name, path, rendered)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+
+		changedZones++
+		fmt.Fprintf(out, "--- %s\n", path)
+		for _, change := range changes {
+			fmt.Fprintln(out, change)
+		}
+		return nil
+	})
+	return changedZones, err
+}
+
+func init() {
+	diffCmd.Flags().StringSliceVar(&inputFiles, "input-file", []string{"zones.yaml"}, "Input YAML file(s), globs and directories, can be repeated or comma separated")
+	diffCmd.Flags().StringVar(&outputDir, "output-dir", ".", "Directory containing the existing BIND zone file(s)")
+
+	rootCmd.AddCommand(diffCmd)
+}
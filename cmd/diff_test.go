@@ -0,0 +1,116 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bcurnow/zonemgr/dns"
+	"github.com/bcurnow/zonemgr/models"
+)
+
+func TestRunE_Diff(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+	defer func() { diffOutput = os.Stdout }()
+
+	inputFiles = []string{"testing"}
+	outputDir = "testing-dir"
+
+	testCases := []struct {
+		parseErr  bool
+		renderErr bool
+		diffErr   bool
+		changes   bool
+		want      string
+		wantOut   string
+	}{
+		{},
+		{changes: true, want: "1 zone(s) differ from the zone files in testing-dir", wantOut: "--- " + filepath.Join("testing-dir", "two.") + "\n+ www.two. IN A 192.0.2.1\n- old.two. IN A 192.0.2.2\n"},
+		{parseErr: true, want: "failed to parse input file testing: parseErr"},
+		{renderErr: true, want: "renderErr"},
+		{diffErr: true, want: "diffErr"},
+	}
+
+	for _, tc := range testCases {
+		var out bytes.Buffer
+		diffOutput = &out
+
+		zoneOne := &models.Zone{Config: &models.Config{}}
+		zoneTwo := &models.Zone{Config: &models.Config{}}
+		call := mockParser.EXPECT().Parse(inputFiles)
+		if tc.parseErr {
+			call.Return(nil, errors.New("parseErr"))
+		} else {
+			call.Return(map[string]*models.Zone{"one.": zoneOne, "two.": zoneTwo}, nil)
+
+			renderCall := mockZoneFileGenerator.EXPECT().RenderZone("one.", zoneOne)
+			if tc.renderErr {
+				renderCall.Return(nil, errors.New("renderErr"))
+			} else {
+				renderCall.Return([]byte("one"), nil)
+				diffCall := mockZoneDiffer.EXPECT().Diff("one.", filepath.Join(outputDir, "one."), []byte("one"))
+				if tc.diffErr {
+					diffCall.Return(nil, errors.New("diffErr"))
+				} else {
+					diffCall.Return(nil, nil)
+					mockZoneFileGenerator.EXPECT().RenderZone("two.", zoneTwo).Return([]byte("two"), nil)
+					var changes []*dns.RecordChange
+					if tc.changes {
+						changes = []*dns.RecordChange{{Type: dns.RecordAdded, New: "www.two. IN A 192.0.2.1"}, {Type: dns.RecordRemoved, Old: "old.two. IN A 192.0.2.2"}}
+					}
+					mockZoneDiffer.EXPECT().Diff("two.", filepath.Join(outputDir, "two."), []byte("two")).Return(changes, nil)
+				}
+			}
+		}
+
+		err := diffCmd.RunE(diffCmd, []string{})
+		if err != nil {
+			if err.Error() != tc.want {
+				t.Errorf("incorrect error: '%s', want: '%s'", err, tc.want)
+			}
+		} else if tc.want != "" {
+			t.Errorf("expected error '%s', found none", tc.want)
+		}
+
+		if out.String() != tc.wantOut {
+			t.Errorf("incorrect output: %q, want: %q", out.String(), tc.wantOut)
+		}
+	}
+}
+
+func TestDiffZoneFiles_PathTraversal(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	inputFiles = []string{"testing"}
+	outputDir = "testing-dir"
+	mockParser.EXPECT().Parse(inputFiles).Return(map[string]*models.Zone{"../one.": {Config: &models.Config{}}}, nil)
+
+	_, err := diffZoneFiles(&bytes.Buffer{})
+	want := `zone name "../one." resolves outside output directory`
+	if err == nil || err.Error() != want {
+		t.Errorf("incorrect error: '%v', want: '%s'", err, want)
+	}
+}
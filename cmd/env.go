@@ -27,7 +27,7 @@ import (
 
 var envCmd = &cobra.Command{
 	Use:   "env",
-	Short: "Prints the environment variables used (or defaulted)",
+	Short: "Prints the effective settings from the flags, environment variables and config file (or defaulted)",
 	Run: func(cmd *cobra.Command, args []string) {
 		keys := v.AllKeys()
 		sort.Strings(keys)
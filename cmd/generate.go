@@ -17,13 +17,18 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cmd
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"github.com/bcurnow/zonemgr/dns"
+	"github.com/bcurnow/zonemgr/dns/serial"
 	"github.com/bcurnow/zonemgr/models"
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -33,41 +38,96 @@ var (
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return generateZoneFile()
 		},
-		PreRunE: func(cmd *cobra.Command, args []string) error {
-			absOutputDir, err := fs.ToAbsoluteFilePath(outputDir)
-			if err != nil {
-				return err
-			}
-			outputDir = absOutputDir
-
-			absInputFile, err := fs.ToAbsoluteFilePath(inputFile)
-			if err != nil {
-				return err
-			}
-			inputFile = absInputFile
-
-			zoneFileGenerator = dns.PluginZoneFileGenerator(pluginManager.Plugins(), pluginManager.Metadata())
-			normalizer = dns.PluginNormalizer(pluginManager.Plugins(), pluginManager.Metadata())
-			parser = dns.YamlZoneParser(normalizer)
-			catalogGenerator = dns.PluginCatalogGenerator(pluginManager.Plugins(), pluginManager.Metadata())
-
-			return nil
-		},
+		PreRunE: generatePreRunE,
 	}
 
-	inputFile         string
+	inputFiles        []string
 	outputDir         string
 	zoneReverser      dns.ZoneReverser = dns.Reverser()
 	zoneFileGenerator dns.ZoneFileGenerator
 	normalizer        dns.Normalizer
 	catalogGenerator  dns.CatalogGenerator
+	newSerialManager  = serial.FileSerialManager
 )
 
+// Shared by every command that runs the generate pipeline
+func generatePreRunE(cmd *cobra.Command, args []string) error {
+	absOutputDir, err := fs.ToAbsoluteFilePath(outputDir)
+	if err != nil {
+		return err
+	}
+	outputDir = absOutputDir
+
+	absInputFiles, err := toAbsoluteFilePaths(inputFiles)
+	if err != nil {
+		return err
+	}
+	inputFiles = absInputFiles
+
+	zoneFileGenerator = dns.PluginZoneFileGenerator(pluginManager.Plugins(), pluginManager.Metadata())
+	normalizer = dns.PluginNormalizer(pluginManager.Plugins(), pluginManager.Metadata(), configDefaults())
+	parser = dns.YamlZoneParser(normalizer)
+	catalogGenerator = dns.PluginCatalogGenerator(pluginManager.Plugins(), pluginManager.Metadata())
+
+	return nil
+}
+
+// Globs and directories are made absolute too, they are expanded when the zones are parsed
+func toAbsoluteFilePaths(paths []string) ([]string, error) {
+	absPaths := make([]string, 0, len(paths))
+	for _, path := range paths {
+		absPath, err := fs.ToAbsoluteFilePath(path)
+		if err != nil {
+			return nil, err
+		}
+		absPaths = append(absPaths, absPath)
+	}
+	return absPaths, nil
+}
+
 func generateZoneFile() error {
-	hclog.L().Info("generating BIND zone file(s)", "outputDir", outputDir, "inputFile", inputFile)
-	zones, err := parser.Parse(inputFile)
+	hclog.L().Info("generating BIND zone file(s)", "outputDir", outputDir, "inputFiles", inputFiles)
+	zones, reverseZones, catalogZones, err := buildZones()
 	if err != nil {
-		return fmt.Errorf("failed to parse input file %s: %w", inputFile, err)
+		return err
+	}
+
+	// Now that every zone is fully populated, decide which generated serial numbers are actually needed
+	var serialCommits []*serialCommit
+	for _, zoneSet := range []map[string]*models.Zone{zones, reverseZones, catalogZones} {
+		commits, err := prepareSerials(zoneSet)
+		if err != nil {
+			return err
+		}
+		serialCommits = append(serialCommits, commits...)
+	}
+
+	// Pass 2: write everything now that every zone is fully populated. Every zone file is staged in a single
+	// transaction and only moved into place once all of them have been rendered, on any error the existing zone files
+	// are left untouched.
+	tx := fs.NewTransaction()
+	defer tx.Rollback()
+
+	if err := withEveryZone(zones, reverseZones, catalogZones, func(name string, zone *models.Zone) error {
+		return zoneFileGenerator.GenerateZone(name, zone, outputDir, tx)
+	}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// Pass 3: only now that every zone file has been written are the generated serial numbers used up, a failure
+	// above leaves the change indexes untouched so the next run hands out the same serial numbers again.
+	return commitSerials(serialCommits)
+}
+
+// buildZones parses the input file and computes the full set of forward, reverse and catalog zones without writing anything
+func buildZones() (map[string]*models.Zone, map[string]*models.Zone, map[string]*models.Zone, error) {
+	zones, err := parser.Parse(inputFiles)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to parse input file %s: %w", strings.Join(inputFiles, ", "), err)
 	}
 
 	var memberZoneNames []string
@@ -98,38 +158,116 @@ func generateZoneFile() error {
 		}
 		return mergeReverseZones(reverseZones, zoneReverseZones)
 	}); err != nil {
-		return err
+		return nil, nil, nil, err
 	}
 
 	if len(reverseZones) > 0 {
 		if err := normalizer.Normalize(reverseZones); err != nil {
-			return err
+			return nil, nil, nil, err
 		}
 	}
 
 	if err := populateCatalogZones(catalogZones, memberZoneNames, reverseZones); err != nil {
-		return err
+		return nil, nil, nil, err
+	}
+
+	// Warnings don't stop the zone files from being generated, the parser normalizes the zones with the same normalizer
+	for _, warning := range normalizer.Warnings() {
+		hclog.L().Warn("input file has a warning", "warning", warning)
 	}
 
-	// Pass 2: write everything now that every zone is fully populated.
+	return zones, reverseZones, catalogZones, nil
+}
+
+// withEveryZone calls fn for every forward zone, then every reverse zone and then every catalog zone
+func withEveryZone(zones map[string]*models.Zone, reverseZones map[string]*models.Zone, catalogZones map[string]*models.Zone, fn func(name string, zone *models.Zone) error) error {
 	if err := models.WithSortedZones(zones, func(name string, zone *models.Zone) error {
 		if zone.Config.IsCatalog {
 			return nil
 		}
-		return zoneFileGenerator.GenerateZone(name, zone, outputDir)
+		return fn(name, zone)
 	}); err != nil {
 		return err
 	}
 
-	if err := models.WithSortedZones(reverseZones, func(name string, zone *models.Zone) error {
-		return zoneFileGenerator.GenerateZone(name, zone, outputDir)
-	}); err != nil {
+	if err := models.WithSortedZones(reverseZones, fn); err != nil {
 		return err
 	}
 
-	return models.WithSortedZones(catalogZones, func(name string, zone *models.Zone) error {
-		return zoneFileGenerator.GenerateZone(name, zone, outputDir)
+	return models.WithSortedZones(catalogZones, fn)
+}
+
+// serialCommit is a generated serial number that needs to be committed once every zone file has been written
+type serialCommit struct {
+	zoneName    string
+	directory   string
+	serial      string
+	contentHash string
+}
+
+// prepareSerials compares the content of every zone that had its serial number generated during normalization to the
+// content its current serial number was committed for. Unchanged zones get their current serial number back so the
+// secondaries aren't notified about a change that didn't happen, changed zones are returned so they can be committed.
+func prepareSerials(zones map[string]*models.Zone) ([]*serialCommit, error) {
+	var commits []*serialCommit
+	err := models.WithSortedZones(zones, func(name string, zone *models.Zone) error {
+		if zone.Config == nil || !zone.Config.GenerateSerial {
+			return nil
+		}
+
+		soa := zone.SOARecord()
+		if soa == nil || len(soa.Values) < 3 {
+			return fmt.Errorf("unable to determine the serial number for zone '%s', it has no normalized SOA record", name)
+		}
+
+		contentHash, err := zoneContentHash(zone)
+		if err != nil {
+			return err
+		}
+
+		// The SOA plugin keys the change index on the name of the SOA record which is also the name of the zone
+		currentSerial, currentHash, err := newSerialManager(zone.Config.SerialChangeIndexDirectory).Current(soa.Name)
+		if err != nil {
+			return err
+		}
+
+		if currentSerial != "" && currentHash == contentHash {
+			hclog.L().Debug("zone content is unchanged, reusing the current serial number", "zone", name, "serial", currentSerial)
+			soa.Values[2].Value = currentSerial
+			return nil
+		}
+
+		commits = append(commits, &serialCommit{zoneName: soa.Name, directory: zone.Config.SerialChangeIndexDirectory, serial: soa.Values[2].Value, contentHash: contentHash})
+		return nil
 	})
+	return commits, err
+}
+
+// commitSerials commits the serial numbers of the zones whose content changed
+func commitSerials(commits []*serialCommit) error {
+	for _, c := range commits {
+		hclog.L().Debug("committing serial number", "zone", c.zoneName, "serial", c.serial)
+		if err := newSerialManager(c.directory).Commit(c.zoneName, c.serial, c.contentHash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// zoneContentHash returns a SHA-256 hash of the normalized zone, the serial number is left out as it is generated
+// fresh on every run and would make every zone look changed.
+func zoneContentHash(zone *models.Zone) (string, error) {
+	serialValue := zone.SOARecord().Values[2]
+	serial := serialValue.Value
+	serialValue.Value = ""
+	defer func() { serialValue.Value = serial }()
+
+	content, err := yaml.Marshal(zone)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:]), nil
 }
 
 // mergeReverseZones merges newZones into accumulated. The first source zone (in processing order) to
@@ -151,7 +289,7 @@ func mergeReverseZones(accumulated, newZones map[string]*models.Zone) error {
 			}
 			if existingRR, ok := existing.ResourceRecords[identifier]; ok {
 				if existingRR.Value != rr.Value {
-					return fmt.Errorf("conflicting PTR record for '%s' in reverse zone '%s': '%s' vs '%s'", identifier, zoneName, existingRR.Value, rr.Value)
+					return fmt.Errorf("conflicting PTR record for '%s' in reverse zone '%s': '%s' vs '%s', set reverse to false or ptr_name on one of the records", identifier, zoneName, existingRR.Value, rr.Value)
 				}
 				continue
 			}
@@ -178,7 +316,7 @@ func populateCatalogZones(catalogZones map[string]*models.Zone, memberZoneNames
 }
 
 func init() {
-	generateCmd.Flags().StringVar(&inputFile, "input-file", "zones.yaml", "Input YAML file")
+	generateCmd.Flags().StringSliceVar(&inputFiles, "input-file", []string{"zones.yaml"}, "Input YAML file(s), globs and directories, can be repeated or comma separated")
 	cobra.CheckErr(generateCmd.MarkFlagRequired("input-file"))
 	generateCmd.Flags().StringVar(&outputDir, "output-dir", ".", "Directory to output the BIND zone file(s) to")
 
@@ -21,9 +21,12 @@ package cmd
 
 import (
 	"errors"
+	"slices"
 	"testing"
 
 	"github.com/bcurnow/zonemgr/models"
+	"github.com/bcurnow/zonemgr/utils"
+	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/viper"
 )
 
@@ -45,6 +48,8 @@ func TestPreRunE_Generate(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
+		// Repeated string slice flags append to the current value
+		inputFiles = nil
 		v = viper.New()
 		v.BindPFlags(generateCmd.Flags())
 		call := mockFs.EXPECT().ToAbsoluteFilePath("testing-dir")
@@ -92,9 +97,8 @@ func TestPreRunE_Generate(t *testing.T) {
 				t.Errorf("incorrect output dir: '%s', want: '%s'", outputDir, want)
 			}
 
-			want = "testing"
-			if inputFile != want {
-				t.Errorf("incorrect input file dir: '%s', want: '%s'", inputFile, want)
+			if !slices.Equal(inputFiles, []string{"testing"}) {
+				t.Errorf("incorrect input files: %v, want: %v", inputFiles, []string{"testing"})
 			}
 
 			if zoneFileGenerator == mockZoneFileGenerator {
@@ -135,10 +139,10 @@ func TestRunE_Generate(t *testing.T) {
 		{reverseZoneFileGeneratorErr: true},
 	}
 
-	inputFile = "testing"
+	inputFiles = []string{"testing"}
 	outputDir = "testing-dir"
 	for _, tc := range testCases {
-		call := mockParser.EXPECT().Parse(inputFile)
+		call := mockParser.EXPECT().Parse(inputFiles)
 		if tc.parseErr {
 			call.Return(nil, errors.New("parseErr"))
 		} else {
@@ -168,19 +172,21 @@ func TestRunE_Generate(t *testing.T) {
 				} else {
 					call.Return(nil)
 
-					call = mockZoneFileGenerator.EXPECT().GenerateZone("one", zoneOne, outputDir)
+					expectTransaction(false)
+					call = mockZoneFileGenerator.EXPECT().GenerateZone("one", zoneOne, outputDir, mockFileTransaction)
 					if tc.zoneFileGeneratorErr {
 						call.Return(errors.New("zoneFileGeneratorErr"))
 					} else {
 						call.Return(nil)
-						mockZoneFileGenerator.EXPECT().GenerateZone("two", zoneTwo, outputDir).Return(nil)
+						mockZoneFileGenerator.EXPECT().GenerateZone("two", zoneTwo, outputDir, mockFileTransaction).Return(nil)
 
-						call = mockZoneFileGenerator.EXPECT().GenerateZone("reverse-one", reverseZoneOne, outputDir)
+						call = mockZoneFileGenerator.EXPECT().GenerateZone("reverse-one", reverseZoneOne, outputDir, mockFileTransaction)
 						if tc.reverseZoneFileGeneratorErr {
 							call.Return(errors.New("reverseZoneFileGeneratorErr"))
 						} else {
 							call.Return(nil)
-							mockZoneFileGenerator.EXPECT().GenerateZone("reverse-two", reverseZoneTwo, outputDir).Return(nil)
+							mockZoneFileGenerator.EXPECT().GenerateZone("reverse-two", reverseZoneTwo, outputDir, mockFileTransaction).Return(nil)
+							mockFileTransaction.EXPECT().Commit().Return(nil)
 						}
 					}
 				}
@@ -216,7 +222,7 @@ func TestRunE_Generate_CatalogZone(t *testing.T) {
 	setup(t)
 	defer teardown(t)
 
-	inputFile = "testing"
+	inputFiles = []string{"testing"}
 	outputDir = "testing-dir"
 
 	zoneOne := &models.Zone{Config: &models.Config{}}
@@ -226,10 +232,11 @@ func TestRunE_Generate_CatalogZone(t *testing.T) {
 		"catalog.example.com.": catalogZone,
 	}
 
-	mockParser.EXPECT().Parse(inputFile).Return(zones, nil)
+	mockParser.EXPECT().Parse(inputFiles).Return(zones, nil)
 	mockCatalogGenerator.EXPECT().AddCatalogRecords("catalog.example.com.", catalogZone, []string{"one"}).Return(nil)
-	mockZoneFileGenerator.EXPECT().GenerateZone("one", zoneOne, outputDir).Return(nil)
-	mockZoneFileGenerator.EXPECT().GenerateZone("catalog.example.com.", catalogZone, outputDir).Return(nil)
+	expectTransaction(true)
+	mockZoneFileGenerator.EXPECT().GenerateZone("one", zoneOne, outputDir, mockFileTransaction).Return(nil)
+	mockZoneFileGenerator.EXPECT().GenerateZone("catalog.example.com.", catalogZone, outputDir, mockFileTransaction).Return(nil)
 
 	if err := generateCmd.RunE(generateCmd, []string{}); err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -240,7 +247,7 @@ func TestRunE_Generate_CatalogZone_IncludeReverseZones(t *testing.T) {
 	setup(t)
 	defer teardown(t)
 
-	inputFile = "testing"
+	inputFiles = []string{"testing"}
 	outputDir = "testing-dir"
 
 	zoneOne := &models.Zone{Config: &models.Config{GenerateReverseLookupZones: true}}
@@ -253,13 +260,14 @@ func TestRunE_Generate_CatalogZone_IncludeReverseZones(t *testing.T) {
 		"reverse.arpa.": {},
 	}
 
-	mockParser.EXPECT().Parse(inputFile).Return(zones, nil)
+	mockParser.EXPECT().Parse(inputFiles).Return(zones, nil)
 	mockZoneReverser.EXPECT().ReverseZone("one", zoneOne).Return(reverseZones, nil)
 	mockNormalizer.EXPECT().Normalize(reverseZones).Return(nil)
 	mockCatalogGenerator.EXPECT().AddCatalogRecords("catalog.example.com.", catalogZone, []string{"one", "reverse.arpa."}).Return(nil)
-	mockZoneFileGenerator.EXPECT().GenerateZone("one", zoneOne, outputDir).Return(nil)
-	mockZoneFileGenerator.EXPECT().GenerateZone("reverse.arpa.", reverseZones["reverse.arpa."], outputDir).Return(nil)
-	mockZoneFileGenerator.EXPECT().GenerateZone("catalog.example.com.", catalogZone, outputDir).Return(nil)
+	expectTransaction(true)
+	mockZoneFileGenerator.EXPECT().GenerateZone("one", zoneOne, outputDir, mockFileTransaction).Return(nil)
+	mockZoneFileGenerator.EXPECT().GenerateZone("reverse.arpa.", reverseZones["reverse.arpa."], outputDir, mockFileTransaction).Return(nil)
+	mockZoneFileGenerator.EXPECT().GenerateZone("catalog.example.com.", catalogZone, outputDir, mockFileTransaction).Return(nil)
 
 	if err := generateCmd.RunE(generateCmd, []string{}); err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -270,7 +278,7 @@ func TestRunE_Generate_CatalogGeneratorErr(t *testing.T) {
 	setup(t)
 	defer teardown(t)
 
-	inputFile = "testing"
+	inputFiles = []string{"testing"}
 	outputDir = "testing-dir"
 
 	zoneOne := &models.Zone{Config: &models.Config{}}
@@ -280,7 +288,7 @@ func TestRunE_Generate_CatalogGeneratorErr(t *testing.T) {
 		"catalog.example.com.": catalogZone,
 	}
 
-	mockParser.EXPECT().Parse(inputFile).Return(zones, nil)
+	mockParser.EXPECT().Parse(inputFiles).Return(zones, nil)
 	mockCatalogGenerator.EXPECT().AddCatalogRecords("catalog.example.com.", catalogZone, []string{"one"}).Return(errors.New("catalogGeneratorErr"))
 	// No GenerateZone calls are expected: nothing should be written until every zone, including
 	// catalog zones, has been fully computed.
@@ -298,7 +306,7 @@ func TestRunE_Generate_MergesReverseZonesFromMultipleSourceZones(t *testing.T) {
 	setup(t)
 	defer teardown(t)
 
-	inputFile = "testing"
+	inputFiles = []string{"testing"}
 	outputDir = "testing-dir"
 
 	zoneOne := &models.Zone{Config: &models.Config{GenerateReverseLookupZones: true}}
@@ -321,13 +329,14 @@ func TestRunE_Generate_MergesReverseZonesFromMultipleSourceZones(t *testing.T) {
 		},
 	}
 
-	mockParser.EXPECT().Parse(inputFile).Return(zones, nil)
+	mockParser.EXPECT().Parse(inputFiles).Return(zones, nil)
 	mockZoneReverser.EXPECT().ReverseZone("one", zoneOne).Return(map[string]*models.Zone{"shared.arpa.": sharedZoneFromOne}, nil)
 	mockZoneReverser.EXPECT().ReverseZone("two", zoneTwo).Return(map[string]*models.Zone{"shared.arpa.": sharedZoneFromTwo}, nil)
 	mockNormalizer.EXPECT().Normalize(map[string]*models.Zone{"shared.arpa.": sharedZoneFromOne}).Return(nil)
-	mockZoneFileGenerator.EXPECT().GenerateZone("one", zoneOne, outputDir).Return(nil)
-	mockZoneFileGenerator.EXPECT().GenerateZone("two", zoneTwo, outputDir).Return(nil)
-	mockZoneFileGenerator.EXPECT().GenerateZone("shared.arpa.", sharedZoneFromOne, outputDir).Return(nil)
+	expectTransaction(true)
+	mockZoneFileGenerator.EXPECT().GenerateZone("one", zoneOne, outputDir, mockFileTransaction).Return(nil)
+	mockZoneFileGenerator.EXPECT().GenerateZone("two", zoneTwo, outputDir, mockFileTransaction).Return(nil)
+	mockZoneFileGenerator.EXPECT().GenerateZone("shared.arpa.", sharedZoneFromOne, outputDir, mockFileTransaction).Return(nil)
 
 	if err := generateCmd.RunE(generateCmd, []string{}); err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -346,7 +355,7 @@ func TestRunE_Generate_MergesReverseZones_Conflict(t *testing.T) {
 	setup(t)
 	defer teardown(t)
 
-	inputFile = "testing"
+	inputFiles = []string{"testing"}
 	outputDir = "testing-dir"
 
 	zoneOne := &models.Zone{Config: &models.Config{GenerateReverseLookupZones: true}}
@@ -367,7 +376,7 @@ func TestRunE_Generate_MergesReverseZones_Conflict(t *testing.T) {
 		},
 	}
 
-	mockParser.EXPECT().Parse(inputFile).Return(zones, nil)
+	mockParser.EXPECT().Parse(inputFiles).Return(zones, nil)
 	mockZoneReverser.EXPECT().ReverseZone("one", zoneOne).Return(map[string]*models.Zone{"shared.arpa.": sharedZoneFromOne}, nil)
 	mockZoneReverser.EXPECT().ReverseZone("two", zoneTwo).Return(map[string]*models.Zone{"shared.arpa.": sharedZoneFromTwo}, nil)
 	// No further calls are expected: the conflict is detected during pass 1, before Normalize or any writes.
@@ -377,3 +386,223 @@ func TestRunE_Generate_MergesReverseZones_Conflict(t *testing.T) {
 		t.Fatal("expected an error, found none")
 	}
 }
+
+func TestRunE_Generate_CommitsSerials(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	inputFiles = []string{"testing"}
+	outputDir = "testing-dir"
+
+	soa := func(name string, serial string) *models.ResourceRecord {
+		return &models.ResourceRecord{Name: name, Type: models.SOA, Values: []*models.ResourceRecordValue{{Value: "ns1.example.com."}, {Value: "admin.example.com."}, {Value: serial}}}
+	}
+	zoneOne := &models.Zone{Config: &models.Config{GenerateSerial: true}, ResourceRecords: map[string]*models.ResourceRecord{"soa": soa("one.", "2025080301")}}
+	zoneTwo := &models.Zone{Config: &models.Config{}, ResourceRecords: map[string]*models.ResourceRecord{"soa": soa("two.", "1")}}
+	zones := map[string]*models.Zone{"one.": zoneOne, "two.": zoneTwo}
+	hash, err := zoneContentHash(zoneOne)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockParser.EXPECT().Parse(inputFiles).Return(zones, nil)
+		mockSerialManager.EXPECT().Current("one.").Return("", "", nil)
+		mockFs.EXPECT().NewTransaction().Return(mockFileTransaction)
+		mockFileTransaction.EXPECT().Rollback()
+		first := mockZoneFileGenerator.EXPECT().GenerateZone("one.", zoneOne, outputDir, mockFileTransaction).Return(nil)
+		second := mockZoneFileGenerator.EXPECT().GenerateZone("two.", zoneTwo, outputDir, mockFileTransaction).Return(nil)
+		written := mockFileTransaction.EXPECT().Commit().Return(nil).After(first).After(second)
+		// Only the zone with a generated serial number is committed and only after every zone file has been written
+		mockSerialManager.EXPECT().Commit("one.", "2025080301", hash).Return(nil).After(written)
+
+		if err := generateCmd.RunE(generateCmd, []string{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("write-error", func(t *testing.T) {
+		mockParser.EXPECT().Parse(inputFiles).Return(zones, nil)
+		mockSerialManager.EXPECT().Current("one.").Return("", "", nil)
+		expectTransaction(false)
+		mockZoneFileGenerator.EXPECT().GenerateZone("one.", zoneOne, outputDir, mockFileTransaction).Return(nil)
+		mockZoneFileGenerator.EXPECT().GenerateZone("two.", zoneTwo, outputDir, mockFileTransaction).Return(errors.New("zoneFileGeneratorErr"))
+		// No Commit calls are expected: the serial number must not be used up when a zone file fails to write
+
+		if err := generateCmd.RunE(generateCmd, []string{}); err == nil || err.Error() != "zoneFileGeneratorErr" {
+			t.Errorf("incorrect error: '%v', want: 'zoneFileGeneratorErr'", err)
+		}
+	})
+
+	t.Run("commit-error", func(t *testing.T) {
+		mockParser.EXPECT().Parse(inputFiles).Return(zones, nil)
+		mockSerialManager.EXPECT().Current("one.").Return("", "", nil)
+		expectTransaction(true)
+		mockZoneFileGenerator.EXPECT().GenerateZone("one.", zoneOne, outputDir, mockFileTransaction).Return(nil)
+		mockZoneFileGenerator.EXPECT().GenerateZone("two.", zoneTwo, outputDir, mockFileTransaction).Return(nil)
+		mockSerialManager.EXPECT().Commit("one.", "2025080301", hash).Return(errors.New("commitErr"))
+
+		if err := generateCmd.RunE(generateCmd, []string{}); err == nil || err.Error() != "commitErr" {
+			t.Errorf("incorrect error: '%v', want: 'commitErr'", err)
+		}
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		mockParser.EXPECT().Parse(inputFiles).Return(zones, nil)
+		mockSerialManager.EXPECT().Current("one.").Return("2025080207", hash, nil)
+		expectTransaction(true)
+		// The zone file is written with the current serial number and nothing is committed
+		mockZoneFileGenerator.EXPECT().GenerateZone("one.", zoneOne, outputDir, mockFileTransaction).DoAndReturn(func(_ string, zone *models.Zone, _ string, _ utils.FileTransaction) error {
+			if serial := zone.SOARecord().Values[2].Value; serial != "2025080207" {
+				t.Errorf("incorrect serial number: '%s', want: '2025080207'", serial)
+			}
+			return nil
+		})
+		mockZoneFileGenerator.EXPECT().GenerateZone("two.", zoneTwo, outputDir, mockFileTransaction).Return(nil)
+
+		if err := generateCmd.RunE(generateCmd, []string{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestRunE_Generate_TransactionCommitErr(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	inputFiles = []string{"testing"}
+	outputDir = "testing-dir"
+
+	zoneOne := &models.Zone{Config: &models.Config{GenerateSerial: true}, ResourceRecords: map[string]*models.ResourceRecord{
+		"soa": {Name: "one.", Type: models.SOA, Values: []*models.ResourceRecordValue{{Value: "ns1"}, {Value: "admin"}, {Value: "1"}}},
+	}}
+	mockParser.EXPECT().Parse(inputFiles).Return(map[string]*models.Zone{"one.": zoneOne}, nil)
+	mockSerialManager.EXPECT().Current("one.").Return("", "", nil)
+	mockFs.EXPECT().NewTransaction().Return(mockFileTransaction)
+	mockFileTransaction.EXPECT().Rollback()
+	mockZoneFileGenerator.EXPECT().GenerateZone("one.", zoneOne, outputDir, mockFileTransaction).Return(nil)
+	mockFileTransaction.EXPECT().Commit().Return(errors.New("commitErr"))
+	// No serial number is committed when the zone files couldn't be moved into place
+
+	if err := generateCmd.RunE(generateCmd, []string{}); err == nil || err.Error() != "commitErr" {
+		t.Errorf("incorrect error: '%v', want: 'commitErr'", err)
+	}
+}
+
+func TestPrepareSerials(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	soaZone := func(serial string) *models.Zone {
+		return &models.Zone{
+			Config: &models.Config{GenerateSerial: true},
+			ResourceRecords: map[string]*models.ResourceRecord{
+				"soa": {Name: "testing.", Type: models.SOA, Values: []*models.ResourceRecordValue{{Value: "ns1"}, {Value: "admin"}, {Value: serial}}},
+			},
+		}
+	}
+	hash, err := zoneContentHash(soaZone("2"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		zone          *models.Zone
+		current       bool
+		currentSerial string
+		currentHash   string
+		currentErr    bool
+		wantCommit    bool
+		wantSerial    string
+		want          string
+	}{
+		{zone: &models.Zone{}},
+		{zone: &models.Zone{Config: &models.Config{}}},
+		{zone: &models.Zone{Config: &models.Config{GenerateSerial: true}}, want: "unable to determine the serial number for zone 'testing.', it has no normalized SOA record"},
+		{
+			zone: &models.Zone{Config: &models.Config{GenerateSerial: true}, ResourceRecords: map[string]*models.ResourceRecord{"soa": {Type: models.SOA, Values: []*models.ResourceRecordValue{{Value: "ns1"}}}}},
+			want: "unable to determine the serial number for zone 'testing.', it has no normalized SOA record",
+		},
+		{zone: soaZone("2"), current: true, currentErr: true, want: "currentErr"},
+		{zone: soaZone("2"), current: true, wantCommit: true, wantSerial: "2"},
+		{zone: soaZone("2"), current: true, currentSerial: "1", currentHash: "different", wantCommit: true, wantSerial: "2"},
+		// The serial number is ignored by the hash so a different generated serial number doesn't count as a change
+		{zone: soaZone("3"), current: true, currentSerial: "1", currentHash: hash, wantSerial: "1"},
+	}
+
+	for _, tc := range testCases {
+		if tc.current {
+			call := mockSerialManager.EXPECT().Current("testing.")
+			if tc.currentErr {
+				call.Return("", "", errors.New("currentErr"))
+			} else {
+				call.Return(tc.currentSerial, tc.currentHash, nil)
+			}
+		}
+
+		commits, err := prepareSerials(map[string]*models.Zone{"testing.": tc.zone})
+		if err != nil {
+			if err.Error() != tc.want {
+				t.Errorf("incorrect error: '%s', want: '%s'", err, tc.want)
+			}
+			continue
+		}
+		if tc.want != "" {
+			t.Errorf("expected error '%s', found none", tc.want)
+		}
+
+		if tc.wantCommit {
+			want := []*serialCommit{{zoneName: "testing.", serial: tc.wantSerial, contentHash: hash}}
+			if diff := cmp.Diff(want, commits, cmp.AllowUnexported(serialCommit{})); diff != "" {
+				t.Errorf("incorrect commits (-want +got):\n%s", diff)
+			}
+		} else if len(commits) != 0 {
+			t.Errorf("expected no commits, found: %v", commits)
+		}
+
+		if tc.wantSerial != "" {
+			if serial := tc.zone.SOARecord().Values[2].Value; serial != tc.wantSerial {
+				t.Errorf("incorrect serial number: '%s', want: '%s'", serial, tc.wantSerial)
+			}
+		}
+	}
+}
+
+func TestZoneContentHash(t *testing.T) {
+	zone := &models.Zone{
+		Config: &models.Config{GenerateSerial: true},
+		ResourceRecords: map[string]*models.ResourceRecord{
+			"soa": {Name: "testing.", Type: models.SOA, Values: []*models.ResourceRecordValue{{Value: "ns1"}, {Value: "admin"}, {Value: "1"}}},
+			"www": {Name: "www", Type: models.A, Value: "192.0.2.1"},
+		},
+	}
+
+	first, err := zoneContentHash(zone)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if serial := zone.SOARecord().Values[2].Value; serial != "1" {
+		t.Errorf("the serial number was not restored, found: '%s'", serial)
+	}
+
+	zone.SOARecord().Values[2].Value = "2"
+	second, _ := zoneContentHash(zone)
+	if first != second {
+		t.Error("expected the serial number to not change the hash")
+	}
+
+	zone.ResourceRecords["www"].Value = "192.0.2.2"
+	third, _ := zoneContentHash(zone)
+	if first == third {
+		t.Error("expected a changed resource record to change the hash")
+	}
+}
+
+// Expects a transaction to be created and rolled back (a nop once committed) and, if commit is true, to be committed
+func expectTransaction(commit bool) {
+	mockFs.EXPECT().NewTransaction().Return(mockFileTransaction)
+	mockFileTransaction.EXPECT().Rollback()
+	if commit {
+		mockFileTransaction.EXPECT().Commit().Return(nil)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 Brian Curnow
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/bcurnow/zonemgr/dns"
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/bcurnow/zonemgr/utils"
+	"github.com/hashicorp/go-hclog"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importCmd = &cobra.Command{
+		Use:   "import <zone file>...",
+		Short: "Converts existing BIND zone files into zonemgr YAML",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return importZoneFiles(args)
+		},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			absOutputFile, err := fs.ToAbsoluteFilePath(importOutputFile)
+			if err != nil {
+				return err
+			}
+			importOutputFile = absOutputFile
+
+			for i, zoneFile := range args {
+				absZoneFile, err := fs.ToAbsoluteFilePath(zoneFile)
+				if err != nil {
+					return err
+				}
+				args[i] = absZoneFile
+			}
+
+			return nil
+		},
+	}
+
+	importOrigin     string
+	importOutputFile string
+	importOverwrite  bool
+	zoneImporter     dns.ZoneImporter                        = dns.BindZoneImporter()
+	zoneYamlFile     utils.YamlFile[map[string]*models.Zone] = &utils.ZoneYamlFile{}
+)
+
+func importZoneFiles(zoneFiles []string) error {
+	if !importOverwrite && fs.Exists(importOutputFile) {
+		return fmt.Errorf("output file %s already exists, use --overwrite to replace it", importOutputFile)
+	}
+
+	zones := make(map[string]*models.Zone)
+	for _, zoneFile := range zoneFiles {
+		hclog.L().Info("importing BIND zone file", "zoneFile", zoneFile, "origin", importOrigin)
+		imported, err := zoneImporter.Import(zoneFile, importOrigin)
+		if err != nil {
+			return fmt.Errorf("failed to import zone file %s: %w", zoneFile, err)
+		}
+
+		for name, zone := range imported {
+			if _, ok := zones[name]; ok {
+				return fmt.Errorf("zone %s is defined by more than one zone file, found again in %s", name, zoneFile)
+			}
+			zones[name] = zone
+		}
+	}
+
+	hclog.L().Info("writing YAML", "outputFile", importOutputFile, "zones", len(zones))
+	return zoneYamlFile.Write(importOutputFile, zones)
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importOrigin, "origin", "", "The origin used for relative names until a zone file sets its own with $ORIGIN")
+	importCmd.Flags().StringVar(&importOutputFile, "output-file", "zones.yaml", "The YAML file to write the imported zones to")
+	importCmd.Flags().BoolVar(&importOverwrite, "overwrite", false, "If set, replaces the output file when it already exists")
+
+	rootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,176 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/bcurnow/zonemgr/utils"
+)
+
+// Records what would have been written instead of writing a file
+type testZoneYamlFile struct {
+	path     string
+	zones    map[string]*models.Zone
+	writeErr error
+}
+
+func (f *testZoneYamlFile) Read(path string) (map[string]*models.Zone, error) {
+	return nil, errors.New("not used")
+}
+
+func (f *testZoneYamlFile) Write(path string, content map[string]*models.Zone) error {
+	f.path = path
+	f.zones = content
+	return f.writeErr
+}
+
+func TestPreRunE_Import(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []struct {
+		absErrOutput bool
+		absErrInput  bool
+	}{
+		{},
+		{absErrOutput: true},
+		{absErrInput: true},
+	}
+
+	for _, tc := range testCases {
+		call := mockFs.EXPECT().ToAbsoluteFilePath("testing.yaml")
+		if tc.absErrOutput {
+			call.Return("", errors.New("absErrOutput"))
+		} else {
+			call.Return("/abs/testing.yaml", nil)
+			call = mockFs.EXPECT().ToAbsoluteFilePath("db.example.com")
+			if tc.absErrInput {
+				call.Return("", errors.New("absErrInput"))
+			} else {
+				call.Return("/abs/db.example.com", nil)
+			}
+		}
+
+		args := []string{"db.example.com"}
+		importCmd.ParseFlags([]string{"--output-file", "testing.yaml"})
+		if err := importCmd.PreRunE(importCmd, args); err != nil {
+			want := ""
+			if tc.absErrOutput {
+				want = "absErrOutput"
+			} else if tc.absErrInput {
+				want = "absErrInput"
+			}
+
+			if err.Error() != want {
+				t.Errorf("incorrect error: '%s', want: '%s'", err, want)
+			}
+		} else {
+			if tc.absErrOutput || tc.absErrInput {
+				t.Error("expected an error, found none")
+			}
+
+			if importOutputFile != "/abs/testing.yaml" {
+				t.Errorf("incorrect output file: '%s', want: '/abs/testing.yaml'", importOutputFile)
+			}
+
+			if args[0] != "/abs/db.example.com" {
+				t.Errorf("incorrect zone file: '%s', want: '/abs/db.example.com'", args[0])
+			}
+		}
+	}
+}
+
+func TestRunE_Import(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+	defer func() { zoneYamlFile = &utils.ZoneYamlFile{} }()
+
+	zoneOne := &models.Zone{}
+	zoneTwo := &models.Zone{}
+
+	testCases := []struct {
+		exists       bool
+		overwrite    bool
+		importErr    bool
+		duplicate    bool
+		writeErr     bool
+		want         string
+		wantZoneSize int
+	}{
+		{wantZoneSize: 2},
+		{exists: true, want: "output file zones.yaml already exists, use --overwrite to replace it"},
+		{exists: true, overwrite: true, wantZoneSize: 2},
+		{importErr: true, want: "failed to import zone file db.one: importErr"},
+		{duplicate: true, want: "zone one. is defined by more than one zone file, found again in db.two"},
+		{writeErr: true, want: "writeErr"},
+	}
+
+	for _, tc := range testCases {
+		importOutputFile = "zones.yaml"
+		importOverwrite = tc.overwrite
+		importOrigin = ""
+		yamlFile := &testZoneYamlFile{}
+		if tc.writeErr {
+			yamlFile.writeErr = errors.New("writeErr")
+		}
+		zoneYamlFile = yamlFile
+
+		if !tc.overwrite {
+			mockFs.EXPECT().Exists("zones.yaml").Return(tc.exists)
+		}
+
+		if !tc.exists || tc.overwrite {
+			call := mockZoneImporter.EXPECT().Import("db.one", "")
+			if tc.importErr {
+				call.Return(nil, errors.New("importErr"))
+			} else {
+				call.Return(map[string]*models.Zone{"one.": zoneOne}, nil)
+				if tc.duplicate {
+					mockZoneImporter.EXPECT().Import("db.two", "").Return(map[string]*models.Zone{"one.": zoneTwo}, nil)
+				} else {
+					mockZoneImporter.EXPECT().Import("db.two", "").Return(map[string]*models.Zone{"two.": zoneTwo}, nil)
+				}
+			}
+		}
+
+		err := importCmd.RunE(importCmd, []string{"db.one", "db.two"})
+		if err != nil {
+			if err.Error() != tc.want {
+				t.Errorf("incorrect error: '%s', want: '%s'", err, tc.want)
+			}
+			continue
+		}
+
+		if tc.want != "" {
+			t.Errorf("expected error '%s', found none", tc.want)
+		}
+
+		if yamlFile.path != "zones.yaml" {
+			t.Errorf("incorrect output file: '%s', want: 'zones.yaml'", yamlFile.path)
+		}
+
+		if len(yamlFile.zones) != tc.wantZoneSize || yamlFile.zones["one."] != zoneOne || yamlFile.zones["two."] != zoneTwo {
+			t.Errorf("incorrect zones written: %v", yamlFile.zones)
+		}
+	}
+}
@@ -17,9 +17,13 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/bcurnow/zonemgr/models"
 	"github.com/bcurnow/zonemgr/plugins/plugin_manager"
 	"github.com/bcurnow/zonemgr/utils"
 	"github.com/hashicorp/go-hclog"
@@ -59,6 +63,8 @@ var (
 	fs             utils.FileSystemOperations   = &utils.FileSystem{}
 	v              *viper.Viper
 	cleanupClients = goplugin.CleanupClients
+
+	defaultConfigFile = filepath.Join(fs.HomeDir(), ".config", "zonemgr", "config.yaml")
 )
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -74,6 +80,17 @@ func init() {
 	rootCmd.PersistentFlags().Bool("log-color", false, "If set, prints the log messages in color where possible")
 	rootCmd.PersistentFlags().Bool("plugin-debug", false, "If set, will including plugin stdout/stderr in the log messages")
 	rootCmd.PersistentFlags().String("plugin-dir", filepath.Join(fs.HomeDir(), ".local", "share", "zonemgr", "plugins"), "The directory to find Zonemgr plugins")
+	rootCmd.PersistentFlags().String("config", defaultConfigFile, "The config file to read settings from, it is only required to exist when set explicitly")
+	rootCmd.PersistentFlags().Bool("generate-serial", false, "The default for generate_serial when a zone doesn't set it")
+	rootCmd.PersistentFlags().String("serial-change-index-directory", "", "The default for serial_change_index_directory when a zone doesn't set it")
+	rootCmd.PersistentFlags().Bool("generate-reverse-lookup-zones", false, "The default for generate_reverse_lookup_zones when a zone doesn't set it")
+	rootCmd.PersistentFlags().Bool("catalog-include-reverse-zones", false, "The default for catalog_include_reverse_zones when a zone doesn't set it")
+	rootCmd.PersistentFlags().String("record-order", "", "The default for record_order when a zone doesn't set it (name, type, identifier)")
+	rootCmd.PersistentFlags().Bool("keep-time-units", false, "The default for keep_time_units when a zone doesn't set it")
+	rootCmd.PersistentFlags().String("ptr-policy", "", "The default for ptr_policy when a zone doesn't set it (error, first, all)")
+	rootCmd.PersistentFlags().String("cname-policy", "", "The default for cname_policy when a zone doesn't set it (managed, strict, none)")
+	rootCmd.PersistentFlags().String("delegation-policy", "", "The default for delegation_policy when a zone doesn't set it (generate, verify, none)")
+	rootCmd.PersistentFlags().StringSlice("reverse-zones", nil, "The default for reverse_zones when a zone doesn't set it (e.g. 10.0.0.0/16,192.0.2.0/26)")
 }
 
 func initConfig(cmd *cobra.Command) error {
@@ -91,6 +108,18 @@ func initConfig(cmd *cobra.Command) error {
 
 	v.AutomaticEnv()
 
+	// Settings not passed as a flag or environment variable come from the config file
+	configFile := v.GetString("config")
+	v.SetConfigFile(configFile)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		// Only the default config file is optional, one that was asked for must exist
+		if !errors.Is(err, os.ErrNotExist) || configFile != defaultConfigFile {
+			return fmt.Errorf("unable to read config file '%s': %w", configFile, err)
+		}
+		hclog.L().Trace("config file not found, skipping", "configFile", configFile)
+	}
+
 	// Normalize the plugin-dir to an absolute path
 	absPluginDir, err := fs.ToAbsoluteFilePath(v.GetString("plugin-dir"))
 	if err != nil {
@@ -101,6 +130,23 @@ func initConfig(cmd *cobra.Command) error {
 	return nil
 }
 
+// The zone config used for every setting a zone doesn't set itself, is_catalog is never defaulted as it only
+// makes sense for a specific zone
+func configDefaults() *models.Config {
+	return &models.Config{
+		GenerateSerial:             v.GetBool("generate-serial"),
+		SerialChangeIndexDirectory: v.GetString("serial-change-index-directory"),
+		GenerateReverseLookupZones: v.GetBool("generate-reverse-lookup-zones"),
+		CatalogIncludeReverseZones: v.GetBool("catalog-include-reverse-zones"),
+		RecordOrder:                models.RecordOrder(v.GetString("record-order")),
+		KeepTimeUnits:              v.GetBool("keep-time-units"),
+		PTRPolicy:                  models.PTRPolicy(v.GetString("ptr-policy")),
+		ReverseZones:               v.GetStringSlice("reverse-zones"),
+		CNAMEPolicy:                models.CNAMEPolicy(v.GetString("cname-policy")),
+		DelegationPolicy:           models.DelegationPolicy(v.GetString("delegation-policy")),
+	}
+}
+
 func setupLogging() {
 	level := hclog.LevelFromString(v.GetString("log-level"))
 
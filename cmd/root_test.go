@@ -21,12 +21,19 @@ package cmd
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/bcurnow/zonemgr/models"
 	"github.com/bcurnow/zonemgr/plugins/plugin_manager"
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-hclog"
 	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"go.uber.org/mock/gomock"
 )
 
 func TestPersistentPostRun_Root(t *testing.T) {
@@ -167,3 +174,86 @@ func TestPersistentPreRunE_Root(t *testing.T) {
 		})
 	}
 }
+
+func TestInitConfig_ConfigDefaults(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configFile, []byte("generate-serial: true\nserial-change-index-directory: /from/file\nrecord-order: name\nreverse-zones:\n  - 10.0.0.0/16\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	invalidConfigFile := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalidConfigFile, []byte("generate-serial: [\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name string
+		args []string
+		env  map[string]string
+		want *models.Config
+		err  string
+	}{
+		{name: "no-config-file", want: &models.Config{}},
+		{name: "config-file", args: []string{"--config", configFile}, want: &models.Config{GenerateSerial: true, SerialChangeIndexDirectory: "/from/file", RecordOrder: models.RecordOrderName, ReverseZones: []string{"10.0.0.0/16"}}},
+		{
+			name: "flag-overrides-config-file",
+			args: []string{"--config", configFile, "--generate-serial=false", "--record-order", "type", "--generate-reverse-lookup-zones", "--keep-time-units", "--reverse-zones", "192.0.2.0/26,2001:db8::/48", "--cname-policy", "strict"},
+			want: &models.Config{SerialChangeIndexDirectory: "/from/file", GenerateReverseLookupZones: true, RecordOrder: models.RecordOrderType, KeepTimeUnits: true, ReverseZones: []string{"192.0.2.0/26", "2001:db8::/48"}, CNAMEPolicy: models.CNAMEPolicyStrict},
+		},
+		{
+			name: "env-overrides-config-file",
+			args: []string{"--config", configFile},
+			env:  map[string]string{"ZONEMGR_SERIAL_CHANGE_INDEX_DIRECTORY": "/from/env", "ZONEMGR_CATALOG_INCLUDE_REVERSE_ZONES": "true", "ZONEMGR_PTR_POLICY": "first", "ZONEMGR_DELEGATION_POLICY": "verify"},
+			want: &models.Config{GenerateSerial: true, SerialChangeIndexDirectory: "/from/env", CatalogIncludeReverseZones: true, RecordOrder: models.RecordOrderName, PTRPolicy: models.PTRPolicyFirst, ReverseZones: []string{"10.0.0.0/16"}, DelegationPolicy: models.DelegationPolicyVerify},
+		},
+		{name: "missing-config-file", args: []string{"--config", filepath.Join(dir, "missing.yaml")}, err: "unable to read config file '" + filepath.Join(dir, "missing.yaml") + "'"},
+		{name: "invalid-config-file", args: []string{"--config", invalidConfigFile}, err: "unable to read config file '" + invalidConfigFile + "'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			setup(t)
+			defer teardown(t)
+			resetFlags(rootCmd.PersistentFlags())
+			defer resetFlags(rootCmd.PersistentFlags())
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			if tc.err == "" {
+				mockFs.EXPECT().ToAbsoluteFilePath(gomock.Any()).Return("testing", nil)
+			}
+
+			if err := rootCmd.ParseFlags(tc.args); err != nil {
+				t.Fatal(err)
+			}
+			err := initConfig(rootCmd)
+			if tc.err != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tc.err) {
+					t.Errorf("incorrect error: '%v', want prefix: '%s'", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, configDefaults()); diff != "" {
+				t.Errorf("incorrect config defaults:\n%s", diff)
+			}
+		})
+	}
+}
+
+// Puts the flags back to their defaults so one test case doesn't leak into the next
+func resetFlags(flags *pflag.FlagSet) {
+	flags.VisitAll(func(f *pflag.Flag) {
+		// Setting a slice to its default would add the default as a value
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			slice.Replace(nil)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
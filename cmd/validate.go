@@ -18,6 +18,9 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/bcurnow/zonemgr/dns"
 	"github.com/spf13/cobra"
@@ -35,13 +38,13 @@ var (
 				}
 			}
 
-			absInput, err := fs.ToAbsoluteFilePath(inputFile)
+			absInputs, err := toAbsoluteFilePaths(inputFiles)
 			if err != nil {
 				return err
 			}
-			inputFile = absInput
+			inputFiles = absInputs
 
-			parser = dns.YamlZoneParser(dns.PluginNormalizer(pluginManager.Plugins(), pluginManager.Metadata()))
+			parser = dns.YamlZoneParser(dns.PluginNormalizer(pluginManager.Plugins(), pluginManager.Metadata(), configDefaults()))
 
 			return nil
 		},
@@ -51,22 +54,55 @@ var (
 		Use:   "yaml",
 		Short: "Validates the YAML input file",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, err := parser.Parse(inputFile)
+			if !isValidOutputFormat(validateOutputFormat) {
+				return fmt.Errorf("invalid output format '%s', must be one of '%s', '%s' or '%s'", validateOutputFormat, outputFormatText, outputFormatJSON, outputFormatSARIF)
+			}
+
+			_, err := parser.Parse(inputFiles)
+			inputs := strings.Join(inputFiles, ", ")
+			if validateOutputFormat == outputFormatText {
+				if err != nil {
+					return fmt.Errorf("failed to parse input file %s: %w", inputs, err)
+				}
+				for _, warning := range parser.Warnings() {
+					fmt.Fprintln(validateOutput, warning)
+				}
+				fmt.Fprintf(validateOutput, "%s is valid\n", inputs)
+				return nil
+			}
+
+			// The warnings are part of the error when there are errors too
+			errs := validationErrors(inputFiles, err)
+			if err == nil {
+				errs = parser.Warnings()
+			}
+			if validateOutputFormat == outputFormatJSON {
+				err = writeValidationJSON(validateOutput, errs)
+			} else {
+				err = writeValidationSARIF(validateOutput, errs)
+			}
 			if err != nil {
-				return fmt.Errorf("failed to parse input file %s: %w", inputFile, err)
+				return err
+			}
 
+			if errs.HasErrors() {
+				// The problems have already been written, they aren't a usage problem
+				cmd.SilenceUsage = true
+				return fmt.Errorf("input file %s is invalid, found %d problem(s)", inputs, len(errs))
 			}
-			fmt.Printf("%s is valid\n", inputFile)
 			return nil
 		},
 	}
 
-	parser dns.ZoneParser
+	parser               dns.ZoneParser
+	validateOutputFormat string
+	validateOutput       io.Writer = os.Stdout
 )
 
 func init() {
-	validateCmd.PersistentFlags().StringVar(&inputFile, "input", "", "The input file to validate")
+	validateCmd.PersistentFlags().StringSliceVar(&inputFiles, "input", nil, "The input file(s), globs and directories to validate, can be repeated or comma separated")
 	cobra.CheckErr(validateCmd.MarkPersistentFlagRequired("input"))
+	validateYamlCmd.Flags().StringVar(&validateOutputFormat, "output-format", outputFormatText, "The format to report problems in (text, json, sarif), json and sarif are written to stdout")
 	validateCmd.AddCommand(validateYamlCmd)
 	rootCmd.AddCommand(validateCmd)
 }
@@ -0,0 +1,244 @@
+/*
+Copyright © 2025 Brian Curnow
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/bcurnow/zonemgr/utils"
+)
+
+const (
+	outputFormatText  = "text"
+	outputFormatJSON  = "json"
+	outputFormatSARIF = "sarif"
+
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	zonemgrURI   = "https://github.com/bcurnow/zonemgr"
+)
+
+var getwd = os.Getwd
+
+// A single problem as it is written in the JSON output
+type validationProblem struct {
+	Severity   models.Severity `json:"severity"`
+	RuleID     string          `json:"rule_id"`
+	Zone       string          `json:"zone,omitempty"`
+	Template   string          `json:"template,omitempty"`
+	Identifier string          `json:"identifier,omitempty"`
+	File       string          `json:"file,omitempty"`
+	Line       int             `json:"line,omitempty"`
+	Column     int             `json:"column,omitempty"`
+	Message    string          `json:"message"`
+}
+
+type validationReport struct {
+	Valid    bool                 `json:"valid"`
+	Problems []*validationProblem `json:"problems"`
+}
+
+// The subset of SARIF 2.1.0 needed for code scanning to annotate the input file
+type sarifLog struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool      `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string       `json:"name"`
+	Version        string       `json:"version"`
+	InformationURI string       `json:"informationUri"`
+	Rules          []*sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      models.Severity   `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []*sarifLocation  `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func isValidOutputFormat(format string) bool {
+	switch format {
+	case outputFormatText, outputFormatJSON, outputFormatSARIF:
+		return true
+	default:
+		return false
+	}
+}
+
+// Every error is reported as a problem, errors that aren't validation errors (e.g. the input file is missing) are
+// reported against the input file itself when there is only one
+func validationErrors(inputFiles []string, err error) models.ValidationErrors {
+	if err == nil {
+		return nil
+	}
+
+	var errs models.ValidationErrors
+	if errors.As(err, &errs) {
+		return errs
+	}
+	var validationErr *models.ValidationError
+	if errors.As(err, &validationErr) {
+		return models.ValidationErrors{validationErr}
+	}
+	inputErr := &models.ValidationError{Rule: models.RuleInput, Err: err}
+	if len(inputFiles) == 1 {
+		inputErr.Position = &models.SourcePosition{File: inputFiles[0]}
+	}
+	return models.ValidationErrors{inputErr}
+}
+
+func writeValidationJSON(out io.Writer, errs models.ValidationErrors) error {
+	report := &validationReport{Valid: !errs.HasErrors(), Problems: make([]*validationProblem, 0, len(errs))}
+	for _, err := range errs {
+		problem := &validationProblem{
+			Severity:   err.Level(),
+			RuleID:     err.Rule,
+			Zone:       err.Zone,
+			Template:   err.Template,
+			Identifier: err.Identifier,
+			Message:    err.Err.Error(),
+		}
+		if err.Position != nil {
+			problem.File = err.Position.File
+			problem.Line = err.Position.Line
+			problem.Column = err.Position.Column
+		}
+		report.Problems = append(report.Problems, problem)
+	}
+	return writeIndentedJSON(out, report)
+}
+
+func writeValidationSARIF(out io.Writer, errs models.ValidationErrors) error {
+	run := &sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "zonemgr",
+			Version:        utils.Version(),
+			InformationURI: zonemgrURI,
+			Rules:          make([]*sarifRule, 0),
+		}},
+		Results: make([]*sarifResult, 0, len(errs)),
+	}
+
+	ruleIDs := make(map[string]bool)
+	for _, err := range errs {
+		ruleIDs[err.Rule] = true
+		result := &sarifResult{
+			RuleID:  err.Rule,
+			Level:   err.Level(),
+			Message: sarifMessage{Text: err.Err.Error()},
+		}
+
+		if err.Position != nil {
+			location := &sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: sarifURI(err.Position.File)}}}
+			if err.Position.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: err.Position.Line, StartColumn: err.Position.Column}
+			}
+			result.Locations = []*sarifLocation{location}
+		}
+
+		if err.Zone != "" || err.Template != "" || err.Identifier != "" {
+			result.Properties = make(map[string]string)
+			if err.Zone != "" {
+				result.Properties["zone"] = err.Zone
+			}
+			if err.Template != "" {
+				result.Properties["template"] = err.Template
+			}
+			if err.Identifier != "" {
+				result.Properties["identifier"] = err.Identifier
+			}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	for ruleID := range ruleIDs {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, &sarifRule{ID: ruleID})
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool { return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID })
+
+	return writeIndentedJSON(out, &sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []*sarifRun{run}})
+}
+
+// Code scanning matches results to files in the repository, so files below the working directory are relative
+func sarifURI(path string) string {
+	if wd, err := getwd(); err == nil && filepath.IsAbs(path) {
+		if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	if filepath.IsAbs(path) {
+		return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+	}
+	return filepath.ToSlash(path)
+}
+
+func writeIndentedJSON(out io.Writer, v any) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to write validation output: %w", err)
+	}
+	return nil
+}
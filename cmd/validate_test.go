@@ -22,10 +22,16 @@ package cmd
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"os"
+	"slices"
 	"testing"
 
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/bcurnow/zonemgr/utils"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 func TestPersistentPreRunE_Validate(t *testing.T) {
@@ -44,6 +50,9 @@ func TestPersistentPreRunE_Validate(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
+		// Repeated string slice flags append to the current value
+		inputFiles = nil
+		v = viper.New()
 		rootPPRECalled := false
 		rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 			rootPPRECalled = true
@@ -73,8 +82,8 @@ func TestPersistentPreRunE_Validate(t *testing.T) {
 				t.Errorf("expected rootCmd PersistentPreRunE to be called, was not")
 			}
 
-			if inputFile != "testing" {
-				t.Errorf("wrong value for input: '%s', want: '%s'", inputFile, "testing")
+			if !slices.Equal(inputFiles, []string{"testing"}) {
+				t.Errorf("wrong value for input: %v, want: %v", inputFiles, []string{"testing"})
 			}
 		}
 	}
@@ -83,34 +92,33 @@ func TestPersistentPreRunE_Validate(t *testing.T) {
 func TestRunE_ValidateYaml(t *testing.T) {
 	setup(t)
 	defer teardown(t)
+	defer func() { validateOutput = os.Stdout }()
+	warning := &models.ValidationError{Position: &models.SourcePosition{File: "testing", Line: 3}, Zone: "example.com.", Identifier: "caa", Severity: models.SeverityWarning, Err: errors.New("unknown tag")}
 	testCases := []struct {
 		parserErr bool
+		warnings  models.ValidationErrors
 		want      string
 	}{
 		{want: "testing is valid\n"},
+		{warnings: models.ValidationErrors{warning}, want: "warning: testing:3: zone 'example.com.', identifier 'caa': unknown tag\ntesting is valid\n"},
 		{parserErr: true, want: "failed to parse input file testing: parserErr"},
 	}
 
 	for _, tc := range testCases {
-		inputFile = "testing"
-		originalStdout := os.Stdout
-		defer func() { os.Stdout = originalStdout }()
-
-		r, w, err := os.Pipe()
-		if err != nil {
-			t.Errorf("could not create pipe to capture stdout")
-		}
-		os.Stdout = w
+		inputFiles = []string{"testing"}
+		validateOutputFormat = outputFormatText
+		var buf bytes.Buffer
+		validateOutput = &buf
 
-		call := mockParser.EXPECT().Parse("testing")
+		call := mockParser.EXPECT().Parse([]string{"testing"})
 		if tc.parserErr {
 			call.Return(nil, errors.New("parserErr"))
 		} else {
 			call.Return(nil, nil)
+			mockParser.EXPECT().Warnings().Return(tc.warnings)
 		}
 
-		err = validateYamlCmd.RunE(validateYamlCmd, []string{})
-		w.Close()
+		err := validateYamlCmd.RunE(validateYamlCmd, []string{})
 		if err != nil {
 			if tc.parserErr {
 				if err.Error() != tc.want {
@@ -120,14 +128,294 @@ func TestRunE_ValidateYaml(t *testing.T) {
 		} else {
 			if tc.parserErr {
 				t.Error("expected an error, found none")
-			} else {
-				var buf bytes.Buffer
-				buf.ReadFrom(r)
-				r.Close()
+			} else if buf.String() != tc.want {
+				t.Errorf("incorrect output: '%s', want: '%s'", buf.String(), tc.want)
+			}
+		}
+	}
+}
 
-				if buf.String() != tc.want {
-					t.Errorf("incorrect output: '%s', want: '%s'", buf.String(), tc.want)
-				}
+func TestRunE_ValidateYaml_OutputFormat(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+	defer func() { validateOutput = os.Stdout }()
+	defer func() { validateOutputFormat = outputFormatText }()
+	defer func() { getwd = os.Getwd }()
+	getwd = func() (string, error) { return "/repo", nil }
+
+	problems := models.ValidationErrors{
+		{Position: &models.SourcePosition{File: "/repo/zones.yaml", Line: 14, Column: 5}, Zone: "example.com.", Identifier: "www", Rule: "A/normalize", Err: errors.New("bad address")},
+		{Position: &models.SourcePosition{File: "/repo/zones.yaml", Line: 23, Column: 1}, Zone: "other.com.", Rule: "SOA/validate-zone", Err: errors.New("missing SOA")},
+	}
+
+	warnings := models.ValidationErrors{
+		{Position: &models.SourcePosition{File: "/repo/zones.yaml", Line: 31, Column: 5}, Zone: "example.com.", Identifier: "caa", Rule: "CAA/validate-zone", Severity: models.SeverityWarning, Err: errors.New("unknown tag")},
+	}
+
+	testCases := []struct {
+		name      string
+		format    string
+		parserErr error
+		warnings  models.ValidationErrors
+		want      string
+		wantErr   string
+	}{
+		{
+			name:   "json-valid",
+			format: outputFormatJSON,
+			want:   "{\n  \"valid\": true,\n  \"problems\": []\n}\n",
+		},
+		{
+			name:     "json-warnings",
+			format:   outputFormatJSON,
+			warnings: warnings,
+			want: `{
+  "valid": true,
+  "problems": [
+    {
+      "severity": "warning",
+      "rule_id": "CAA/validate-zone",
+      "zone": "example.com.",
+      "identifier": "caa",
+      "file": "/repo/zones.yaml",
+      "line": 31,
+      "column": 5,
+      "message": "unknown tag"
+    }
+  ]
+}
+`,
+		},
+		{
+			name:      "json-problems",
+			format:    outputFormatJSON,
+			parserErr: fmt.Errorf("failed to normalize zones: %w", problems),
+			want: `{
+  "valid": false,
+  "problems": [
+    {
+      "severity": "error",
+      "rule_id": "A/normalize",
+      "zone": "example.com.",
+      "identifier": "www",
+      "file": "/repo/zones.yaml",
+      "line": 14,
+      "column": 5,
+      "message": "bad address"
+    },
+    {
+      "severity": "error",
+      "rule_id": "SOA/validate-zone",
+      "zone": "other.com.",
+      "file": "/repo/zones.yaml",
+      "line": 23,
+      "column": 1,
+      "message": "missing SOA"
+    }
+  ]
+}
+`,
+			wantErr: "input file /repo/zones.yaml is invalid, found 2 problem(s)",
+		},
+		{
+			name:      "json-other-error",
+			format:    outputFormatJSON,
+			parserErr: errors.New("failed to open"),
+			want: `{
+  "valid": false,
+  "problems": [
+    {
+      "severity": "error",
+      "rule_id": "input",
+      "file": "/repo/zones.yaml",
+      "message": "failed to open"
+    }
+  ]
+}
+`,
+			wantErr: "input file /repo/zones.yaml is invalid, found 1 problem(s)",
+		},
+		{
+			name:      "sarif-problems",
+			format:    outputFormatSARIF,
+			parserErr: problems[:1],
+			want: `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "zonemgr",
+          "version": "` + utils.Version() + `",
+          "informationUri": "https://github.com/bcurnow/zonemgr",
+          "rules": [
+            {
+              "id": "A/normalize"
+            }
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "A/normalize",
+          "level": "error",
+          "message": {
+            "text": "bad address"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "zones.yaml"
+                },
+                "region": {
+                  "startLine": 14,
+                  "startColumn": 5
+                }
+              }
+            }
+          ],
+          "properties": {
+            "identifier": "www",
+            "zone": "example.com."
+          }
+        }
+      ]
+    }
+  ]
+}
+`,
+			wantErr: "input file /repo/zones.yaml is invalid, found 1 problem(s)",
+		},
+		{
+			name:     "sarif-warnings",
+			format:   outputFormatSARIF,
+			warnings: warnings,
+			want: `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "zonemgr",
+          "version": "` + utils.Version() + `",
+          "informationUri": "https://github.com/bcurnow/zonemgr",
+          "rules": [
+            {
+              "id": "CAA/validate-zone"
+            }
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "CAA/validate-zone",
+          "level": "warning",
+          "message": {
+            "text": "unknown tag"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "zones.yaml"
+                },
+                "region": {
+                  "startLine": 31,
+                  "startColumn": 5
+                }
+              }
+            }
+          ],
+          "properties": {
+            "identifier": "caa",
+            "zone": "example.com."
+          }
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+		{name: "invalid-format", format: "xml", wantErr: "invalid output format 'xml', must be one of 'text', 'json' or 'sarif'"},
+	}
+
+	for _, tc := range testCases {
+		inputFiles = []string{"/repo/zones.yaml"}
+		validateOutputFormat = tc.format
+		var buf bytes.Buffer
+		validateOutput = &buf
+
+		if tc.format != "xml" {
+			mockParser.EXPECT().Parse(inputFiles).Return(nil, tc.parserErr)
+			if tc.parserErr == nil {
+				mockParser.EXPECT().Warnings().Return(tc.warnings)
+			}
+		}
+
+		err := validateYamlCmd.RunE(validateYamlCmd, []string{})
+		if tc.wantErr != "" {
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("%s - incorrect error: '%v', want: '%s'", tc.name, err, tc.wantErr)
+			}
+		} else if err != nil {
+			t.Errorf("%s - unexpected error: %s", tc.name, err)
+		}
+
+		if buf.String() != tc.want {
+			t.Errorf("%s - incorrect output:\n%s\nwant:\n%s", tc.name, buf.String(), tc.want)
+		}
+	}
+}
+
+func TestSarifURI(t *testing.T) {
+	defer func() { getwd = os.Getwd }()
+	getwd = func() (string, error) { return "/repo", nil }
+
+	testCases := []struct {
+		path string
+		want string
+	}{
+		{path: "/repo/zones/zones.yaml", want: "zones/zones.yaml"},
+		{path: "/elsewhere/zones.yaml", want: "file:///elsewhere/zones.yaml"},
+		{path: "zones.yaml", want: "zones.yaml"},
+	}
+
+	for _, tc := range testCases {
+		if got := sarifURI(tc.path); got != tc.want {
+			t.Errorf("incorrect uri for '%s': '%s', want: '%s'", tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	validationErr := &models.ValidationError{Zone: "example.com.", Rule: models.RuleSchema, Err: errors.New("invalid")}
+
+	testCases := []struct {
+		name       string
+		inputFiles []string
+		err        error
+		want       models.ValidationErrors
+	}{
+		{name: "nil", inputFiles: []string{"zones.yaml"}},
+		{name: "validation-errors", inputFiles: []string{"zones.yaml"}, err: models.ValidationErrors{validationErr}, want: models.ValidationErrors{validationErr}},
+		{name: "validation-error", inputFiles: []string{"zones.yaml"}, err: fmt.Errorf("wrapped: %w", validationErr), want: models.ValidationErrors{validationErr}},
+		{name: "single-input", inputFiles: []string{"zones.yaml"}, err: errors.New("inputErr"), want: models.ValidationErrors{{Position: &models.SourcePosition{File: "zones.yaml"}, Rule: models.RuleInput, Err: errors.New("inputErr")}}},
+		{name: "multiple-inputs", inputFiles: []string{"one.yaml", "two.yaml"}, err: errors.New("inputErr"), want: models.ValidationErrors{{Rule: models.RuleInput, Err: errors.New("inputErr")}}},
+	}
+
+	for _, tc := range testCases {
+		got := validationErrors(tc.inputFiles, tc.err)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s - incorrect errors: %v, want: %v", tc.name, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i].Error() != tc.want[i].Error() || got[i].Rule != tc.want[i].Rule {
+				t.Errorf("%s - incorrect error: '%s' (%s), want: '%s' (%s)", tc.name, got[i], got[i].Rule, tc.want[i], tc.want[i].Rule)
 			}
 		}
 	}
@@ -0,0 +1,169 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/bcurnow/zonemgr/utils"
+)
+
+// The names of a zone as a resolver sees them, used to follow the target of a CNAME record from one zone into another
+type managedZone struct {
+	*models.ZoneNames
+	// The absolute target of the CNAME record at each name
+	cnames map[string]string
+	// The names below the apex with NS records, the zone doesn't answer for anything at or below them
+	delegations map[string]bool
+}
+
+type managedZones map[string]*managedZone
+
+// Follows the target of every CNAME record through all of the zones, the cname_policy of the zone decides how strict
+// this is. The zones in skip aren't checked but their records can still be a target.
+func checkCNAMETargets(zones map[string]*models.Zone, skip map[string]bool) models.ValidationErrors {
+	managed := newManagedZones(zones)
+
+	var errs models.ValidationErrors
+	models.WithSortedZones(zones, func(name string, zone *models.Zone) error {
+		policy := models.CNAMEPolicyManaged
+		if zone.Config != nil && zone.Config.CNAMEPolicy != "" {
+			policy = zone.Config.CNAMEPolicy
+		}
+		if skip[name] || policy == models.CNAMEPolicyNone {
+			return nil
+		}
+
+		zone.WithSortedResourceRecords(func(identifier string, rr *models.ResourceRecord) error {
+			if rr.Type != models.CNAME {
+				return nil
+			}
+			value := rr.RetrieveSingleValue()
+			if reason := managed.followTarget(policy, models.AbsoluteName(rr.Name, name), models.AbsoluteName(value, name)); reason != "" {
+				errs = append(errs, recordError(name, zone, identifier, models.RuleCNAMETarget, fmt.Errorf("invalid CNAME record, '%s' has a value of '%s' which %s", identifier, value, reason)))
+			}
+			return nil
+		})
+		return nil
+	})
+	return errs
+}
+
+func newManagedZones(zones map[string]*models.Zone) managedZones {
+	managed := make(managedZones)
+	for name, zone := range zones {
+		mz := &managedZone{
+			ZoneNames:   models.NewZoneNames(name, zone),
+			cnames:      make(map[string]string),
+			delegations: make(map[string]bool),
+		}
+		for _, rr := range zone.ResourceRecords {
+			owner := models.AbsoluteName(rr.Name, name)
+			if !mz.Contains(owner) {
+				continue
+			}
+
+			switch {
+			case rr.Type == models.CNAME:
+				mz.cnames[owner] = models.AbsoluteName(rr.RetrieveSingleValue(), name)
+			case rr.Type == models.NS && owner != mz.Apex():
+				mz.delegations[owner] = true
+			}
+		}
+		managed[mz.Apex()] = mz
+	}
+	return managed
+}
+
+// Follows the target of the CNAME record at owner until it gets to a name that isn't a CNAME record, returns why the
+// target isn't valid or an empty string if it is
+func (mzs managedZones) followTarget(policy models.CNAMEPolicy, owner string, target string) string {
+	chain := []string{owner}
+	for name := target; ; {
+		mz := mzs.zoneOf(name)
+		if mz == nil {
+			if policy == models.CNAMEPolicyStrict {
+				return chainReason(target, name, "is not in any of the zones")
+			}
+			// The target is someone else's to answer for
+			return ""
+		}
+
+		resolved := mz.Resolve(name)
+		if resolved == "" {
+			return chainReason(target, name, fmt.Sprintf("does not exist in zone '%s'", mz.Apex()))
+		}
+
+		next, ok := mz.cnames[resolved]
+		if !ok {
+			// The CNAME records of a reverse lookup zone point at PTR records, e.g. the ones of a classless zone (RFC 2317)
+			switch {
+			case policy != models.CNAMEPolicyStrict:
+			case utils.IsReverseZoneName(resolved) && !mz.Owns(resolved, models.PTR):
+				return chainReason(target, name, "does not have a PTR record")
+			case !utils.IsReverseZoneName(resolved) && !mz.Owns(resolved, models.A) && !mz.Owns(resolved, models.AAAA):
+				return chainReason(target, name, "does not have an A or AAAA record")
+			}
+			return ""
+		}
+
+		if slices.Contains(chain, name) {
+			return fmt.Sprintf("is part of a CNAME loop (%s -> %s)", strings.Join(chain, " -> "), name)
+		}
+		chain = append(chain, name)
+		name = next
+	}
+}
+
+// Returns the most specific zone that answers for the absolute name, nil when none of them do
+func (mzs managedZones) zoneOf(name string) *managedZone {
+	for zoneName := name; ; zoneName = models.ParentName(zoneName) {
+		if mz, ok := mzs[zoneName]; ok {
+			if mz.isDelegated(name) {
+				return nil
+			}
+			return mz
+		}
+		if zoneName == "." {
+			return nil
+		}
+	}
+}
+
+// Checks if the absolute name is at or below a delegation of the zone
+func (mz *managedZone) isDelegated(name string) bool {
+	for n := name; n != mz.Apex() && mz.Contains(n); n = models.ParentName(n) {
+		if mz.delegations[n] {
+			return true
+		}
+	}
+	return false
+}
+
+// The reason is about the name the chain got to, that's the target itself unless it went through other CNAME records
+func chainReason(target string, name string, reason string) string {
+	if name == target {
+		return reason
+	}
+	return fmt.Sprintf("leads to '%s' which %s", name, reason)
+}
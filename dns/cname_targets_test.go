@@ -0,0 +1,174 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"testing"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckCNAMETargets(t *testing.T) {
+	zone := func(policy models.CNAMEPolicy, records map[string]*models.ResourceRecord) *models.Zone {
+		return &models.Zone{Config: &models.Config{CNAMEPolicy: policy}, ResourceRecords: records}
+	}
+	other := zone("", map[string]*models.ResourceRecord{
+		"v6":      {Type: models.AAAA, Name: "v6", Value: "2001:db8::1"},
+		"txt":     {Type: models.TXT, Name: "txt", Value: "text"},
+		"alias":   {Type: models.CNAME, Name: "alias", Value: "v6"},
+		"dev":     {Type: models.NS, Name: "dev", Value: "ns.dev.example.net."},
+		"apps":    {Type: models.A, Name: "*.apps", Value: "192.0.2.1"},
+		"missing": {Type: models.CNAME, Name: "missing", Value: "nowhere"},
+	})
+
+	testCases := []struct {
+		name    string
+		zones   map[string]*models.Zone
+		skip    map[string]bool
+		wantErr []string
+	}{
+		{
+			name: "same-zone",
+			zones: map[string]*models.Zone{"example.com.": zone("", map[string]*models.ResourceRecord{
+				"www":  {Type: models.A, Name: "www", Value: "192.0.2.1"},
+				"web":  {Type: models.CNAME, Name: "web", Value: "www"},
+				"apex": {Type: models.CNAME, Name: "home", Value: "@"},
+				"soa":  {Type: models.SOA, Name: "example.com.", Value: "SOA"},
+			})},
+		},
+		{
+			name: "other-zone",
+			zones: map[string]*models.Zone{
+				"example.com.": zone("", map[string]*models.ResourceRecord{
+					"aaaa":     {Type: models.CNAME, Name: "aaaa", Value: "v6.example.net."},
+					"chain":    {Type: models.CNAME, Name: "chain", Value: "alias.example.net."},
+					"txt":      {Type: models.CNAME, Name: "txt", Value: "TXT.Example.Net."},
+					"wildcard": {Type: models.CNAME, Name: "console", Value: "console.apps.example.net."},
+				}),
+				"example.net.": other,
+			},
+			skip: map[string]bool{"example.net.": true},
+		},
+		{
+			name: "external",
+			zones: map[string]*models.Zone{"example.com.": zone(models.CNAMEPolicyManaged, map[string]*models.ResourceRecord{
+				"cdn":       {Type: models.CNAME, Name: "cdn", Value: "example.cdn.example.org."},
+				"delegated": {Type: models.CNAME, Name: "delegated", Value: "host.dev.example.com."},
+				"dev":       {Type: models.NS, Name: "dev", Value: "ns.example.org."},
+			})},
+		},
+		{
+			name: "dangling",
+			zones: map[string]*models.Zone{
+				"example.com.": zone("", map[string]*models.ResourceRecord{
+					"web":   {Type: models.CNAME, Name: "web", Value: "www"},
+					"other": {Type: models.CNAME, Name: "other", Value: "gone.example.net."},
+					"chain": {Type: models.CNAME, Name: "chain", Value: "missing.example.net."},
+				}),
+				"example.net.": other,
+			},
+			skip: map[string]bool{"example.net.": true},
+			wantErr: []string{
+				"zone 'example.com.', identifier 'chain': invalid CNAME record, 'chain' has a value of 'missing.example.net.' which leads to 'nowhere.example.net.' which does not exist in zone 'example.net.'",
+				"zone 'example.com.', identifier 'other': invalid CNAME record, 'other' has a value of 'gone.example.net.' which does not exist in zone 'example.net.'",
+				"zone 'example.com.', identifier 'web': invalid CNAME record, 'web' has a value of 'www' which does not exist in zone 'example.com.'",
+			},
+		},
+		{
+			name: "loop",
+			zones: map[string]*models.Zone{"example.com.": zone("", map[string]*models.ResourceRecord{
+				"a":    {Type: models.CNAME, Name: "a", Value: "b"},
+				"b":    {Type: models.CNAME, Name: "b", Value: "a.example.com."},
+				"self": {Type: models.CNAME, Name: "self", Value: "self"},
+			})},
+			wantErr: []string{
+				"zone 'example.com.', identifier 'a': invalid CNAME record, 'a' has a value of 'b' which is part of a CNAME loop (a.example.com. -> b.example.com. -> a.example.com.)",
+				"zone 'example.com.', identifier 'b': invalid CNAME record, 'b' has a value of 'a.example.com.' which is part of a CNAME loop (b.example.com. -> a.example.com. -> b.example.com.)",
+				"zone 'example.com.', identifier 'self': invalid CNAME record, 'self' has a value of 'self' which is part of a CNAME loop (self.example.com. -> self.example.com.)",
+			},
+		},
+		{
+			name: "strict",
+			zones: map[string]*models.Zone{
+				"example.com.": zone(models.CNAMEPolicyStrict, map[string]*models.ResourceRecord{
+					"aaaa": {Type: models.CNAME, Name: "aaaa", Value: "alias.example.net."},
+					"cdn":  {Type: models.CNAME, Name: "cdn", Value: "example.cdn.example.org."},
+					"txt":  {Type: models.CNAME, Name: "txt", Value: "txt.example.net."},
+				}),
+				"example.net.": other,
+			},
+			skip: map[string]bool{"example.net.": true},
+			wantErr: []string{
+				"zone 'example.com.', identifier 'cdn': invalid CNAME record, 'cdn' has a value of 'example.cdn.example.org.' which is not in any of the zones",
+				"zone 'example.com.', identifier 'txt': invalid CNAME record, 'txt' has a value of 'txt.example.net.' which does not have an A or AAAA record",
+			},
+		},
+		{
+			name: "none",
+			zones: map[string]*models.Zone{"example.com.": zone(models.CNAMEPolicyNone, map[string]*models.ResourceRecord{
+				"web": {Type: models.CNAME, Name: "web", Value: "www"},
+			})},
+		},
+		{
+			// The parent zone of a classless zone (RFC 2317)
+			name: "reverse-zone",
+			zones: map[string]*models.Zone{
+				"2.0.192.in-addr.arpa.": zone(models.CNAMEPolicyStrict, map[string]*models.ResourceRecord{
+					"0-63": {Type: models.NS, Name: "0-63", Value: "ns1.example.com."},
+					"5":    {Type: models.CNAME, Name: "5", Value: "5.0-63.2.0.192.in-addr.arpa."},
+					"6":    {Type: models.CNAME, Name: "6", Value: "6.0-63.2.0.192.in-addr.arpa."},
+					"7":    {Type: models.CNAME, Name: "7", Value: "7.0-63.2.0.192.in-addr.arpa."},
+				}),
+				"0-63.2.0.192.in-addr.arpa.": zone(models.CNAMEPolicyStrict, map[string]*models.ResourceRecord{
+					"5": {Type: models.PTR, Name: "5", Value: "www.example.com."},
+					"7": {Type: models.TXT, Name: "7", Value: "text"},
+				}),
+			},
+			wantErr: []string{
+				"zone '2.0.192.in-addr.arpa.', identifier '6': invalid CNAME record, '6' has a value of '6.0-63.2.0.192.in-addr.arpa.' which does not exist in zone '0-63.2.0.192.in-addr.arpa.'",
+				"zone '2.0.192.in-addr.arpa.', identifier '7': invalid CNAME record, '7' has a value of '7.0-63.2.0.192.in-addr.arpa.' which does not have a PTR record",
+			},
+		},
+		{
+			// Without the classless zone the target is in the parent zone, which doesn't have it
+			name: "reverse-zone-missing-classless-zone",
+			zones: map[string]*models.Zone{"2.0.192.in-addr.arpa.": zone("", map[string]*models.ResourceRecord{
+				"5": {Type: models.CNAME, Name: "5", Value: "5.0-63.2.0.192.in-addr.arpa."},
+			})},
+			wantErr: []string{
+				"zone '2.0.192.in-addr.arpa.', identifier '5': invalid CNAME record, '5' has a value of '5.0-63.2.0.192.in-addr.arpa.' which does not exist in zone '2.0.192.in-addr.arpa.'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		var got []string
+		for _, err := range checkCNAMETargets(tc.zones, tc.skip) {
+			if err.Rule != models.RuleCNAMETarget {
+				t.Errorf("%s - incorrect rule: '%s', want: '%s'", tc.name, err.Rule, models.RuleCNAMETarget)
+			}
+			got = append(got, err.Error())
+		}
+		if diff := cmp.Diff(tc.wantErr, got); diff != "" {
+			t.Errorf("%s - incorrect errors (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
@@ -20,11 +20,16 @@
 package dns
 
 import (
+	"os"
+
 	"github.com/bcurnow/zonemgr/utils"
 	"github.com/hashicorp/go-hclog"
 )
 
-var fs utils.FileSystemOperations = &utils.FileSystem{}
+var (
+	fs       utils.FileSystemOperations = &utils.FileSystem{}
+	readFile                            = os.ReadFile
+)
 
 func logger() hclog.Logger {
 	return hclog.L().Named("dns")
@@ -98,3 +98,7 @@ func dnsTeardown(_ *testing.T) {
 func toInt32Ptr(i int32) *int32 {
 	return &i
 }
+
+func toBoolPtr(b bool) *bool {
+	return &b
+}
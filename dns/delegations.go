@@ -0,0 +1,213 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/bcurnow/zonemgr/utils"
+)
+
+// A record with the zone it's in and its identifier so problems can be reported against it
+type identifiedRecord struct {
+	zone       string
+	identifier string
+	rr         *models.ResourceRecord
+}
+
+// Adds the delegation of every zone whose parent zone is also in the input to the parent, or checks it, depending on
+// the delegation_policy of the parent. The delegation is a copy of the NS records at the apex of the child and, for the
+// name servers inside the child, the glue A and AAAA records the parent needs to be able to reach them. Catalog zones
+// are never delegated and the zones in skip are left alone.
+func delegateZones(zones map[string]*models.Zone, skip map[string]bool) models.ValidationErrors {
+	// The zones by their canonical name so the parent of a zone can be found whichever way the names are written
+	byName := make(map[string]string)
+	for name, zone := range zones {
+		if zone.Config == nil || !zone.Config.IsCatalog {
+			byName[models.AbsoluteName("@", name)] = name
+		}
+	}
+
+	var errs models.ValidationErrors
+	models.WithSortedZones(zones, func(childName string, child *models.Zone) error {
+		apex := models.AbsoluteName("@", childName)
+		if _, ok := byName[apex]; !ok || skip[childName] {
+			return nil
+		}
+
+		parentName := zoneContaining(byName, models.ParentName(apex))
+		if parentName == "" || skip[parentName] {
+			return nil
+		}
+		parent := zones[parentName]
+
+		policy := models.DelegationPolicyGenerate
+		if parent.Config != nil && parent.Config.DelegationPolicy != "" {
+			policy = parent.Config.DelegationPolicy
+		}
+		if policy == models.DelegationPolicyNone {
+			return nil
+		}
+
+		logger().Debug("delegating zone", "zoneName", childName, "parentZoneName", parentName, "policy", policy)
+		errs = append(errs, delegateZone(policy, zones, byName, parentName, childName)...)
+		return nil
+	})
+	return errs
+}
+
+func delegateZone(policy models.DelegationPolicy, zones map[string]*models.Zone, byName map[string]string, parentName string, childName string) models.ValidationErrors {
+	parent, child := zones[parentName], zones[childName]
+	apex := models.AbsoluteName("@", childName)
+
+	// Without name servers of its own there's nothing to delegate to
+	childNS := recordsAt(childName, child, apex, models.NS)
+	if len(childNS) == 0 {
+		return nil
+	}
+
+	var errs models.ValidationErrors
+	nameServers := recordData(childName, childNS)
+	parentNS := recordsAt(parentName, parent, apex, models.NS)
+	switch {
+	case len(parentNS) == 0 && policy == models.DelegationPolicyVerify:
+		errs = append(errs, zoneError(parentName, parent, models.RuleDelegation, fmt.Errorf("zone '%s' isn't delegated, the NS records for it are missing (%s)", childName, strings.Join(nameServers, ", "))))
+	case len(parentNS) == 0:
+		addDelegationRecords(parentName, parent, childNS)
+	default:
+		if delegated := recordData(parentName, parentNS); !slices.Equal(delegated, nameServers) {
+			errs = append(errs, recordError(parentName, parent, parentNS[0].identifier, models.RuleDelegation, fmt.Errorf("the delegation of zone '%s' has the name servers (%s) but the zone has (%s)", childName, strings.Join(delegated, ", "), strings.Join(nameServers, ", "))))
+		}
+	}
+
+	// Only the name servers inside the child need glue, the parent can find the others the usual way
+	for _, nameServer := range nameServers {
+		if nameServer != apex && !strings.HasSuffix(nameServer, "."+apex) {
+			continue
+		}
+
+		// The name server can be in a zone delegated from the child
+		ownerName := zoneContaining(byName, nameServer)
+		addresses := addressRecordsAt(ownerName, zones[ownerName], nameServer)
+		if len(addresses) == 0 {
+			identifier := childNS[slices.IndexFunc(childNS, func(ns *identifiedRecord) bool {
+				return models.AbsoluteName(ns.rr.RetrieveSingleValue(), childName) == nameServer
+			})].identifier
+			errs = append(errs, recordError(childName, child, identifier, models.RuleDelegation, fmt.Errorf("name server '%s' is in zone '%s' but doesn't have an A or AAAA record, the glue for zone '%s' needs its address", nameServer, ownerName, parentName)))
+			continue
+		}
+
+		glue := addressRecordsAt(parentName, parent, nameServer)
+		switch {
+		case len(glue) == 0 && policy == models.DelegationPolicyVerify:
+			errs = append(errs, zoneError(parentName, parent, models.RuleDelegation, fmt.Errorf("the glue for name server '%s' of zone '%s' is missing (%s)", nameServer, childName, strings.Join(addressData(addresses), ", "))))
+		case len(glue) == 0:
+			addDelegationRecords(parentName, parent, addresses)
+		default:
+			if glued, want := addressData(glue), addressData(addresses); !slices.Equal(glued, want) {
+				errs = append(errs, recordError(parentName, parent, glue[0].identifier, models.RuleDelegation, fmt.Errorf("the glue for name server '%s' of zone '%s' has the addresses (%s) but zone '%s' has (%s)", nameServer, childName, strings.Join(glued, ", "), ownerName, strings.Join(want, ", "))))
+			}
+		}
+	}
+	return errs
+}
+
+// Copies the records into the parent zone with their names relative to it, the copied glue is never reversed as
+// the records it's copied from already are and the name servers are made absolute as a relative name would now
+// be relative to the parent
+func addDelegationRecords(parentName string, parent *models.Zone, records []*identifiedRecord) {
+	for _, record := range records {
+		rr := record.rr.Clone()
+		rr.Name = relativeName(models.AbsoluteName(rr.Name, record.zone), models.AbsoluteName("@", parentName))
+		if rr.Type == models.A || rr.Type == models.AAAA {
+			rr.Reverse = new(bool)
+			rr.PTRName = ""
+		}
+		if rr.Type == models.NS {
+			nameServer := models.AbsoluteName(rr.RetrieveSingleValue(), record.zone)
+			if len(rr.Values) > 0 {
+				rr.Values[0].Value = nameServer
+			} else {
+				rr.Value = nameServer
+			}
+		}
+
+		identifier := fmt.Sprintf("%s %s %s", rr.Name, rr.Type, rr.RetrieveSingleValue())
+		if _, ok := parent.ResourceRecords[identifier]; !ok {
+			logger().Trace("adding delegation record", "zoneName", parentName, "identifier", identifier)
+			parent.ResourceRecords[identifier] = rr
+		}
+	}
+}
+
+// Returns the name of the most specific zone the absolute name is in, empty when it isn't in any of them
+func zoneContaining(byName map[string]string, name string) string {
+	for zoneName := name; ; zoneName = models.ParentName(zoneName) {
+		if key, ok := byName[zoneName]; ok {
+			return key
+		}
+		if zoneName == "." {
+			return ""
+		}
+	}
+}
+
+// The records of the type whose canonical name is owner, in identifier order
+func recordsAt(zoneName string, zone *models.Zone, owner string, rrType models.ResourceRecordType) []*identifiedRecord {
+	var records []*identifiedRecord
+	zone.WithSortedResourceRecords(func(identifier string, rr *models.ResourceRecord) error {
+		if rr.Type == rrType && models.AbsoluteName(rr.Name, zoneName) == owner {
+			records = append(records, &identifiedRecord{identifier: identifier, rr: rr, zone: zoneName})
+		}
+		return nil
+	})
+	return records
+}
+
+func addressRecordsAt(zoneName string, zone *models.Zone, owner string) []*identifiedRecord {
+	return append(recordsAt(zoneName, zone, owner, models.A), recordsAt(zoneName, zone, owner, models.AAAA)...)
+}
+
+// The sorted canonical names the records point at
+func recordData(zoneName string, records []*identifiedRecord) []string {
+	var names []string
+	for _, record := range records {
+		names = append(names, models.AbsoluteName(record.rr.RetrieveSingleValue(), zoneName))
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// The sorted addresses of the records, written the same way however they were written in the records
+func addressData(records []*identifiedRecord) []string {
+	var addresses []string
+	for _, record := range records {
+		address := record.rr.RetrieveSingleValue()
+		if ip, err := utils.ParseIP(address); err == nil {
+			address = ip.String()
+		}
+		addresses = append(addresses, address)
+	}
+	slices.Sort(addresses)
+	return slices.Compact(addresses)
+}
@@ -0,0 +1,156 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDelegateZones(t *testing.T) {
+	parent := func(policy models.DelegationPolicy, records map[string]*models.ResourceRecord) *models.Zone {
+		if records == nil {
+			records = make(map[string]*models.ResourceRecord)
+		}
+		records["ns"] = &models.ResourceRecord{Type: models.NS, Name: "@", Value: "ns.example.org."}
+		return &models.Zone{Config: &models.Config{DelegationPolicy: policy}, ResourceRecords: records}
+	}
+	child := func() *models.Zone {
+		return &models.Zone{Config: &models.Config{}, ResourceRecords: map[string]*models.ResourceRecord{
+			"ns1":      {Type: models.NS, Name: "@", Value: "ns1.lab.example.com."},
+			"ns2":      {Type: models.NS, Name: "@", Value: "ns.example.org."},
+			"ns1-v4":   {Type: models.A, Name: "ns1", Value: "192.0.2.53", Reverse: toBoolPtr(true)},
+			"ns1-v6":   {Type: models.AAAA, Name: "ns1", Value: "2001:db8::53"},
+			"www":      {Type: models.A, Name: "www", Value: "192.0.2.80"},
+			"ns1-text": {Type: models.TXT, Name: "ns1", Value: "name server"},
+		}}
+	}
+
+	testCases := []struct {
+		name            string
+		zones           map[string]*models.Zone
+		skip            map[string]bool
+		wantIdentifiers []string
+		wantErr         []string
+	}{
+		{
+			name:            "generate",
+			zones:           map[string]*models.Zone{"example.com.": parent("", nil), "lab.example.com.": child()},
+			wantIdentifiers: []string{"lab NS ns.example.org.", "lab NS ns1.lab.example.com.", "ns", "ns1.lab A 192.0.2.53", "ns1.lab AAAA 2001:db8::53"},
+		},
+		{
+			name: "generate-matching",
+			zones: map[string]*models.Zone{"example.com.": parent(models.DelegationPolicyGenerate, map[string]*models.ResourceRecord{
+				"lab1":    {Type: models.NS, Name: "lab", Value: "NS1.lab"},
+				"lab2":    {Type: models.NS, Name: "lab.example.com.", Value: "ns.example.org."},
+				"glue-v4": {Type: models.A, Name: "ns1.lab", Value: "192.0.2.53"},
+				"glue-v6": {Type: models.AAAA, Name: "ns1.lab", Value: "2001:0db8:0000::0053"},
+			}), "lab.example.com.": child()},
+			wantIdentifiers: []string{"glue-v4", "glue-v6", "lab1", "lab2", "ns"},
+		},
+		{
+			name:            "verify",
+			zones:           map[string]*models.Zone{"example.com.": parent(models.DelegationPolicyVerify, nil), "lab.example.com.": child()},
+			wantIdentifiers: []string{"ns"},
+			wantErr: []string{
+				"zone 'example.com.': zone 'lab.example.com.' isn't delegated, the NS records for it are missing (ns.example.org., ns1.lab.example.com.)",
+				"zone 'example.com.': the glue for name server 'ns1.lab.example.com.' of zone 'lab.example.com.' is missing (192.0.2.53, 2001:db8::53)",
+			},
+		},
+		{
+			name: "drift",
+			zones: map[string]*models.Zone{"example.com.": parent(models.DelegationPolicyVerify, map[string]*models.ResourceRecord{
+				"lab":  {Type: models.NS, Name: "lab", Value: "ns1.lab"},
+				"glue": {Type: models.A, Name: "ns1.lab", Value: "192.0.2.35"},
+			}), "lab.example.com.": child()},
+			wantIdentifiers: []string{"glue", "lab", "ns"},
+			wantErr: []string{
+				"zone 'example.com.', identifier 'lab': the delegation of zone 'lab.example.com.' has the name servers (ns1.lab.example.com.) but the zone has (ns.example.org., ns1.lab.example.com.)",
+				"zone 'example.com.', identifier 'glue': the glue for name server 'ns1.lab.example.com.' of zone 'lab.example.com.' has the addresses (192.0.2.35) but zone 'lab.example.com.' has (192.0.2.53, 2001:db8::53)",
+			},
+		},
+		{
+			name: "missing-address",
+			zones: map[string]*models.Zone{"example.com.": parent("", nil), "lab.example.com.": {Config: &models.Config{}, ResourceRecords: map[string]*models.ResourceRecord{
+				"ns": {Type: models.NS, Name: "@", Value: "ns"},
+			}}},
+			wantIdentifiers: []string{"lab NS ns.lab.example.com.", "ns"},
+			wantErr: []string{
+				"zone 'lab.example.com.', identifier 'ns': name server 'ns.lab.example.com.' is in zone 'lab.example.com.' but doesn't have an A or AAAA record, the glue for zone 'example.com.' needs its address",
+			},
+		},
+		{
+			name:            "none",
+			zones:           map[string]*models.Zone{"example.com.": parent(models.DelegationPolicyNone, nil), "lab.example.com.": child()},
+			wantIdentifiers: []string{"ns"},
+		},
+		{
+			name:            "skipped",
+			zones:           map[string]*models.Zone{"example.com.": parent("", nil), "lab.example.com.": child()},
+			skip:            map[string]bool{"lab.example.com.": true},
+			wantIdentifiers: []string{"ns"},
+		},
+		{
+			name: "grandparent",
+			zones: map[string]*models.Zone{"com.": parent("", nil), "example.com.": {Config: &models.Config{IsCatalog: true}, ResourceRecords: map[string]*models.ResourceRecord{}},
+				"lab.example.com.": child()},
+			wantIdentifiers: []string{"lab.example NS ns.example.org.", "lab.example NS ns1.lab.example.com.", "ns", "ns1.lab.example A 192.0.2.53", "ns1.lab.example AAAA 2001:db8::53"},
+		},
+	}
+
+	for _, tc := range testCases {
+		var got []string
+		for _, err := range delegateZones(tc.zones, tc.skip) {
+			if err.Rule != models.RuleDelegation {
+				t.Errorf("%s - incorrect rule: '%s', want: '%s'", tc.name, err.Rule, models.RuleDelegation)
+			}
+			got = append(got, err.Error())
+		}
+		if diff := cmp.Diff(tc.wantErr, got); diff != "" {
+			t.Errorf("%s - incorrect errors (-want +got):\n%s", tc.name, diff)
+		}
+
+		top := "example.com."
+		if _, ok := tc.zones["com."]; ok {
+			top = "com."
+		}
+		var identifiers []string
+		for identifier, rr := range tc.zones[top].ResourceRecords {
+			identifiers = append(identifiers, identifier)
+			if rr.Reverse != nil && *rr.Reverse {
+				t.Errorf("%s - '%s' is reversed, delegation records must never be reversed", tc.name, identifier)
+			}
+		}
+		slices.Sort(identifiers)
+		if diff := cmp.Diff(tc.wantIdentifiers, identifiers); diff != "" {
+			t.Errorf("%s - incorrect records (-want +got):\n%s", tc.name, diff)
+		}
+	}
+
+	// The clones can't share anything with the records of the child zone
+	zones := map[string]*models.Zone{"example.com.": parent("", nil), "lab.example.com.": child()}
+	delegateZones(zones, nil)
+	if glue := zones["example.com."].ResourceRecords["ns1.lab A 192.0.2.53"]; glue == zones["lab.example.com."].ResourceRecords["ns1-v4"] || *zones["lab.example.com."].ResourceRecords["ns1-v4"].Reverse != true {
+		t.Error("the glue record must be a copy of the record in the child zone")
+	}
+}
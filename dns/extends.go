@@ -0,0 +1,161 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bcurnow/zonemgr/models"
+)
+
+// What a zone inherits from the zone or template it extends
+type inheritance struct {
+	config          *models.Config
+	ttl             *models.TTL
+	soaDefaults     *models.SOAFields
+	resourceRecords map[string]*models.ResourceRecord
+	position        func(identifier string) *models.SourcePosition
+}
+
+type zoneExtender struct {
+	zones     map[string]*models.Zone
+	templates map[string]*models.Template
+	// The zones that have been extended (true) or couldn't be (false)
+	extended map[string]bool
+	errs     models.ValidationErrors
+}
+
+// Expands every zone that extends another zone or a template. The zone inherits the config settings and SOA defaults
+// it doesn't set, the ttl if it doesn't set one and every resource record whose identifier it doesn't remove. A resource
+// record with the identifier of an inherited one inherits the fields it doesn't set (see ResourceRecord.Inherit). A zone
+// is extended after the zone it extends, so inheritance can be chained, and after the templates are included, so
+// included resource records are inherited too.
+func extendZones(zones map[string]*models.Zone, templates map[string]*models.Template) models.ValidationErrors {
+	e := &zoneExtender{zones: zones, templates: templates, extended: make(map[string]bool)}
+	for _, name := range sortedKeys(zones) {
+		e.extend(name, nil)
+	}
+	return e.errs
+}
+
+// Extends the zone once the zone it extends has been, chain holds the zones being extended to find cycles. Returns false
+// if the zone couldn't be extended, the error is only reported for the zone that caused it.
+func (e *zoneExtender) extend(name string, chain []string) bool {
+	if extended, ok := e.extended[name]; ok {
+		return extended
+	}
+	zone := e.zones[name]
+	if zone.Extends == "" {
+		if len(zone.Remove) > 0 {
+			e.errs = append(e.errs, zoneError(name, zone, models.RuleExtends, errors.New("remove can only be used with extends")))
+			e.extended[name] = false
+			return false
+		}
+		e.extended[name] = true
+		return true
+	}
+
+	chain = append(chain, name)
+	if slices.Contains(chain[:len(chain)-1], name) {
+		e.errs = append(e.errs, zoneError(name, zone, models.RuleExtends, fmt.Errorf("extends cycle: %s", strings.Join(chain, " -> "))))
+		return false
+	}
+
+	parent, err := e.inheritance(zone.Extends, chain)
+	if err != nil {
+		if !errors.Is(err, errParentNotExtended) {
+			e.errs = append(e.errs, zoneError(name, zone, models.RuleExtends, err))
+		}
+		e.extended[name] = false
+		return false
+	}
+
+	errs := inherit(name, zone, parent)
+	e.errs = append(e.errs, errs...)
+	e.extended[name] = len(errs) == 0
+	return e.extended[name]
+}
+
+var errParentNotExtended = errors.New("the zone it extends couldn't be extended")
+
+func (e *zoneExtender) inheritance(parentName string, chain []string) (*inheritance, error) {
+	parentZone, isZone := e.zones[parentName]
+	template, isTemplate := e.templates[parentName]
+	switch {
+	case isZone && isTemplate:
+		return nil, fmt.Errorf("unable to extend '%s', it is both a zone and a template", parentName)
+	case isZone:
+		if !e.extend(parentName, chain) {
+			return nil, errParentNotExtended
+		}
+		return &inheritance{
+			config:          parentZone.Config,
+			ttl:             parentZone.TTL,
+			soaDefaults:     parentZone.SOADefaults,
+			resourceRecords: parentZone.ResourceRecords,
+			position:        parentZone.ResourceRecordPosition,
+		}, nil
+	case isTemplate:
+		return &inheritance{resourceRecords: template.ResourceRecords, position: template.ResourceRecordPosition}, nil
+	default:
+		return nil, fmt.Errorf("unable to extend '%s', there is no zone or template with that name", parentName)
+	}
+}
+
+func inherit(name string, zone *models.Zone, parent *inheritance) models.ValidationErrors {
+	var errs models.ValidationErrors
+	for _, identifier := range zone.Remove {
+		switch {
+		case parent.resourceRecords[identifier] == nil:
+			errs = append(errs, recordError(name, zone, identifier, models.RuleExtends, fmt.Errorf("unable to remove '%s', it isn't inherited from '%s'", identifier, zone.Extends)))
+		case zone.ResourceRecords[identifier] != nil:
+			errs = append(errs, recordError(name, zone, identifier, models.RuleExtends, fmt.Errorf("unable to remove '%s', it is also defined by the zone", identifier)))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	zone.Config = zone.Config.Inherit(parent.config)
+	if nil == zone.TTL && nil != parent.ttl {
+		ttl := *parent.ttl
+		zone.TTL = &ttl
+	}
+	zone.SOADefaults = zone.SOADefaults.WithDefaults(parent.soaDefaults).Clone()
+
+	if nil == zone.ResourceRecords {
+		zone.ResourceRecords = make(map[string]*models.ResourceRecord, len(parent.resourceRecords))
+	}
+	for _, identifier := range sortedKeys(parent.resourceRecords) {
+		if slices.Contains(zone.Remove, identifier) {
+			continue
+		}
+		if rr, ok := zone.ResourceRecords[identifier]; ok {
+			zone.ResourceRecords[identifier] = rr.Inherit(parent.resourceRecords[identifier])
+			continue
+		}
+		zone.ResourceRecords[identifier] = parent.resourceRecords[identifier].Clone()
+		zone.SetResourceRecordPosition(identifier, parent.position(identifier))
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"testing"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtendZones(t *testing.T) {
+	ttl := int32(3600)
+	newZone := func(line int, extends string, remove []string, records map[string]*models.ResourceRecord) *models.Zone {
+		zone := &models.Zone{Extends: extends, Remove: remove, ResourceRecords: records}
+		zone.SetPosition(&models.SourcePosition{File: "zones.yaml", Line: line, Column: 1})
+		return zone
+	}
+	newBase := func() *models.Zone {
+		base := newZone(1, "", nil, map[string]*models.ResourceRecord{
+			"soa": {Name: "@", Type: models.SOA, Value: "base"},
+			"www": {Name: "www", Type: models.A, Value: "192.0.2.1"},
+			"ftp": {Name: "ftp", Type: models.A, Value: "192.0.2.2"},
+		})
+		base.Config = &models.Config{GenerateSerial: true, IsCatalog: true}
+		base.SOADefaults = &models.SOAFields{MName: &models.SOAField{Value: "ns1.example.com."}, RName: &models.SOAField{Value: "hostmaster.example.com."}}
+		base.TTL = &models.TTL{Value: &ttl}
+		base.SetResourceRecordPosition("www", &models.SourcePosition{File: "zones.yaml", Line: 4, Column: 5})
+		return base
+	}
+	templates := map[string]*models.Template{
+		"hosts": {ResourceRecords: map[string]*models.ResourceRecord{"mail": {Name: "mail", Type: models.A, Value: "192.0.2.3"}}},
+	}
+
+	testCases := []struct {
+		name  string
+		zones map[string]*models.Zone
+		// The identifiers of each zone once extended
+		want    map[string][]string
+		wantErr string
+	}{
+		{
+			name: "extends-zone",
+			zones: map[string]*models.Zone{
+				"base.": newBase(),
+				"child.": newZone(10, "base.", []string{"ftp"}, map[string]*models.ResourceRecord{
+					"soa": {Name: "@", Type: models.SOA, Value: "child"},
+				}),
+			},
+			want: map[string][]string{"base.": {"ftp", "soa", "www"}, "child.": {"soa", "www"}},
+		},
+		{
+			name: "chained",
+			zones: map[string]*models.Zone{
+				"a.":    newZone(20, "b.", nil, nil),
+				"b.":    newZone(10, "base.", nil, nil),
+				"base.": newBase(),
+			},
+			want: map[string][]string{"a.": {"ftp", "soa", "www"}, "b.": {"ftp", "soa", "www"}, "base.": {"ftp", "soa", "www"}},
+		},
+		{
+			name:  "extends-template",
+			zones: map[string]*models.Zone{"child.": newZone(1, "hosts", nil, nil)},
+			want:  map[string][]string{"child.": {"mail"}},
+		},
+		{
+			name: "cycle",
+			zones: map[string]*models.Zone{
+				"a.": newZone(1, "b.", nil, nil),
+				"b.": newZone(2, "a.", nil, nil),
+			},
+			wantErr: "zones.yaml:1:1: zone 'a.': extends cycle: a. -> b. -> a.",
+		},
+		{
+			name:    "unknown",
+			zones:   map[string]*models.Zone{"child.": newZone(1, "missing.", nil, nil)},
+			wantErr: "zones.yaml:1:1: zone 'child.': unable to extend 'missing.', there is no zone or template with that name",
+		},
+		{
+			name: "zone-and-template",
+			zones: map[string]*models.Zone{
+				"hosts":  newZone(1, "", nil, nil),
+				"child.": newZone(2, "hosts", nil, nil),
+			},
+			wantErr: "zones.yaml:2:1: zone 'child.': unable to extend 'hosts', it is both a zone and a template",
+		},
+		{
+			name: "invalid-remove",
+			zones: map[string]*models.Zone{
+				"base.": newBase(),
+				"child.": newZone(10, "base.", []string{"mail", "soa"}, map[string]*models.ResourceRecord{
+					"soa": {Name: "@", Type: models.SOA, Value: "child"},
+				}),
+				"other.": newZone(20, "", []string{"www"}, nil),
+			},
+			wantErr: "found 3 errors:\n" +
+				"  zones.yaml:10:1: zone 'child.', identifier 'mail': unable to remove 'mail', it isn't inherited from 'base.'\n" +
+				"  zones.yaml:10:1: zone 'child.', identifier 'soa': unable to remove 'soa', it is also defined by the zone\n" +
+				"  zones.yaml:20:1: zone 'other.': remove can only be used with extends",
+		},
+	}
+
+	for _, tc := range testCases {
+		errs := extendZones(tc.zones, templates)
+		if tc.wantErr != "" {
+			errs.SortByPosition()
+			if errs.Error() != tc.wantErr {
+				t.Errorf("%s - incorrect error: '%s', want: '%s'", tc.name, errs, tc.wantErr)
+			}
+			continue
+		}
+		if len(errs) > 0 {
+			t.Errorf("%s - unexpected error: %s", tc.name, errs)
+			continue
+		}
+
+		for name, identifiers := range tc.want {
+			if diff := cmp.Diff(identifiers, sortedKeys(tc.zones[name].ResourceRecords)); diff != "" {
+				t.Errorf("%s - incorrect identifiers for %s:\n%s", tc.name, name, diff)
+			}
+		}
+	}
+
+	// The inherited settings and records
+	zones := map[string]*models.Zone{
+		"base.": newBase(),
+		"child.": newZone(10, "base.", nil, map[string]*models.ResourceRecord{
+			"soa": {Name: "@", Type: models.SOA, Value: "child"},
+			"ftp": {Type: models.A, TTL: &ttl},
+		}),
+	}
+	zones["child."].SOADefaults = &models.SOAFields{RName: &models.SOAField{Value: "admin.example.net."}}
+	if errs := extendZones(zones, templates); len(errs) > 0 {
+		t.Fatalf("unexpected error: %s", errs)
+	}
+	base, child := zones["base."], zones["child."]
+	if !child.Config.Equal(&models.Config{GenerateSerial: true}) {
+		t.Errorf("incorrect config: %s, want the config of base. without is_catalog", child.Config)
+	}
+	if child.TTL == nil || *child.TTL.Value != ttl {
+		t.Errorf("incorrect ttl: %s, want: %d", child.TTL, ttl)
+	}
+	wantSOADefaults := &models.SOAFields{MName: &models.SOAField{Value: "ns1.example.com."}, RName: &models.SOAField{Value: "admin.example.net."}}
+	if diff := cmp.Diff(wantSOADefaults, child.SOADefaults); diff != "" {
+		t.Errorf("incorrect SOA defaults:\n%s", diff)
+	}
+	if child.ResourceRecords["soa"].Value != "child" {
+		t.Errorf("expected the soa record of the zone to replace the inherited one, found: %s", child.ResourceRecords["soa"])
+	}
+	if child.ResourceRecords["www"] == base.ResourceRecords["www"] || child.ResourceRecords["www"].Value != "192.0.2.1" {
+		t.Errorf("expected a copy of the www record, found: %s", child.ResourceRecords["www"])
+	}
+	if ftp := child.ResourceRecords["ftp"]; ftp.Name != "ftp" || ftp.Value != "192.0.2.2" || ftp.TTL == nil || *ftp.TTL != ttl {
+		t.Errorf("expected the ftp record of the zone to be merged with the inherited one, found: %s", ftp)
+	}
+	if got := child.ResourceRecordPosition("www").String(); got != "zones.yaml:4:5" {
+		t.Errorf("incorrect position for www: '%s', want: 'zones.yaml:4:5'", got)
+	}
+}
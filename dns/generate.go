@@ -0,0 +1,234 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/bcurnow/zonemgr/utils"
+)
+
+// The types BIND documents for $GENERATE, a record rendered as a directive must be one of them
+var generateDirectiveTypes = []models.ResourceRecordType{models.A, models.AAAA, models.CNAME, models.NS, models.PTR}
+
+// Replaces every resource record that has a generate with a record for each number of its range, the records are
+// then normalized, validated and reversed like any other. The identifier of each record is the identifier of the
+// record it was expanded from followed by the number, e.g. hosts[10].
+func expandGenerateRecords(zones map[string]*models.Zone) models.ValidationErrors {
+	var errs models.ValidationErrors
+	models.WithSortedZones(zones, func(name string, zone *models.Zone) error {
+		for _, identifier := range sortedIdentifiers(zone) {
+			rr := zone.ResourceRecords[identifier]
+			if rr == nil || rr.Generate == nil {
+				continue
+			}
+
+			expanded, err := expandGenerateRecord(rr)
+			if err != nil {
+				errs = append(errs, recordError(name, zone, identifier, models.RuleGenerate, err))
+				continue
+			}
+
+			position := zone.ResourceRecordPosition(identifier)
+			delete(zone.ResourceRecords, identifier)
+			for _, n := range sortedKeys(expanded) {
+				expandedIdentifier := identifier + "[" + n + "]"
+				if _, ok := zone.ResourceRecords[expandedIdentifier]; ok {
+					errs = append(errs, recordError(name, zone, identifier, models.RuleGenerate, fmt.Errorf("unable to generate '%s', the identifier is already used", expandedIdentifier)))
+					continue
+				}
+				zone.ResourceRecords[expandedIdentifier] = expanded[n]
+				zone.SetResourceRecordPosition(expandedIdentifier, position)
+				zone.SetGeneratedFrom(expandedIdentifier, rr)
+			}
+			logger().Trace("expanded generate", "zoneName", name, "identifier", identifier, "range", rr.Generate, "count", len(expanded))
+		}
+		return nil
+	})
+	return errs
+}
+
+// Returns the records keyed by the number they were generated for
+func expandGenerateRecord(rr *models.ResourceRecord) (map[string]*models.ResourceRecord, error) {
+	if rr.Type == models.SOA || rr.SOA != nil {
+		return nil, errors.New("generate can't be used on SOA records")
+	}
+	if rr.Name == "" {
+		return nil, errors.New("generate requires a name, it's the template for the names of the records, e.g. host-$")
+	}
+	if rr.Generate.Directive {
+		if !slices.Contains(generateDirectiveTypes, rr.Type) {
+			return nil, fmt.Errorf("generate can only be rendered as a $GENERATE directive for %s records", typeList(generateDirectiveTypes))
+		}
+		if len(rr.Values) > 1 {
+			return nil, errors.New("generate can only be rendered as a $GENERATE directive for a record with a single value")
+		}
+		if _, _, ok := addressTemplate(rr); ok {
+			return nil, errors.New("generate can't be rendered as a $GENERATE directive when the value adds to an address, BIND doesn't support it")
+		}
+	}
+
+	numbers, err := rr.Generate.Numbers()
+	if err != nil {
+		return nil, err
+	}
+
+	expanded := make(map[string]*models.ResourceRecord, len(numbers))
+	for _, n := range numbers {
+		generated := rr.Clone()
+		generated.Generate = nil
+		if generated.Name, err = expandGenerateTemplate(rr.Name, n); err != nil {
+			return nil, err
+		}
+		if address, template, ok := addressTemplate(rr); ok {
+			if generated.Value, err = addToAddress(address, template, n); err != nil {
+				return nil, err
+			}
+		} else if generated.Value, err = expandGenerateTemplate(rr.Value, n); err != nil {
+			return nil, err
+		}
+		for _, value := range generated.Values {
+			if value.Value, err = expandGenerateTemplate(value.Value, n); err != nil {
+				return nil, err
+			}
+		}
+		// The numbers are zero padded so the records sort in the order they were generated
+		expanded[fmt.Sprintf("%0*d", len(strconv.Itoa(numbers[len(numbers)-1])), n)] = generated
+	}
+	return expanded, nil
+}
+
+// The value of an A or AAAA record can be an address plus a template (e.g. 10.0.0.0+$), the number the template
+// expands to is added to the address so a range can cross octet boundaries
+func addressTemplate(rr *models.ResourceRecord) (utils.IP, string, bool) {
+	if rr.Type != models.A && rr.Type != models.AAAA {
+		return utils.IP{}, "", false
+	}
+	addressText, template, ok := strings.Cut(rr.Value, "+")
+	if !ok {
+		return utils.IP{}, "", false
+	}
+	address, err := utils.ParseIP(addressText)
+	if err != nil {
+		return utils.IP{}, "", false
+	}
+	return address, template, true
+}
+
+func addToAddress(address utils.IP, template string, n int) (string, error) {
+	text, err := expandGenerateTemplate(template, n)
+	if err != nil {
+		return "", err
+	}
+	offset, err := strconv.ParseUint(text, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid generate value '%s+%s', '%s' must expand to a number that is added to the address, found '%s'", address, template, template, text)
+	}
+	sum, err := address.Add(offset)
+	if err != nil {
+		return "", fmt.Errorf("invalid generate value '%s+%s', %w", address, template, err)
+	}
+	return sum.String(), nil
+}
+
+// Replaces each $ in the template with the number, the same way the BIND $GENERATE directive does:
+//   - ${offset}, ${offset,width} and ${offset,width,base} add the offset to the number and zero pad it to the width,
+//     the base is d (decimal, the default), o (octal), x (hexadecimal) or X (upper case hexadecimal)
+//   - $$ or \$ is a $ which isn't replaced
+func expandGenerateTemplate(template string, n int) (string, error) {
+	var expanded strings.Builder
+	for i := 0; i < len(template); i++ {
+		switch {
+		case template[i] == '\\' && strings.HasPrefix(template[i+1:], "$"), strings.HasPrefix(template[i:], "$$"):
+			expanded.WriteByte('$')
+			i++
+		case template[i] != '$':
+			expanded.WriteByte(template[i])
+		case strings.HasPrefix(template[i+1:], "{"):
+			end := strings.IndexByte(template[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("invalid generate template '%s', '${' isn't closed with '}'", template)
+			}
+			modified, err := applyGenerateModifier(template[i+2:i+end], n)
+			if err != nil {
+				return "", fmt.Errorf("invalid generate template '%s', %w", template, err)
+			}
+			expanded.WriteString(modified)
+			i += end
+		default:
+			expanded.WriteString(strconv.Itoa(n))
+		}
+	}
+	return expanded.String(), nil
+}
+
+func applyGenerateModifier(modifier string, n int) (string, error) {
+	fields := strings.Split(modifier, ",")
+	if len(fields) > 3 {
+		return "", fmt.Errorf("'${%s}' must be ${offset}, ${offset,width} or ${offset,width,base}", modifier)
+	}
+
+	offset, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return "", fmt.Errorf("the offset of '${%s}' must be a number", modifier)
+	}
+	width := 0
+	if len(fields) > 1 {
+		if width, err = strconv.Atoi(fields[1]); err != nil || width < 0 {
+			return "", fmt.Errorf("the width of '${%s}' must be a positive number", modifier)
+		}
+	}
+	base := "d"
+	if len(fields) > 2 {
+		base = fields[2]
+	}
+	if !slices.Contains([]string{"d", "o", "x", "X"}, base) {
+		return "", fmt.Errorf("the base of '${%s}' must be d, o, x or X", modifier)
+	}
+	if n+offset < 0 {
+		return "", fmt.Errorf("'${%s}' is negative for %d", modifier, n)
+	}
+	return fmt.Sprintf("%0*"+base, width, n+offset), nil
+}
+
+// Renders the records expanded from rr as a $GENERATE directive, generated is one of them which has been normalized
+// so the class and TTL are rendered the same way as every other record. BIND only accepts them before the type, which
+// is where models.ResourceRecord.RenderResourceWithoutValue puts them.
+func renderGenerateDirective(rr *models.ResourceRecord, generated *models.ResourceRecord) string {
+	directive := generated.Clone()
+	directive.Name = rr.Name
+	directive.Value = rr.RetrieveSingleValue()
+	directive.Values = nil
+	directive.Comment = rr.RetrieveSingleComment()
+	return "$GENERATE " + rr.Generate.String() + " " + directive.RenderSingleValueResource()
+}
+
+func typeList(types []models.ResourceRecordType) string {
+	names := make([]string, len(types))
+	for i, rrType := range types {
+		names[i] = string(rrType)
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
@@ -0,0 +1,191 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpandGenerateTemplate(t *testing.T) {
+	testCases := []struct {
+		template string
+		n        int
+		want     string
+		wantErr  string
+	}{
+		{template: "host", n: 5, want: "host"},
+		{template: "host-$", n: 5, want: "host-5"},
+		{template: "$-$", n: 5, want: "5-5"},
+		{template: "host-${10}", n: 5, want: "host-15"},
+		{template: "host-${-5}", n: 5, want: "host-0"},
+		{template: "host-${0,3}", n: 5, want: "host-005"},
+		{template: "host-${0,4,x}", n: 255, want: "host-00ff"},
+		{template: "host-${1,0,X}", n: 254, want: "host-FF"},
+		{template: "host-${0,3,o}", n: 8, want: "host-010"},
+		{template: "cost-$$", n: 5, want: "cost-$"},
+		{template: `cost-\$`, n: 5, want: "cost-$"},
+		{template: "host-${0", n: 5, wantErr: "invalid generate template 'host-${0', '${' isn't closed with '}'"},
+		{template: "host-${a}", n: 5, wantErr: "invalid generate template 'host-${a}', the offset of '${a}' must be a number"},
+		{template: "host-${0,a}", n: 5, wantErr: "invalid generate template 'host-${0,a}', the width of '${0,a}' must be a positive number"},
+		{template: "host-${0,0,n}", n: 5, wantErr: "invalid generate template 'host-${0,0,n}', the base of '${0,0,n}' must be d, o, x or X"},
+		{template: "host-${0,0,d,1}", n: 5, wantErr: "invalid generate template 'host-${0,0,d,1}', '${0,0,d,1}' must be ${offset}, ${offset,width} or ${offset,width,base}"},
+		{template: "host-${-10}", n: 5, wantErr: "invalid generate template 'host-${-10}', '${-10}' is negative for 5"},
+	}
+
+	for _, tc := range testCases {
+		got, err := expandGenerateTemplate(tc.template, tc.n)
+		if err != nil {
+			if err.Error() != tc.wantErr {
+				t.Errorf("%s - incorrect error: '%s', want: '%s'", tc.template, err, tc.wantErr)
+			}
+			continue
+		}
+		if tc.wantErr != "" {
+			t.Errorf("%s - expected error '%s', found none", tc.template, tc.wantErr)
+		}
+		if got != tc.want {
+			t.Errorf("%s - incorrect expansion: '%s', want: '%s'", tc.template, got, tc.want)
+		}
+	}
+}
+
+func TestExpandGenerateRecords(t *testing.T) {
+	testCases := []struct {
+		name    string
+		records map[string]*models.ResourceRecord
+		want    map[string]*models.ResourceRecord
+		wantErr []string
+	}{
+		{
+			name: "template",
+			records: map[string]*models.ResourceRecord{
+				"hosts": {Name: "host-$", Type: models.A, Value: "192.0.2.$", Comment: "pool", Generate: &models.Generate{Range: "8-12", Step: 2}},
+				"www":   {Name: "www", Type: models.A, Value: "192.0.2.100"},
+			},
+			want: map[string]*models.ResourceRecord{
+				"hosts[08]": {Name: "host-8", Type: models.A, Value: "192.0.2.8", Comment: "pool"},
+				"hosts[10]": {Name: "host-10", Type: models.A, Value: "192.0.2.10", Comment: "pool"},
+				"hosts[12]": {Name: "host-12", Type: models.A, Value: "192.0.2.12", Comment: "pool"},
+				"www":       {Name: "www", Type: models.A, Value: "192.0.2.100"},
+			},
+		},
+		{
+			name: "address-arithmetic",
+			records: map[string]*models.ResourceRecord{
+				"v4": {Name: "v4-$", Type: models.A, Value: "10.0.0.254+$", Generate: &models.Generate{Range: "1-2"}},
+				"v6": {Name: "v6-$", Type: models.AAAA, Value: "2001:db8::+${0,0,d}", Generate: &models.Generate{Range: "15-16"}},
+			},
+			want: map[string]*models.ResourceRecord{
+				"v4[1]":  {Name: "v4-1", Type: models.A, Value: "10.0.0.255"},
+				"v4[2]":  {Name: "v4-2", Type: models.A, Value: "10.0.1.0"},
+				"v6[15]": {Name: "v6-15", Type: models.AAAA, Value: "2001:db8::f"},
+				"v6[16]": {Name: "v6-16", Type: models.AAAA, Value: "2001:db8::10"},
+			},
+		},
+		{
+			name: "values",
+			records: map[string]*models.ResourceRecord{
+				"mx": {Name: "mx-$", Type: models.MX, Values: []*models.ResourceRecordValue{{Value: "1$"}, {Value: "mail-$.example.com.", Comment: "mail"}}, Generate: &models.Generate{Range: "1-1"}},
+			},
+			want: map[string]*models.ResourceRecord{
+				"mx[1]": {Name: "mx-1", Type: models.MX, Values: []*models.ResourceRecordValue{{Value: "11"}, {Value: "mail-1.example.com.", Comment: "mail"}}},
+			},
+		},
+		{
+			name: "errors",
+			records: map[string]*models.ResourceRecord{
+				"address":      {Name: "a-$", Type: models.A, Value: "10.0.0.0+${0,0,x}", Generate: &models.Generate{Range: "10-10"}},
+				"directive":    {Name: "mx-$", Type: models.MX, Value: "10 mail", Generate: &models.Generate{Range: "1-2", Directive: true}},
+				"duplicate":    {Name: "d-$", Type: models.A, Value: "192.0.2.$", Generate: &models.Generate{Range: "1-1"}},
+				"duplicate[1]": {Name: "d", Type: models.A, Value: "192.0.2.1"},
+				"no-name":      {Type: models.A, Value: "192.0.2.$", Generate: &models.Generate{Range: "1-2"}},
+				"overflow":     {Name: "o-$", Type: models.A, Value: "255.255.255.255+$", Generate: &models.Generate{Range: "1-1"}},
+				"plus":         {Name: "p-$", Type: models.A, Value: "192.0.2.0+$", Generate: &models.Generate{Range: "1-1", Directive: true}},
+				"range":        {Name: "r-$", Type: models.A, Value: "192.0.2.$", Generate: &models.Generate{Range: "2-1"}},
+				"soa":          {Type: models.SOA, Name: "@", Generate: &models.Generate{Range: "1-2"}},
+				"template":     {Name: "t-${", Type: models.A, Value: "192.0.2.$", Generate: &models.Generate{Range: "1-2"}},
+				"values":       {Name: "v-$", Type: models.A, Values: []*models.ResourceRecordValue{{Value: "a"}, {Value: "b"}}, Generate: &models.Generate{Range: "1-2", Directive: true}},
+			},
+			wantErr: []string{
+				"zone 'example.com.', identifier 'address': invalid generate value '10.0.0.0+${0,0,x}', '${0,0,x}' must expand to a number that is added to the address, found 'a'",
+				"zone 'example.com.', identifier 'directive': generate can only be rendered as a $GENERATE directive for A, AAAA, CNAME, NS and PTR records",
+				"zone 'example.com.', identifier 'duplicate': unable to generate 'duplicate[1]', the identifier is already used",
+				"zone 'example.com.', identifier 'no-name': generate requires a name, it's the template for the names of the records, e.g. host-$",
+				"zone 'example.com.', identifier 'overflow': invalid generate value '255.255.255.255+$', '255.255.255.255' + 1 is past the end of the address space",
+				"zone 'example.com.', identifier 'plus': generate can't be rendered as a $GENERATE directive when the value adds to an address, BIND doesn't support it",
+				"zone 'example.com.', identifier 'range': invalid generate range '2-1', must be <start>-<stop> where start and stop are numbers and start isn't more than stop",
+				"zone 'example.com.', identifier 'soa': generate can't be used on SOA records",
+				"zone 'example.com.', identifier 'template': invalid generate template 't-${', '${' isn't closed with '}'",
+				"zone 'example.com.', identifier 'values': generate can only be rendered as a $GENERATE directive for a record with a single value",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		original := make(map[string]bool)
+		for identifier := range tc.records {
+			original[identifier] = true
+		}
+		zone := &models.Zone{ResourceRecords: tc.records}
+		var got []string
+		for _, err := range expandGenerateRecords(map[string]*models.Zone{"example.com.": zone}) {
+			if err.Rule != models.RuleGenerate {
+				t.Errorf("%s - incorrect rule: '%s', want: '%s'", tc.name, err.Rule, models.RuleGenerate)
+			}
+			got = append(got, err.Error())
+		}
+		if diff := cmp.Diff(tc.wantErr, got); diff != "" {
+			t.Errorf("%s - incorrect errors (-want +got):\n%s", tc.name, diff)
+		}
+		if tc.want == nil {
+			continue
+		}
+
+		if diff := cmp.Diff(tc.want, zone.ResourceRecords, cmp.AllowUnexported(models.ResourceRecord{})); diff != "" {
+			t.Errorf("%s - incorrect records (-want +got):\n%s", tc.name, diff)
+		}
+		for identifier := range zone.ResourceRecords {
+			if generatedFrom := zone.GeneratedFrom(identifier); (generatedFrom != nil) == original[identifier] {
+				t.Errorf("%s - incorrect record '%s' was generated from: %v", tc.name, identifier, generatedFrom)
+			}
+		}
+	}
+}
+
+func TestRenderGenerateDirective(t *testing.T) {
+	rr := &models.ResourceRecord{Name: "www-$", Type: models.CNAME, Value: "host-${10,3}", Comment: "aliases", Generate: &models.Generate{Range: "0-20", Step: 5}}
+	generated := &models.ResourceRecord{Name: "www-0", Type: models.CNAME, Class: models.INTERNET, TTL: toInt32Ptr(300), Value: "host-010", Comment: "aliases"}
+
+	got := renderGenerateDirective(rr, generated)
+	want := "$GENERATE 0-20/5 " + (&models.ResourceRecord{Name: "www-$", Type: models.CNAME, Class: models.INTERNET, TTL: toInt32Ptr(300), Value: "host-${10,3}", Comment: "aliases"}).RenderSingleValueResource()
+	if got != want {
+		t.Errorf("incorrect directive: '%s', want: '%s'", got, want)
+	}
+
+	// BIND only accepts the TTL and class before the type
+	if fields := strings.Fields(got); !slices.Equal(fields[:7], []string{"$GENERATE", "0-20/5", "www-$", "300", "IN", "CNAME", "host-${10,3}"}) {
+		t.Errorf("incorrect order of the directive: '%s'", got)
+	}
+}
@@ -0,0 +1,452 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bcurnow/zonemgr/models"
+)
+
+type ZoneImporter interface {
+	// Parses an RFC1035 master file into zones, keyed by zone name. The origin is used to resolve relative
+	// names until the file sets its own with $ORIGIN, it may be empty if the file only uses absolute names.
+	Import(zoneFile string, origin string) (map[string]*models.Zone, error)
+}
+
+type bindZoneImporter struct {
+	ZoneImporter
+}
+
+func BindZoneImporter() ZoneImporter {
+	return &bindZoneImporter{}
+}
+
+// An RR as read from the master file, with every name already made absolute
+type importedRecord struct {
+	name   string
+	rrType models.ResourceRecordType
+	class  models.ResourceRecordClass
+	ttl    *int32
+	rdata  []masterFileToken
+	// Every comment in the entry, used as the record comment when there's a single value
+	comment string
+	// The comments which weren't attached to one of the RDATA tokens
+	otherComments []string
+}
+
+// The state that $ORIGIN, $TTL and the blank owner name carry from one entry to the next
+type importState struct {
+	origin    string
+	lastOwner string
+	ttl       *int32
+}
+
+func (i *bindZoneImporter) Import(zoneFile string, origin string) (map[string]*models.Zone, error) {
+	if origin != "" {
+		origin = validations.EnsureTrailingDot(origin)
+	}
+
+	var records []*importedRecord
+	var zoneTTL *models.TTL
+	if err := i.importFile(zoneFile, &importState{origin: origin}, &records, &zoneTTL, 0); err != nil {
+		return nil, err
+	}
+
+	return buildImportedZone(zoneFile, records, zoneTTL)
+}
+
+// $INCLUDE can nest, guard against a file that (indirectly) includes itself
+const maxIncludeDepth = 10
+
+func (i *bindZoneImporter) importFile(path string, state *importState, records *[]*importedRecord, zoneTTL **models.TTL, depth int) error {
+	if depth > maxIncludeDepth {
+		return fmt.Errorf("%s: $INCLUDE nested more than %d levels deep", path, maxIncludeDepth)
+	}
+
+	logger().Debug("importing master file", "path", path, "origin", state.origin)
+	content, err := readFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to open '%s': %w", path, err)
+	}
+
+	return i.importContent(path, content, state, records, zoneTTL, depth)
+}
+
+// Imports master file content which has already been read, path is used for error messages and to resolve $INCLUDE
+func (i *bindZoneImporter) importContent(path string, content []byte, state *importState, records *[]*importedRecord, zoneTTL **models.TTL, depth int) error {
+	entries, err := tokenizeMasterFile(string(content))
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	for _, entry := range entries {
+		if err := i.importEntry(path, entry, state, records, zoneTTL, depth); err != nil {
+			return fmt.Errorf("%s:%d: %w", path, entry.line, err)
+		}
+	}
+	return nil
+}
+
+func (i *bindZoneImporter) importEntry(path string, entry *masterFileEntry, state *importState, records *[]*importedRecord, zoneTTL **models.TTL, depth int) error {
+	first := entry.tokens[0]
+	if !entry.blankOwner && !first.quoted && strings.HasPrefix(first.text, "$") {
+		return i.importDirective(path, entry, state, records, zoneTTL, depth)
+	}
+
+	tokens := entry.tokens
+	owner := state.lastOwner
+	if !entry.blankOwner {
+		name, err := absoluteName(tokens[0].text, state.origin)
+		if err != nil {
+			return err
+		}
+		owner = name
+		tokens = tokens[1:]
+	}
+	if owner == "" {
+		return fmt.Errorf("resource record has no owner name and there is no previous owner to inherit")
+	}
+	state.lastOwner = owner
+
+	record := &importedRecord{name: owner, ttl: state.ttl, comment: entry.comments(), otherComments: append([]string{}, entry.unattachedComments...)}
+	if !entry.blankOwner && entry.tokens[0].comment != "" {
+		record.otherComments = append(record.otherComments, entry.tokens[0].comment)
+	}
+	for len(tokens) > 0 && record.rrType == "" {
+		text := tokens[0].text
+		if tokens[0].comment != "" {
+			record.otherComments = append(record.otherComments, tokens[0].comment)
+		}
+		tokens = tokens[1:]
+		if class := models.ResourceRecordClass(strings.ToUpper(text)); class != "" && class.IsValid() {
+			record.class = class
+			continue
+		}
+		if ttl, err := models.ParseTimeInterval(text); err == nil {
+			record.ttl = &ttl
+			continue
+		}
+		record.rrType = models.ResourceRecordType(strings.ToUpper(text))
+	}
+	if record.rrType == "" {
+		return fmt.Errorf("resource record for '%s' is missing a type", owner)
+	}
+	if len(tokens) == 0 {
+		return fmt.Errorf("%s record for '%s' has no data", record.rrType, owner)
+	}
+
+	// The domain names are resolved against the current origin so the result doesn't depend on where $ORIGIN was
+	for _, field := range record.rrType.DomainNameFields() {
+		if field >= len(tokens) {
+			return fmt.Errorf("%s record for '%s' has too few values, found %d", record.rrType, owner, len(tokens))
+		}
+		name, err := absoluteName(tokens[field].text, state.origin)
+		if err != nil {
+			return err
+		}
+		tokens[field].text = name
+	}
+	record.rdata = tokens
+
+	*records = append(*records, record)
+	return nil
+}
+
+func (i *bindZoneImporter) importDirective(path string, entry *masterFileEntry, state *importState, records *[]*importedRecord, zoneTTL **models.TTL, depth int) error {
+	directive := strings.ToUpper(entry.tokens[0].text)
+	args := entry.tokens[1:]
+	switch directive {
+	case "$ORIGIN":
+		if len(args) != 1 {
+			return fmt.Errorf("$ORIGIN requires exactly one domain name")
+		}
+		origin, err := absoluteName(args[0].text, state.origin)
+		if err != nil {
+			return err
+		}
+		state.origin = origin
+	case "$TTL":
+		if len(args) != 1 {
+			return fmt.Errorf("$TTL requires exactly one TTL value")
+		}
+		ttl, err := models.ParseTimeInterval(args[0].text)
+		if err != nil {
+			return err
+		}
+		state.ttl = &ttl
+		if *zoneTTL == nil {
+			// The first $TTL becomes the zone's TTL, any later change is carried by the individual records
+			*zoneTTL = &models.TTL{Value: &ttl, Comment: entry.comments()}
+		}
+	case "$INCLUDE":
+		if len(args) < 1 || len(args) > 2 {
+			return fmt.Errorf("$INCLUDE requires a file name and an optional domain name")
+		}
+		includePath := args[0].text
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(filepath.Dir(path), includePath)
+		}
+		// RFC1035 5.1: the origin and current owner are restored once the included file has been read
+		includeState := &importState{origin: state.origin, lastOwner: state.lastOwner, ttl: state.ttl}
+		if len(args) == 2 {
+			origin, err := absoluteName(args[1].text, state.origin)
+			if err != nil {
+				return err
+			}
+			includeState.origin = origin
+		}
+		if err := i.importFile(includePath, includeState, records, zoneTTL, depth+1); err != nil {
+			return err
+		}
+		state.ttl = includeState.ttl
+	case "$GENERATE":
+		return i.importGenerate(path, entry, state, records, zoneTTL, depth)
+	default:
+		return fmt.Errorf("unsupported directive '%s'", entry.tokens[0].text)
+	}
+	return nil
+}
+
+// $GENERATE <range> <owner> [<ttl>] [<class>] <type> <rdata> is imported as the records it generates, each of them is
+// imported the same way as a record written out in full
+func (i *bindZoneImporter) importGenerate(path string, entry *masterFileEntry, state *importState, records *[]*importedRecord, zoneTTL **models.TTL, depth int) error {
+	args := entry.tokens[1:]
+	if len(args) < 4 {
+		return fmt.Errorf("$GENERATE requires a range, an owner name, a type and the record data")
+	}
+
+	rangeText, stepText, hasStep := strings.Cut(args[0].text, "/")
+	generate := &models.Generate{Range: rangeText}
+	if hasStep {
+		step, err := strconv.Atoi(stepText)
+		if err != nil || step < 1 {
+			return fmt.Errorf("invalid $GENERATE step '%s', must be a positive number", stepText)
+		}
+		generate.Step = step
+	}
+	numbers, err := generate.Numbers()
+	if err != nil {
+		return err
+	}
+
+	// The record data is a single token, it's quoted when it has more than one field (e.g. "10 mail-$") but for a
+	// character-string it's the string itself
+	rdata := args[len(args)-1]
+	splitRData := rdata.quoted && !isCharacterStringType(models.ResourceRecordType(strings.ToUpper(args[len(args)-2].text)))
+
+	// $GENERATE doesn't change the owner a record with a blank owner name inherits
+	lastOwner := state.lastOwner
+	defer func() { state.lastOwner = lastOwner }()
+	for _, n := range numbers {
+		generated := &masterFileEntry{line: entry.line, unattachedComments: entry.unattachedComments}
+		for _, token := range args[1:] {
+			text, err := expandGenerateTemplate(token.text, n)
+			if err != nil {
+				return err
+			}
+			token.text = text
+			generated.tokens = append(generated.tokens, token)
+		}
+		if splitRData {
+			last := generated.tokens[len(generated.tokens)-1]
+			generated.tokens = generated.tokens[:len(generated.tokens)-1]
+			for _, field := range strings.Fields(last.text) {
+				generated.tokens = append(generated.tokens, masterFileToken{text: field})
+			}
+			generated.tokens[len(generated.tokens)-1].comment = last.comment
+		}
+		if err := i.importEntry(path, generated, state, records, zoneTTL, depth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Converts the imported records into a zone. The SOA record determines the name of the zone, owner names
+// within the zone are made relative to it so the YAML reads the same way a hand written one would.
+func buildImportedZone(zoneFile string, records []*importedRecord, zoneTTL *models.TTL) (map[string]*models.Zone, error) {
+	var zoneName string
+	for _, record := range records {
+		if record.rrType == models.SOA {
+			if zoneName != "" {
+				return nil, fmt.Errorf("%s: more than one SOA record found", zoneFile)
+			}
+			zoneName = record.name
+		}
+	}
+	if zoneName == "" {
+		return nil, fmt.Errorf("%s: no SOA record found, unable to determine the zone name", zoneFile)
+	}
+
+	identifiers := importIdentifiers(zoneName, records)
+	zone := &models.Zone{
+		ResourceRecords: make(map[string]*models.ResourceRecord, len(records)),
+		TTL:             zoneTTL,
+	}
+	for i, record := range records {
+		rr := &models.ResourceRecord{
+			Name:  relativeName(record.name, zoneName),
+			Type:  record.rrType,
+			Class: record.class,
+		}
+		if record.rrType == models.SOA {
+			// The SOA plugin requires the name to be fully qualified
+			rr.Name = record.name
+		}
+		if record.ttl != nil && (zoneTTL == nil || *record.ttl != *zoneTTL.Value) {
+			ttl := *record.ttl
+			rr.TTL = &ttl
+		}
+		importRecordData(zoneName, record, rr)
+		zone.ResourceRecords[identifiers[i]] = rr
+	}
+
+	return map[string]*models.Zone{zoneName: zone}, nil
+}
+
+// Converts the RDATA tokens into either the single value shortcut or a list of values. Character-strings
+// are stored without their quotes (TXT values are expected to carry their RFC1035 escaping, not quotes),
+// every other type keeps the text exactly as it was written.
+func importRecordData(zoneName string, record *importedRecord, rr *models.ResourceRecord) {
+	values := make([]*models.ResourceRecordValue, len(record.rdata))
+	for i, token := range record.rdata {
+		value := token.text
+		if token.quoted && !isCharacterStringType(record.rrType) {
+			value = `"` + value + `"`
+		}
+		values[i] = &models.ResourceRecordValue{Value: value, Comment: token.comment}
+	}
+
+	// A CNAME is normally written relative to the zone so it lines up with the name of the record it points at
+	if record.rrType == models.CNAME {
+		values[0].Value = relativeName(values[0].Value, zoneName)
+	}
+
+	if len(values) == 1 && record.rrType != models.SOA {
+		rr.Value = values[0].Value
+		rr.Comment = record.comment
+		return
+	}
+
+	// Any comment that wasn't on one of the values (e.g. after the opening parenthesis) goes on the first one
+	if len(record.otherComments) > 0 {
+		values[0].Comment = strings.TrimSpace(strings.Join(record.otherComments, " ") + " " + values[0].Comment)
+	}
+	rr.Values = values
+}
+
+func isCharacterStringType(rrType models.ResourceRecordType) bool {
+	return rrType == models.TXT || rrType == models.SPF || rrType == models.CAA
+}
+
+// Derives an identifier for each record that only depends on the content of the file: the owner name when it
+// is unique, otherwise the owner name and type, with a counter (in file order) if that still isn't unique. An
+// identifier made from the type can be the name of another owner (e.g. www-a for www A and www-a CNAME), another
+// counter is added until it isn't an owner name or an identifier that's already used.
+func importIdentifiers(zoneName string, records []*importedRecord) []string {
+	bases := make([]string, len(records))
+	byName := make(map[string]int)
+	byNameAndType := make(map[string]int)
+	used := make(map[string]bool)
+	for i, record := range records {
+		bases[i] = relativeName(record.name, zoneName)
+		if bases[i] == "@" {
+			bases[i] = zoneName
+		}
+		byName[bases[i]]++
+		byNameAndType[bases[i]+" "+string(record.rrType)]++
+		used[bases[i]] = true
+	}
+
+	identifiers := make([]string, len(records))
+	counters := make(map[string]int)
+	for i, record := range records {
+		if byName[bases[i]] == 1 {
+			identifiers[i] = bases[i]
+			continue
+		}
+
+		identifier := strings.TrimSuffix(bases[i], ".") + "-" + strings.ToLower(string(record.rrType))
+		key := bases[i] + " " + string(record.rrType)
+		if byNameAndType[key] > 1 {
+			counters[key]++
+			identifier = identifier + "-" + strconv.Itoa(counters[key])
+		}
+		for unique, n := identifier, 2; ; n++ {
+			if !used[unique] {
+				identifier = unique
+				break
+			}
+			unique = identifier + "-" + strconv.Itoa(n)
+		}
+		used[identifier] = true
+		identifiers[i] = identifier
+	}
+	return identifiers
+}
+
+// Resolves a master file name against the origin: '@' is the origin itself, a name ending in an unescaped
+// dot is already absolute and anything else is relative to the origin.
+func absoluteName(name string, origin string) (string, error) {
+	if name == "@" {
+		if origin == "" {
+			return "", fmt.Errorf("'@' used but no origin is set, use $ORIGIN or specify the origin")
+		}
+		return origin, nil
+	}
+	if isAbsoluteName(name) {
+		return name, nil
+	}
+	if origin == "" {
+		return "", fmt.Errorf("relative name '%s' used but no origin is set, use $ORIGIN or specify the origin", name)
+	}
+	if origin == "." {
+		return name + ".", nil
+	}
+	return name + "." + origin, nil
+}
+
+func isAbsoluteName(name string) bool {
+	if !strings.HasSuffix(name, ".") {
+		return false
+	}
+	// The final dot only terminates the name if it isn't escaped, i.e. it's preceded by an even number of backslashes
+	backslashes := 0
+	for i := len(name) - 2; i >= 0 && name[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 0
+}
+
+// Returns name relative to zoneName ('@' for the apex), names outside of the zone are returned unchanged
+func relativeName(name string, zoneName string) string {
+	if strings.EqualFold(name, zoneName) {
+		return "@"
+	}
+	suffix := "." + zoneName
+	if len(name) > len(suffix) && strings.EqualFold(name[len(name)-len(suffix):], suffix) && isAbsoluteName(name[:len(name)-len(zoneName)]) {
+		return name[:len(name)-len(suffix)]
+	}
+	return name
+}
@@ -0,0 +1,279 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+// Compares the unexported fields of every models type, including the ones embedded in a Zone
+var allowModelsUnexported = cmp.Exporter(func(t reflect.Type) bool {
+	return t.PkgPath() == reflect.TypeOf(models.Zone{}).PkgPath()
+})
+
+func TestBindZoneImporter(t *testing.T) {
+	res1 := BindZoneImporter()
+	res2 := BindZoneImporter()
+
+	if res1 == res2 {
+		t.Errorf("expected a new instance on each call, got same instance")
+	}
+}
+
+func TestImport(t *testing.T) {
+	zones, err := BindZoneImporter().Import("import.example.com.zone", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]*models.Zone{
+		"example.com.": {
+			TTL: &models.TTL{Value: toInt32Ptr(3600), Comment: "one hour"},
+			ResourceRecords: map[string]*models.ResourceRecord{
+				"example.com-soa": {
+					Name:  "example.com.",
+					Type:  models.SOA,
+					Class: models.INTERNET,
+					Values: []*models.ResourceRecordValue{
+						{Value: "ns1.example.com."},
+						{Value: "hostmaster.example.com."},
+						{Value: "2025080301", Comment: "serial"},
+						{Value: "7200", Comment: "refresh"},
+						{Value: "600", Comment: "retry"},
+						{Value: "3600000", Comment: "expire"},
+						{Value: "172800", Comment: "minimum"},
+					},
+				},
+				"example.com-ns-1": {Name: "@", Type: models.NS, Class: models.INTERNET, Value: "ns1.example.com."},
+				"example.com-ns-2": {Name: "@", Type: models.NS, Class: models.INTERNET, Value: "ns2.example.net."},
+				"ns1":              {Name: "ns1", Type: models.A, Class: models.INTERNET, Value: "192.0.2.1"},
+				"www-a":            {Name: "www", Type: models.A, Class: models.INTERNET, TTL: toInt32Ptr(300), Value: "192.0.2.10", Comment: "the web server"},
+				"www-aaaa":         {Name: "www", Type: models.AAAA, Class: models.INTERNET, Value: "2001:db8::10"},
+				"base":             {Name: "base", Type: models.CNAME, Value: "www"},
+				"example.com-txt": {
+					Name: "@",
+					Type: models.TXT,
+					Values: []*models.ResourceRecordValue{
+						{Value: "v=spf1 -all"},
+						{Value: `with a \"quote\"`},
+					},
+				},
+				"example.com-caa": {
+					Name: "@",
+					Type: models.CAA,
+					Values: []*models.ResourceRecordValue{
+						{Value: "0"},
+						{Value: "issue"},
+						{Value: "ca.example.net; account=230123"},
+					},
+				},
+				"dhcp-1":    {Name: "dhcp-1", Type: models.A, Value: "192.0.2.1", Comment: "the dhcp pool"},
+				"dhcp-2":    {Name: "dhcp-2", Type: models.A, Value: "192.0.2.2", Comment: "the dhcp pool"},
+				"mx1":       {Name: "mx1", Type: models.MX, Values: []*models.ResourceRecordValue{{Value: "10"}, {Value: "mail-01.example.com."}}},
+				"mx3":       {Name: "mx3", Type: models.MX, Values: []*models.ResourceRecordValue{{Value: "10"}, {Value: "mail-03.example.com."}}},
+				"host1.lab": {Name: "host1.lab", Type: models.A, Value: "192.0.2.101"},
+				"host2.lab": {Name: "host2.lab", Type: models.A, Value: "192.0.2.102"},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, zones, allowModelsUnexported); diff != "" {
+		t.Errorf("incorrect zones (-want +got):\n%s", diff)
+	}
+
+	// Importing the same file again must produce exactly the same result
+	again, err := BindZoneImporter().Import("import.example.com.zone", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(zones, again, allowModelsUnexported); diff != "" {
+		t.Errorf("re-import is not deterministic (-first +second):\n%s", diff)
+	}
+}
+
+func TestImport_Errors(t *testing.T) {
+	testCases := []struct {
+		content string
+		origin  string
+		want    string
+	}{
+		{"@ IN SOA ns1 hostmaster 1 2 3 4 5\n", "", "testing.zone:1: '@' used but no origin is set, use $ORIGIN or specify the origin"},
+		{"www IN A 192.0.2.1\n", "", "testing.zone:1: relative name 'www' used but no origin is set, use $ORIGIN or specify the origin"},
+		{"www IN A 192.0.2.1\n", "example.com", "testing.zone: no SOA record found, unable to determine the zone name"},
+		{"@ SOA ns1 hm 1 2 3 4 5\n@ SOA ns1 hm 1 2 3 4 5\n", "example.com.", "testing.zone: more than one SOA record found"},
+		{" IN A 192.0.2.1\n", "example.com.", "testing.zone:1: resource record has no owner name and there is no previous owner to inherit"},
+		{"www IN 300\n", "example.com.", "testing.zone:1: resource record for 'www.example.com.' is missing a type"},
+		{"www IN A\n", "example.com.", "testing.zone:1: A record for 'www.example.com.' has no data"},
+		{"mail MX 10\n", "example.com.", "testing.zone:1: MX record for 'mail.example.com.' has too few values, found 1"},
+		{"$ORIGIN\n", "", "testing.zone:1: $ORIGIN requires exactly one domain name"},
+		{"$TTL 1x\n", "", "testing.zone:1: invalid time interval '1x', expected a number of seconds or numbers with a unit (w, d, h, m or s) e.g. 1h30m"},
+		{"$TTL\n", "", "testing.zone:1: $TTL requires exactly one TTL value"},
+		{"$INCLUDE\n", "", "testing.zone:1: $INCLUDE requires a file name and an optional domain name"},
+		{"$INCLUDE missing.zone\n", "", "testing.zone:1: failed to open 'missing.zone': open missing.zone: no such file or directory"},
+		{"$GENERATE 1-10 host$ A\n", "example.com.", "testing.zone:1: $GENERATE requires a range, an owner name, a type and the record data"},
+		{"$GENERATE 10-1 host$ A 192.0.2.$\n", "example.com.", "testing.zone:1: invalid generate range '10-1', must be <start>-<stop> where start and stop are numbers and start isn't more than stop"},
+		{"$GENERATE 1-10/0 host$ A 192.0.2.$\n", "example.com.", "testing.zone:1: invalid $GENERATE step '0', must be a positive number"},
+		{"$GENERATE 1-10 host${1 A 192.0.2.$\n", "example.com.", "testing.zone:1: invalid generate template 'host${1', '${' isn't closed with '}'"},
+		{"$GENERATE 1-10 host$ 300 192.0.2.$\n", "example.com.", "testing.zone:1: 192.0.2.1 record for 'host1.example.com.' has no data"},
+		{"$UNKNOWN\n", "", "testing.zone:1: unsupported directive '$UNKNOWN'"},
+		{"www IN A (192.0.2.1\n", "example.com.", "testing.zone: line 2: unbalanced '(', missing ')'"},
+	}
+
+	for _, tc := range testCases {
+		readFile = func(name string) ([]byte, error) {
+			if name == "testing.zone" {
+				return []byte(tc.content), nil
+			}
+			return os.ReadFile(name)
+		}
+
+		_, err := BindZoneImporter().Import("testing.zone", tc.origin)
+		if err == nil {
+			t.Errorf("%q: expected an error, found none", tc.content)
+			continue
+		}
+		if err.Error() != tc.want {
+			t.Errorf("%q: incorrect error: '%s', want: '%s'", tc.content, err, tc.want)
+		}
+	}
+	readFile = os.ReadFile
+}
+
+func TestImport_IncludeDepth(t *testing.T) {
+	defer func() { readFile = os.ReadFile }()
+	readFile = func(_ string) ([]byte, error) { return []byte("$INCLUDE testing.zone\n"), nil }
+
+	_, err := BindZoneImporter().Import("testing.zone", "")
+	if err == nil {
+		t.Fatal("expected an error, found none")
+	}
+	// Each level of $INCLUDE adds its own file and line to the error
+	want := "testing.zone: $INCLUDE nested more than 10 levels deep"
+	if !strings.HasSuffix(err.Error(), want) {
+		t.Errorf("incorrect error: '%s', want it to end with: '%s'", err, want)
+	}
+}
+
+func TestImport_TTLChange(t *testing.T) {
+	defer func() { readFile = os.ReadFile }()
+	readFile = func(_ string) ([]byte, error) {
+		return []byte("$TTL 300\n@ SOA ns1 hm 1 2 3 4 5\n$TTL 1d\nwww A 192.0.2.1\n"), nil
+	}
+
+	zones, err := BindZoneImporter().Import("testing.zone", "example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	zone := zones["example.com."]
+	if *zone.TTL.Value != 300 {
+		t.Errorf("incorrect zone TTL: %d, want: 300", *zone.TTL.Value)
+	}
+	if zone.ResourceRecords["example.com-soa"] != nil {
+		t.Error("expected the SOA record to use the zone name as the identifier when it is unique")
+	}
+	if soa := zone.ResourceRecords["example.com."]; soa == nil || soa.TTL != nil {
+		t.Errorf("expected an SOA record without a TTL, found: %s", soa)
+	}
+	if www := zone.ResourceRecords["www"]; www.TTL == nil || *www.TTL != 86400 {
+		t.Errorf("expected the www record to carry the changed $TTL, found: %s", www)
+	}
+}
+
+func TestImport_IdentifierCollision(t *testing.T) {
+	defer func() { readFile = os.ReadFile }()
+	readFile = func(_ string) ([]byte, error) {
+		return []byte("@ SOA ns1 hm 1 2 3 4 5\nwww A 192.0.2.1\nwww AAAA 2001:db8::1\nwww-a CNAME www\nwww-a-2 TXT taken\n"), nil
+	}
+
+	zones, err := BindZoneImporter().Import("testing.zone", "example.com.")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// www-a and www-a-2 are owner names so the A record of www needs another counter
+	want := map[string]models.ResourceRecordType{
+		"example.com.": models.SOA,
+		"www-a-3":      models.A,
+		"www-aaaa":     models.AAAA,
+		"www-a":        models.CNAME,
+		"www-a-2":      models.TXT,
+	}
+	records := zones["example.com."].ResourceRecords
+	if len(records) != len(want) {
+		t.Errorf("incorrect number of records: %d, want: %d", len(records), len(want))
+	}
+	for identifier, rrType := range want {
+		if rr := records[identifier]; rr == nil || rr.Type != rrType {
+			t.Errorf("incorrect record for '%s': %v, want type: %s", identifier, rr, rrType)
+		}
+	}
+}
+
+func TestAbsoluteName(t *testing.T) {
+	testCases := []struct {
+		name   string
+		origin string
+		want   string
+	}{
+		{"@", "example.com.", "example.com."},
+		{"www", "example.com.", "www.example.com."},
+		{"www.example.com.", "example.org.", "www.example.com."},
+		{`www\.`, "example.com.", `www\..example.com.`},
+		{"com", ".", "com."},
+	}
+
+	for _, tc := range testCases {
+		got, err := absoluteName(tc.name, tc.origin)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.name, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: incorrect name: '%s', want: '%s'", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestRelativeName(t *testing.T) {
+	testCases := []struct {
+		name string
+		want string
+	}{
+		{"example.com.", "@"},
+		{"EXAMPLE.com.", "@"},
+		{"www.example.com.", "www"},
+		{"a.b.example.com.", "a.b"},
+		{"www.example.net.", "www.example.net."},
+		{"wwwexample.com.", "wwwexample.com."},
+		{`www\.example.com.`, `www\.example.com.`},
+	}
+
+	for _, tc := range testCases {
+		if got := relativeName(tc.name, "example.com."); got != tc.want {
+			t.Errorf("%s: incorrect name: '%s', want: '%s'", tc.name, got, tc.want)
+		}
+	}
+}
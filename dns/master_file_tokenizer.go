@@ -0,0 +1,172 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A single item from a master file entry. Escapes (\X and \DDD) are kept exactly as written, a quoted
+// character-string is stored without its surrounding quotes.
+type masterFileToken struct {
+	text    string
+	quoted  bool
+	comment string
+}
+
+// A logical entry from a master file (RFC1035 5.1): a directive or a resource record, which may span
+// several lines when parentheses are used.
+type masterFileEntry struct {
+	line       int
+	blankOwner bool
+	tokens     []masterFileToken
+	// Comments within the entry which were on a line with no token to attach them to
+	unattachedComments []string
+}
+
+// Returns all the comments for the entry, in order
+func (e *masterFileEntry) comments() string {
+	comments := append([]string{}, e.unattachedComments...)
+	for _, token := range e.tokens {
+		if token.comment != "" {
+			comments = append(comments, token.comment)
+		}
+	}
+	return strings.Join(comments, " ")
+}
+
+// Splits master file content into entries. A comment is attached to the last token on the same line so the
+// per-value comments commonly found in multi-line SOA records are kept with the value they describe.
+func tokenizeMasterFile(content string) ([]*masterFileEntry, error) {
+	var entries []*masterFileEntry
+	var current *masterFileEntry
+	line := 1
+	lastTokenLine := 0
+	depth := 0
+	lineStartsBlank := false
+	atLineStart := true
+
+	finishEntry := func() {
+		if current != nil {
+			entries = append(entries, current)
+			current = nil
+		}
+	}
+
+	addToken := func(token masterFileToken) {
+		if current == nil {
+			current = &masterFileEntry{line: line, blankOwner: lineStartsBlank}
+		}
+		current.tokens = append(current.tokens, token)
+		lastTokenLine = line
+	}
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		if atLineStart && depth == 0 && current == nil {
+			lineStartsBlank = c == ' ' || c == '\t'
+		}
+		atLineStart = false
+
+		switch c {
+		case '\n':
+			line++
+			atLineStart = true
+			if depth == 0 {
+				finishEntry()
+			}
+			i++
+		case ' ', '\t', '\r':
+			i++
+		case ';':
+			end := strings.IndexByte(content[i:], '\n')
+			if end < 0 {
+				end = len(content) - i
+			}
+			comment := strings.TrimSpace(strings.TrimLeft(content[i:i+end], ";"))
+			i += end
+			if current == nil || comment == "" {
+				continue
+			}
+			if lastTokenLine == line {
+				last := &current.tokens[len(current.tokens)-1]
+				last.comment = strings.TrimSpace(last.comment + " " + comment)
+			} else {
+				current.unattachedComments = append(current.unattachedComments, comment)
+			}
+		case '(':
+			depth++
+			if current == nil {
+				return nil, fmt.Errorf("line %d: '(' found before the start of an entry", line)
+			}
+			i++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("line %d: unbalanced ')'", line)
+			}
+			i++
+		case '"':
+			var text strings.Builder
+			i++
+			closed := false
+			for i < len(content) {
+				if content[i] == '\\' && i+1 < len(content) {
+					text.WriteString(content[i : i+2])
+					i += 2
+					continue
+				}
+				if content[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				if content[i] == '\n' {
+					break
+				}
+				text.WriteByte(content[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("line %d: unterminated quoted string", line)
+			}
+			addToken(masterFileToken{text: text.String(), quoted: true})
+		default:
+			var text strings.Builder
+			for i < len(content) && !strings.ContainsRune(" \t\r\n;()\"", rune(content[i])) {
+				if content[i] == '\\' && i+1 < len(content) {
+					text.WriteString(content[i : i+2])
+					i += 2
+					continue
+				}
+				text.WriteByte(content[i])
+				i++
+			}
+			addToken(masterFileToken{text: text.String()})
+		}
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced '(', missing ')'", line)
+	}
+	finishEntry()
+	return entries, nil
+}
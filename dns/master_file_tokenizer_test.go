@@ -0,0 +1,87 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTokenizeMasterFile(t *testing.T) {
+	content := "; leading comment\n" +
+		"$ORIGIN example.com.\n" +
+		"www IN A 192.0.2.1 ; web\n" +
+		"\tIN TXT \"a \\\"quoted\\\" string\" unquoted\\ space\n" +
+		"@ SOA ns1 hm ( ; the SOA\n" +
+		"  1 ; serial\n" +
+		"  ; on its own\n" +
+		"  2 3 4 5 )\n"
+
+	entries, err := tokenizeMasterFile(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []*masterFileEntry{
+		{line: 2, tokens: []masterFileToken{{text: "$ORIGIN"}, {text: "example.com."}}},
+		{line: 3, tokens: []masterFileToken{{text: "www"}, {text: "IN"}, {text: "A"}, {text: "192.0.2.1", comment: "web"}}},
+		{line: 4, blankOwner: true, tokens: []masterFileToken{{text: "IN"}, {text: "TXT"}, {text: `a \"quoted\" string`, quoted: true}, {text: `unquoted\ space`}}},
+		{
+			line: 5,
+			tokens: []masterFileToken{
+				{text: "@"}, {text: "SOA"}, {text: "ns1"}, {text: "hm", comment: "the SOA"},
+				{text: "1", comment: "serial"}, {text: "2"}, {text: "3"}, {text: "4"}, {text: "5"},
+			},
+			unattachedComments: []string{"on its own"},
+		},
+	}
+
+	if diff := cmp.Diff(want, entries, cmp.AllowUnexported(masterFileEntry{}, masterFileToken{})); diff != "" {
+		t.Errorf("incorrect entries (-want +got):\n%s", diff)
+	}
+
+	if comments := entries[3].comments(); comments != "on its own the SOA serial" {
+		t.Errorf("incorrect comments: '%s', want: 'on its own the SOA serial'", comments)
+	}
+}
+
+func TestTokenizeMasterFile_Errors(t *testing.T) {
+	testCases := []struct {
+		content string
+		want    string
+	}{
+		{"(\n", "line 1: '(' found before the start of an entry"},
+		{"www A 1 )\n", "line 1: unbalanced ')'"},
+		{"www A ( 1\n", "line 2: unbalanced '(', missing ')'"},
+		{"www TXT \"open\n", "line 1: unterminated quoted string"},
+	}
+
+	for _, tc := range testCases {
+		_, err := tokenizeMasterFile(tc.content)
+		if err == nil {
+			t.Errorf("%q: expected an error, found none", tc.content)
+			continue
+		}
+		if err.Error() != tc.want {
+			t.Errorf("%q: incorrect error: '%s', want: '%s'", tc.content, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dns/importer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=dns/importer.go -package dns -self_package github.com/bcurnow/zonemgr/dns
+//
+
+// Package dns is a generated GoMock package.
+package dns
+
+import (
+	reflect "reflect"
+
+	models "github.com/bcurnow/zonemgr/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockZoneImporter is a mock of ZoneImporter interface.
+type MockZoneImporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockZoneImporterMockRecorder
+	isgomock struct{}
+}
+
+// MockZoneImporterMockRecorder is the mock recorder for MockZoneImporter.
+type MockZoneImporterMockRecorder struct {
+	mock *MockZoneImporter
+}
+
+// NewMockZoneImporter creates a new mock instance.
+func NewMockZoneImporter(ctrl *gomock.Controller) *MockZoneImporter {
+	mock := &MockZoneImporter{ctrl: ctrl}
+	mock.recorder = &MockZoneImporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockZoneImporter) EXPECT() *MockZoneImporterMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockZoneImporter) Import(zoneFile, origin string) (map[string]*models.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", zoneFile, origin)
+	ret0, _ := ret[0].(map[string]*models.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockZoneImporterMockRecorder) Import(zoneFile, origin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockZoneImporter)(nil).Import), zoneFile, origin)
+}
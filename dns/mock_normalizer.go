@@ -47,3 +47,17 @@ func (mr *MockNormalizerMockRecorder) Normalize(zones interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Normalize", reflect.TypeOf((*MockNormalizer)(nil).Normalize), zones)
 }
+
+// Warnings mocks base method.
+func (m *MockNormalizer) Warnings() models.ValidationErrors {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Warnings")
+	ret0, _ := ret[0].(models.ValidationErrors)
+	return ret0
+}
+
+// Warnings indicates an expected call of Warnings.
+func (mr *MockNormalizerMockRecorder) Warnings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warnings", reflect.TypeOf((*MockNormalizer)(nil).Warnings))
+}
@@ -1,5 +1,10 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: dns/parser.go
+//
+// Generated by this command:
+//
+//	mockgen -source=dns/parser.go -package dns -self_package github.com/bcurnow/zonemgr/dns
+//
 
 // Package dns is a generated GoMock package.
 package dns
@@ -15,6 +20,7 @@ import (
 type MockZoneParser struct {
 	ctrl     *gomock.Controller
 	recorder *MockZoneParserMockRecorder
+	isgomock struct{}
 }
 
 // MockZoneParserMockRecorder is the mock recorder for MockZoneParser.
@@ -35,16 +41,30 @@ func (m *MockZoneParser) EXPECT() *MockZoneParserMockRecorder {
 }
 
 // Parse mocks base method.
-func (m *MockZoneParser) Parse(inputFile string) (map[string]*models.Zone, error) {
+func (m *MockZoneParser) Parse(inputs []string) (map[string]*models.Zone, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Parse", inputFile)
+	ret := m.ctrl.Call(m, "Parse", inputs)
 	ret0, _ := ret[0].(map[string]*models.Zone)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Parse indicates an expected call of Parse.
-func (mr *MockZoneParserMockRecorder) Parse(inputFile interface{}) *gomock.Call {
+func (mr *MockZoneParserMockRecorder) Parse(inputs any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockZoneParser)(nil).Parse), inputFile)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockZoneParser)(nil).Parse), inputs)
+}
+
+// Warnings mocks base method.
+func (m *MockZoneParser) Warnings() models.ValidationErrors {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Warnings")
+	ret0, _ := ret[0].(models.ValidationErrors)
+	return ret0
+}
+
+// Warnings indicates an expected call of Warnings.
+func (mr *MockZoneParserMockRecorder) Warnings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warnings", reflect.TypeOf((*MockZoneParser)(nil).Warnings))
 }
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dns/zone_differ.go
+//
+// Generated by this command:
+//
+//	mockgen -source=dns/zone_differ.go -package dns -self_package github.com/bcurnow/zonemgr/dns
+//
+
+// Package dns is a generated GoMock package.
+package dns
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockZoneDiffer is a mock of ZoneDiffer interface.
+type MockZoneDiffer struct {
+	ctrl     *gomock.Controller
+	recorder *MockZoneDifferMockRecorder
+	isgomock struct{}
+}
+
+// MockZoneDifferMockRecorder is the mock recorder for MockZoneDiffer.
+type MockZoneDifferMockRecorder struct {
+	mock *MockZoneDiffer
+}
+
+// NewMockZoneDiffer creates a new mock instance.
+func NewMockZoneDiffer(ctrl *gomock.Controller) *MockZoneDiffer {
+	mock := &MockZoneDiffer{ctrl: ctrl}
+	mock.recorder = &MockZoneDifferMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockZoneDiffer) EXPECT() *MockZoneDifferMockRecorder {
+	return m.recorder
+}
+
+// Diff mocks base method.
+func (m *MockZoneDiffer) Diff(zoneName, path string, rendered []byte) ([]*RecordChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", zoneName, path, rendered)
+	ret0, _ := ret[0].([]*RecordChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockZoneDifferMockRecorder) Diff(zoneName, path, rendered any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockZoneDiffer)(nil).Diff), zoneName, path, rendered)
+}
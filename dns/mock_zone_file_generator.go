@@ -1,5 +1,10 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: dns/zone_file_generator.go
+//
+// Generated by this command:
+//
+//	mockgen -source=dns/zone_file_generator.go -package dns -self_package github.com/bcurnow/zonemgr/dns
+//
 
 // Package dns is a generated GoMock package.
 package dns
@@ -8,6 +13,7 @@ import (
 	reflect "reflect"
 
 	models "github.com/bcurnow/zonemgr/models"
+	utils "github.com/bcurnow/zonemgr/utils"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -15,6 +21,7 @@ import (
 type MockZoneFileGenerator struct {
 	ctrl     *gomock.Controller
 	recorder *MockZoneFileGeneratorMockRecorder
+	isgomock struct{}
 }
 
 // MockZoneFileGeneratorMockRecorder is the mock recorder for MockZoneFileGenerator.
@@ -35,15 +42,30 @@ func (m *MockZoneFileGenerator) EXPECT() *MockZoneFileGeneratorMockRecorder {
 }
 
 // GenerateZone mocks base method.
-func (m *MockZoneFileGenerator) GenerateZone(name string, zone *models.Zone, outputDir string) error {
+func (m *MockZoneFileGenerator) GenerateZone(name string, zone *models.Zone, outputDir string, tx utils.FileTransaction) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateZone", name, zone, outputDir)
+	ret := m.ctrl.Call(m, "GenerateZone", name, zone, outputDir, tx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // GenerateZone indicates an expected call of GenerateZone.
-func (mr *MockZoneFileGeneratorMockRecorder) GenerateZone(name, zone, outputDir interface{}) *gomock.Call {
+func (mr *MockZoneFileGeneratorMockRecorder) GenerateZone(name, zone, outputDir, tx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateZone", reflect.TypeOf((*MockZoneFileGenerator)(nil).GenerateZone), name, zone, outputDir)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateZone", reflect.TypeOf((*MockZoneFileGenerator)(nil).GenerateZone), name, zone, outputDir, tx)
+}
+
+// RenderZone mocks base method.
+func (m *MockZoneFileGenerator) RenderZone(name string, zone *models.Zone) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderZone", name, zone)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenderZone indicates an expected call of RenderZone.
+func (mr *MockZoneFileGeneratorMockRecorder) RenderZone(name, zone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderZone", reflect.TypeOf((*MockZoneFileGenerator)(nil).RenderZone), name, zone)
 }
@@ -20,72 +20,183 @@
 package dns
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/bcurnow/zonemgr/models"
 	"github.com/bcurnow/zonemgr/plugins"
+	"github.com/bcurnow/zonemgr/utils"
 )
 
 type Normalizer interface {
+	// Normalizes and validates the zones, the problems are returned as models.ValidationErrors if any of them is an error
 	Normalize(zones map[string]*models.Zone) error
+	// The warnings found by every call to Normalize that didn't return an error
+	Warnings() models.ValidationErrors
 }
 
 type pluginNormalizer struct {
 	Normalizer
 	plugins  map[plugins.Type]plugins.ZoneMgrPlugin
 	metadata map[plugins.Type]*plugins.Metadata
+	defaults *models.Config
+	// The configs that already had the defaults applied, reverse zones share the config of the zone they came from
+	withDefaults map[*models.Config]bool
+	// The config the plugins were last configured with
+	configured *models.Config
+	warnings   models.ValidationErrors
 }
 
-func PluginNormalizer(plugins map[plugins.Type]plugins.ZoneMgrPlugin, metadata map[plugins.Type]*plugins.Metadata) Normalizer {
-	return &pluginNormalizer{plugins: plugins, metadata: metadata}
+// The defaults are used for every setting a zone's config doesn't set, they can be nil
+func PluginNormalizer(plugins map[plugins.Type]plugins.ZoneMgrPlugin, metadata map[plugins.Type]*plugins.Metadata, defaults *models.Config) Normalizer {
+	return &pluginNormalizer{plugins: plugins, metadata: metadata, defaults: defaults, withDefaults: make(map[*models.Config]bool)}
 }
 
+// Every zone is normalized even when an earlier one has errors, the errors are collected and returned as
+// models.ValidationErrors in the order they appear in the input so they can all be fixed at once. When there are only
+// warnings, the zones are valid and the warnings are kept for Warnings.
 func (n *pluginNormalizer) Normalize(zones map[string]*models.Zone) error {
 	logger().Trace("normalizing zones", "count", len(zones))
 	if len(zones) == 0 {
 		return fmt.Errorf("no zones found")
 	}
 
-	return models.WithSortedZones(zones, func(name string, zone *models.Zone) error {
-		// Normalize the config if necessary
-		if err := n.normalizeConfig(name, zone); err != nil {
-			return err
+	var errs models.ValidationErrors
+	failed := make(map[string]bool)
+	models.WithSortedZones(zones, func(name string, zone *models.Zone) error {
+		zoneErrs := n.normalize(name, zone)
+		failed[name] = zoneErrs.HasErrors()
+		errs = append(errs, zoneErrs...)
+		return nil
+	})
+
+	// The delegations span zones so they're only added once all of the zones are normalized, the zones are validated
+	// afterwards so the records added to a parent are validated along with its own records. This is part of Normalize,
+	// rather than generating the zone files, so validate, generate and diff all see the same zones.
+	errs = append(errs, delegateZones(zones, failed)...)
+
+	models.WithSortedZones(zones, func(name string, zone *models.Zone) error {
+		// Validating a zone whose records couldn't be normalized would only report the same problems again
+		if failed[name] {
+			return nil
 		}
+		zoneErrs := n.validate(name, zone)
+		failed[name] = zoneErrs.HasErrors()
+		errs = append(errs, zoneErrs...)
+		return nil
+	})
+
+	// A CNAME record can point into any of the zones so the targets are only followed once they're all validated
+	errs = append(errs, checkCNAMETargets(zones, failed)...)
+	errs.SortByPosition()
+	if !errs.HasErrors() {
+		n.warnings = append(n.warnings, errs...)
+		return nil
+	}
+	return errs
+}
+
+func (n *pluginNormalizer) Warnings() models.ValidationErrors {
+	return n.warnings
+}
+
+func (n *pluginNormalizer) normalize(name string, zone *models.Zone) models.ValidationErrors {
+	// Normalize the config if necessary
+	if err := n.normalizeConfig(name, zone); err != nil {
+		return models.ValidationErrors{zoneError(name, zone, models.RuleConfig, err)}
+	}
 
-		// Configure each of the plugins for this specific zone
-		// We need to do multiple loops over the plugins because we need all the plugins configured
-		// Then all the normalization done
-		// Then all the zone validation
-		// If we do this in a single loop, we'd end up calling ValidateZone before all the normalization for the zone is complete
-		if err := plugins.WithSortedPlugins(n.plugins, n.metadata, func(pluginType plugins.Type, p plugins.ZoneMgrPlugin, metadata *plugins.Metadata) error {
-			logger().Debug("calling Configure", "zoneName", name, "pluginName", metadata.Name)
-			return p.Configure(zone.Config)
-		}); err != nil {
-			return err
+	// TTLs written with units (e.g. 1h) are rendered as a number of seconds unless the zone keeps them
+	if !zone.Config.KeepTimeUnits {
+		zone.DiscardTimeUnits()
+	}
+
+	// We need to do multiple loops over the plugins because we need all the plugins configured
+	// Then all the normalization done
+	// Then all the zone validation
+	// If we do this in a single loop, we'd end up calling ValidateZone before all the normalization for the zone is complete
+	if err := n.configure(name, zone); err != nil {
+		return models.ValidationErrors{err}
+	}
+
+	return n.normalizeZone(name, zone)
+}
+
+// Performs the validations on the zone itself, once every zone is normalized
+func (n *pluginNormalizer) validate(name string, zone *models.Zone) models.ValidationErrors {
+	// The plugins still have the config of the last zone that was normalized, which isn't always this one
+	if n.configured != zone.Config {
+		if err := n.configure(name, zone); err != nil {
+			return models.ValidationErrors{err}
 		}
+	}
 
-		if err := n.normalizeZone(name, zone); err != nil {
-			return err
+	var errs models.ValidationErrors
+	if err := plugins.WithSortedPlugins(n.plugins, n.metadata, func(pluginType plugins.Type, p plugins.ZoneMgrPlugin, metadata *plugins.Metadata) error {
+		logger().Debug("calling ValidateZone", "zoneName", name, "pluginName", metadata.Name)
+		if err := p.ValidateZone(name, zone); err != nil {
+			errs = append(errs, validateZoneErrors(name, zone, pluginRule(pluginType, "validate-zone"), err)...)
 		}
+		return nil
+	}); err != nil {
+		errs = append(errs, zoneError(name, zone, models.RuleMissingPlugin, err))
+	}
 
-		// Now perform any validations on the zone itself
-		if err := plugins.WithSortedPlugins(n.plugins, n.metadata, func(pluginType plugins.Type, p plugins.ZoneMgrPlugin, metadata *plugins.Metadata) error {
-			logger().Debug("calling ValidateZone", "zoneName", name, "pluginName", metadata.Name)
-			if err := p.ValidateZone(name, zone); err != nil {
-				return err
-			}
-			return nil
-		}); err != nil {
-			return err
+	// The checks that don't depend on the type of the records are the same for every zone, whatever the plugins are
+	errs = append(errs, checkZone(name, zone)...)
+	return errs
+}
+
+// Configures each of the plugins for this specific zone
+func (n *pluginNormalizer) configure(name string, zone *models.Zone) *models.ValidationError {
+	n.configured = nil
+	if err := plugins.WithSortedPlugins(n.plugins, n.metadata, func(pluginType plugins.Type, p plugins.ZoneMgrPlugin, metadata *plugins.Metadata) error {
+		logger().Debug("calling Configure", "zoneName", name, "pluginName", metadata.Name)
+		if err := p.Configure(zone.Config); err != nil {
+			return zoneError(name, zone, pluginRule(pluginType, "configure"), err)
 		}
 		return nil
-	})
+	}); err != nil {
+		var validationErr *models.ValidationError
+		if !errors.As(err, &validationErr) {
+			validationErr = zoneError(name, zone, models.RuleMissingPlugin, err)
+		}
+		return validationErr
+	}
+	n.configured = zone.Config
+	return nil
 }
 
 func (n *pluginNormalizer) normalizeConfig(name string, zone *models.Zone) error {
 	if nil == zone.Config {
-		logger().Debug("zone missing config, setting to default values", "zoneName", name, "defaults", &models.Config{})
-		zone.Config = &models.Config{}
+		logger().Debug("zone missing config, setting to default values", "zoneName", name, "defaults", n.defaults)
+	}
+	// Anything the zone doesn't set comes from the defaults
+	if !n.withDefaults[zone.Config] {
+		zone.Config = zone.Config.WithDefaults(n.defaults)
+		n.withDefaults[zone.Config] = true
+	}
+
+	if !zone.Config.RecordOrder.IsValid() {
+		return fmt.Errorf("invalid record_order '%s' for zone '%s', must be one of '%s', '%s' or '%s'", zone.Config.RecordOrder, name, models.RecordOrderName, models.RecordOrderType, models.RecordOrderIdentifier)
+	}
+
+	if !zone.Config.PTRPolicy.IsValid() {
+		return fmt.Errorf("invalid ptr_policy '%s' for zone '%s', must be one of '%s', '%s' or '%s'", zone.Config.PTRPolicy, name, models.PTRPolicyError, models.PTRPolicyFirst, models.PTRPolicyAll)
+	}
+
+	if !zone.Config.CNAMEPolicy.IsValid() {
+		return fmt.Errorf("invalid cname_policy '%s' for zone '%s', must be one of '%s', '%s' or '%s'", zone.Config.CNAMEPolicy, name, models.CNAMEPolicyManaged, models.CNAMEPolicyStrict, models.CNAMEPolicyNone)
+	}
+
+	if !zone.Config.DelegationPolicy.IsValid() {
+		return fmt.Errorf("invalid delegation_policy '%s' for zone '%s', must be one of '%s', '%s' or '%s'", zone.Config.DelegationPolicy, name, models.DelegationPolicyGenerate, models.DelegationPolicyVerify, models.DelegationPolicyNone)
+	}
+
+	for _, reverseZone := range zone.Config.ReverseZones {
+		if _, err := utils.ParseReverseZone(reverseZone); err != nil {
+			return fmt.Errorf("invalid reverse_zones for zone '%s': %w", name, err)
+		}
 	}
 
 	// Ensure that the serial change index directory is an absolute path
@@ -98,24 +209,56 @@ func (n *pluginNormalizer) normalizeConfig(name string, zone *models.Zone) error
 	return nil
 }
 
-func (n *pluginNormalizer) normalizeZone(name string, zone *models.Zone) error {
+func (n *pluginNormalizer) normalizeZone(name string, zone *models.Zone) models.ValidationErrors {
 	logger().Debug("normalizing zone", "name", name)
-	if err := zone.WithSortedResourceRecords(func(identifier string, rr *models.ResourceRecord) error {
+	var errs models.ValidationErrors
+	zone.WithSortedResourceRecords(func(identifier string, rr *models.ResourceRecord) error {
 		logger().Trace("normalizing record", "identifier", identifier, "zoneName", name)
 		// We only call normalize on the resource record types we have plugins for, no need to loop
 		plugin := n.plugins[plugins.Type(rr.Type)]
 		if nil == plugin {
-			return fmt.Errorf("unable to normalize zone '%s', no plugin for resource record type '%s', identifier: '%s'", name, rr.Type, identifier)
+			errs = append(errs, recordError(name, zone, identifier, models.RuleMissingPlugin, fmt.Errorf("unable to normalize zone '%s', no plugin for resource record type '%s', identifier: '%s'", name, rr.Type, identifier)))
+			return nil
 		}
 		logger().Trace("calling Normalize on plugin", "identifier", identifier, "resourceRecordType", rr.Type, "zoneName", name, "plugin", plugin)
-		err := plugin.Normalize(identifier, rr)
-		if err != nil {
-			return err
+		if err := plugin.Normalize(identifier, rr); err != nil {
+			errs = append(errs, recordError(name, zone, identifier, pluginRule(plugins.Type(rr.Type), "normalize"), err))
 		}
-
 		return nil
-	}); err != nil {
-		return err
+	})
+	return errs
+}
+
+func zoneError(name string, zone *models.Zone, rule string, err error) *models.ValidationError {
+	return &models.ValidationError{Position: zone.Position(), Zone: name, Rule: rule, Err: err}
+}
+
+func recordError(name string, zone *models.Zone, identifier string, rule string, err error) *models.ValidationError {
+	return &models.ValidationError{Position: zone.ResourceRecordPosition(identifier), Zone: name, Identifier: identifier, Rule: rule, Err: err}
+}
+
+// The rule of a problem reported by a plugin, e.g. CNAME/validate-zone
+func pluginRule(pluginType plugins.Type, step string) string {
+	return string(pluginType) + "/" + step
+}
+
+// A plugin reports every problem with a zone at once, the problems with a record are a plugins.RecordError so they're
+// reported at the record and any other problem is reported at the zone
+func validateZoneErrors(name string, zone *models.Zone, rule string, err error) models.ValidationErrors {
+	var errs models.ValidationErrors
+	for _, problem := range plugins.Problems(err) {
+		var recordErr *plugins.RecordError
+		if !errors.As(problem, &recordErr) {
+			errs = append(errs, zoneError(name, zone, rule, problem))
+			continue
+		}
+
+		validationErr := zoneError(name, zone, rule, problem)
+		if zone.ResourceRecords[recordErr.Identifier] != nil {
+			validationErr = recordError(name, zone, recordErr.Identifier, rule, problem)
+		}
+		validationErr.Severity = recordErr.Severity
+		errs = append(errs, validationErr)
 	}
-	return nil
+	return errs
 }
@@ -21,11 +21,15 @@ package dns
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/bcurnow/zonemgr/models"
 	"github.com/bcurnow/zonemgr/plugins"
 	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/yaml.v3"
 )
 
 func TestNormalize(t *testing.T) {
@@ -59,22 +63,8 @@ func TestNormalize(t *testing.T) {
 	for _, tc := range testCases {
 		// If we don't have any zones then we won't make any calls
 		if len(tc.zones) != 0 {
+			// Every zone is normalized, even once one of them has an error
 			iterationZones := tc.zones
-			if len(iterationZones) > 1 && (tc.absPathErr || tc.normalizeErr || tc.validateZoneErr || tc.missingPluginErr || tc.missingPluginMetadataErr) {
-				// We need to adjust the number of zones we'll iterate over because we won't get past the first one
-				var firstZoneName string
-				var firstZone *models.Zone
-				first := true
-				models.WithSortedZones(tc.zones, func(name string, zone *models.Zone) error {
-					if first {
-						firstZoneName = name
-						firstZone = zone
-						first = false
-					}
-					return nil
-				})
-				iterationZones = map[string]*models.Zone{firstZoneName: firstZone}
-			}
 
 			models.WithSortedZones(iterationZones, func(zoneName string, zone *models.Zone) error {
 				if tc.absPathErr {
@@ -99,27 +89,25 @@ func TestNormalize(t *testing.T) {
 						if rr.Type == models.A {
 							if tc.normalizeErr {
 								mockAPlugin.EXPECT().Normalize(identifier, rr).Return(errors.New("normalize-error"))
-								// We won't call any other plugins
-								return nil
 							} else {
 								mockAPlugin.EXPECT().Normalize(identifier, rr)
 							}
 						} else {
-							if !tc.normalizeErr {
-								mockCNAMEPlugin.EXPECT().Normalize(identifier, rr)
-							}
+							// The rest of the records are still normalized after an error
+							mockCNAMEPlugin.EXPECT().Normalize(identifier, rr)
 						}
 						return nil
 					})
 
-					if tc.validateZoneErr {
-						mockAPlugin.EXPECT().ValidateZone(zoneName, zone).Return(errors.New("validate-zone-error"))
-					} else {
-						if !tc.normalizeErr {
-							// Each plugin should have validate called for each zone
+					// A zone whose records couldn't be normalized isn't validated
+					if !tc.normalizeErr {
+						// Each plugin should have validate called for each zone, even after an error
+						if tc.validateZoneErr {
+							mockAPlugin.EXPECT().ValidateZone(zoneName, zone).Return(errors.New("validate-zone-error"))
+						} else {
 							mockAPlugin.EXPECT().ValidateZone(zoneName, zone)
-							mockCNAMEPlugin.EXPECT().ValidateZone(zoneName, zone)
 						}
+						mockCNAMEPlugin.EXPECT().ValidateZone(zoneName, zone)
 					}
 				}
 
@@ -138,21 +126,31 @@ func TestNormalize(t *testing.T) {
 			theMetadata = make(map[plugins.Type]*plugins.Metadata)
 		}
 
-		if err := PluginNormalizer(thePlugins, theMetadata).Normalize(tc.zones); err != nil {
-			// Determine which error we want
+		if err := PluginNormalizer(thePlugins, theMetadata, globalConfig).Normalize(tc.zones); err != nil {
+			// Determine which error we want, each zone reports its own errors
+			var wantErrs []string
+			models.WithSortedZones(tc.zones, func(zoneName string, _ *models.Zone) error {
+				if tc.absPathErr {
+					wantErrs = append(wantErrs, fmt.Sprintf("zone '%s': abs-path-testing", zoneName))
+				} else if tc.missingPluginErr {
+					wantErrs = append(wantErrs,
+						fmt.Sprintf("zone '%[1]s', identifier 'record1': unable to normalize zone '%[1]s', no plugin for resource record type 'A', identifier: 'record1'", zoneName),
+						fmt.Sprintf("zone '%[1]s', identifier 'record2': unable to normalize zone '%[1]s', no plugin for resource record type 'CNAME', identifier: 'record2'", zoneName))
+				} else if tc.validateZoneErr {
+					wantErrs = append(wantErrs, fmt.Sprintf("zone '%s': validate-zone-error", zoneName))
+				} else if tc.normalizeErr {
+					wantErrs = append(wantErrs, fmt.Sprintf("zone '%s', identifier 'record1': normalize-error", zoneName))
+				} else if tc.missingPluginMetadataErr {
+					wantErrs = append(wantErrs, fmt.Sprintf("zone '%s': could not find plugin metadata for plugin type: A", zoneName))
+				}
+				return nil
+			})
+
 			want := ""
-			if tc.absPathErr {
-				want = "abs-path-testing"
-			} else if len(tc.zones) == 0 {
+			if len(tc.zones) == 0 {
 				want = "no zones found"
-			} else if tc.missingPluginErr {
-				want = "unable to normalize zone 'zone1', no plugin for resource record type 'A', identifier: 'record1'"
-			} else if tc.validateZoneErr {
-				want = "validate-zone-error"
-			} else if tc.normalizeErr {
-				want = "normalize-error"
-			} else if tc.missingPluginMetadataErr {
-				want = "could not find plugin metadata for plugin type: A"
+			} else if len(wantErrs) > 0 {
+				want = fmt.Sprintf("found %d errors:\n  %s", len(wantErrs), strings.Join(wantErrs, "\n  "))
 			}
 
 			if want != "" {
@@ -175,3 +173,328 @@ func TestNormalize(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalize_InvalidRecordOrder(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	zones := map[string]*models.Zone{"zone1": {Config: &models.Config{RecordOrder: "bogus"}}}
+	err := PluginNormalizer(mockPlugins, mockMetadata, nil).Normalize(zones)
+	want := "zone 'zone1': invalid record_order 'bogus' for zone 'zone1', must be one of 'name', 'type' or 'identifier'"
+	if err == nil || err.Error() != want {
+		t.Errorf("incorrect error: '%v', want '%s'", err, want)
+	}
+}
+
+func TestNormalize_InvalidPTRPolicy(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	zones := map[string]*models.Zone{"zone1": {Config: &models.Config{PTRPolicy: "bogus"}}}
+	err := PluginNormalizer(mockPlugins, mockMetadata, nil).Normalize(zones)
+	want := "zone 'zone1': invalid ptr_policy 'bogus' for zone 'zone1', must be one of 'error', 'first' or 'all'"
+	if err == nil || err.Error() != want {
+		t.Errorf("incorrect error: '%v', want '%s'", err, want)
+	}
+}
+
+func TestNormalize_InvalidCNAMEPolicy(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	zones := map[string]*models.Zone{"zone1": {Config: &models.Config{CNAMEPolicy: "bogus"}}}
+	err := PluginNormalizer(mockPlugins, mockMetadata, nil).Normalize(zones)
+	want := "zone 'zone1': invalid cname_policy 'bogus' for zone 'zone1', must be one of 'managed', 'strict' or 'none'"
+	if err == nil || err.Error() != want {
+		t.Errorf("incorrect error: '%v', want '%s'", err, want)
+	}
+}
+
+func TestNormalize_InvalidDelegationPolicy(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	zones := map[string]*models.Zone{"zone1": {Config: &models.Config{DelegationPolicy: "bogus"}}}
+	err := PluginNormalizer(mockPlugins, mockMetadata, nil).Normalize(zones)
+	want := "zone 'zone1': invalid delegation_policy 'bogus' for zone 'zone1', must be one of 'generate', 'verify' or 'none'"
+	if err == nil || err.Error() != want {
+		t.Errorf("incorrect error: '%v', want '%s'", err, want)
+	}
+}
+
+func TestNormalize_InvalidReverseZones(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	zones := map[string]*models.Zone{"zone1": {Config: &models.Config{ReverseZones: []string{"10.0.0.0/16", "10.0.0.0/12"}}}}
+	err := PluginNormalizer(mockPlugins, mockMetadata, nil).Normalize(zones)
+	want := "zone 'zone1': invalid reverse_zones for zone 'zone1': invalid reverse zone '10.0.0.0/12', IPv4 reverse zones must be a /8, /16, /24 or between a /25 and a /31"
+	if err == nil || err.Error() != want {
+		t.Errorf("incorrect error: '%v', want '%s'", err, want)
+	}
+}
+
+func TestNormalize_ConfigDefaults(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	defaults := &models.Config{GenerateSerial: true, SerialChangeIndexDirectory: "/defaults", RecordOrder: models.RecordOrderName}
+	config := &models.Config{}
+	if err := yaml.Unmarshal([]byte("generate_serial: false\n"), config); err != nil {
+		t.Fatal(err)
+	}
+	zones := map[string]*models.Zone{"zone1": {Config: config, ResourceRecords: map[string]*models.ResourceRecord{}}}
+
+	mockFs.EXPECT().ToAbsoluteFilePath("/defaults").Return("/defaults", nil).Times(2)
+	normalizer := PluginNormalizer(realPlugins, realMetadata, defaults)
+	if err := normalizer.Normalize(zones); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := &models.Config{SerialChangeIndexDirectory: "/defaults", RecordOrder: models.RecordOrderName}
+	if diff := cmp.Diff(want, zones["zone1"].Config); diff != "" {
+		t.Errorf("incorrect config:\n%s", diff)
+	}
+
+	// Reverse zones share the config of the zone they came from, the defaults must not be applied again
+	reverseZones := map[string]*models.Zone{"reverse1": {Config: zones["zone1"].Config, ResourceRecords: map[string]*models.ResourceRecord{}}}
+	if err := normalizer.Normalize(reverseZones); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, reverseZones["reverse1"].Config); diff != "" {
+		t.Errorf("incorrect reverse zone config:\n%s", diff)
+	}
+}
+
+func TestNormalize_TimeUnits(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	zones := map[string]*models.Zone{}
+	for name, content := range map[string]string{
+		"seconds.example.com.": "ttl:\n  value: 1d\nresource_records:\n  www:\n    type: A\n    ttl: 5m\n    value: 192.0.2.1\n",
+		"units.example.com.":   "config:\n  keep_time_units: true\nttl:\n  value: 1d\nresource_records:\n  www:\n    type: A\n    ttl: 5m\n    value: 192.0.2.1\n",
+	} {
+		zone := &models.Zone{}
+		if err := yaml.Unmarshal([]byte(content), zone); err != nil {
+			t.Fatal(err)
+		}
+		zones[name] = zone
+	}
+
+	mockFs.EXPECT().ToAbsoluteFilePath("").Return("", nil).Times(2)
+	if err := PluginNormalizer(realPlugins, realMetadata, &models.Config{}).Normalize(zones); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCases := []struct {
+		zoneName string
+		wantTTL  string
+		wantRR   string
+	}{
+		{zoneName: "seconds.example.com.", wantTTL: "$TTL 86400", wantRR: "300"},
+		{zoneName: "units.example.com.", wantTTL: "$TTL 1d", wantRR: "5m"},
+	}
+
+	for _, tc := range testCases {
+		zone := zones[tc.zoneName]
+		if zone.TTL.Render() != tc.wantTTL {
+			t.Errorf("%s - incorrect zone TTL: '%s', want: '%s'", tc.zoneName, zone.TTL.Render(), tc.wantTTL)
+		}
+		if rendered := zone.ResourceRecords["www"].RenderResourceWithoutValue(); !strings.Contains(rendered, " "+tc.wantRR+" ") {
+			t.Errorf("%s - incorrect record TTL: '%s', want: '%s'", tc.zoneName, rendered, tc.wantRR)
+		}
+	}
+}
+
+func TestNormalize_CollectsErrors(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	badRecords := &models.Zone{
+		Config: &models.Config{},
+		ResourceRecords: map[string]*models.ResourceRecord{
+			"bad-a":   {Type: models.A, Value: "not-an-ip"},
+			"bad-a-2": {Type: models.A, Value: "also-not-an-ip"},
+		},
+	}
+	badRecords.SetPosition(&models.SourcePosition{File: "zones.yaml", Line: 1, Column: 1})
+	badRecords.SetResourceRecordPosition("bad-a", &models.SourcePosition{File: "zones.yaml", Line: 3, Column: 5})
+	badRecords.SetResourceRecordPosition("bad-a-2", &models.SourcePosition{File: "zones.yaml", Line: 6, Column: 5})
+
+	badCNAMEs := &models.Zone{
+		Config: &models.Config{},
+		ResourceRecords: map[string]*models.ResourceRecord{
+			"host":   {Type: models.A, Value: "1.2.3.4"},
+			"cname1": {Type: models.CNAME, Value: "missing1"},
+			"cname2": {Type: models.CNAME, Value: "missing2"},
+		},
+	}
+	badCNAMEs.SetPosition(&models.SourcePosition{File: "zones.yaml", Line: 10, Column: 1})
+	badCNAMEs.SetResourceRecordPosition("cname2", &models.SourcePosition{File: "zones.yaml", Line: 18, Column: 5})
+
+	mockFs.EXPECT().ToAbsoluteFilePath("").Return("", nil).Times(2)
+	err := PluginNormalizer(realPlugins, realMetadata, nil).Normalize(map[string]*models.Zone{"one.": badRecords, "two.": badCNAMEs})
+
+	var errs models.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected validation errors, found: %v", err)
+	}
+
+	want := []string{
+		"zones.yaml:3:5: zone 'one.', identifier 'bad-a': ",
+		"zones.yaml:6:5: zone 'one.', identifier 'bad-a-2': ",
+		// cname1 has no position of its own so it falls back to the zone's
+		"zones.yaml:10:1: zone 'two.', identifier 'cname1': invalid CNAME record, 'cname1' has a value of 'missing1'",
+		"zones.yaml:18:5: zone 'two.', identifier 'cname2': invalid CNAME record, 'cname2' has a value of 'missing2'",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("incorrect number of errors: %d, want: %d\n%s", len(errs), len(want), err)
+	}
+	wantRules := []string{"A/normalize", "A/normalize", models.RuleCNAMETarget, models.RuleCNAMETarget}
+	for i, prefix := range want {
+		if !strings.HasPrefix(errs[i].Error(), prefix) {
+			t.Errorf("incorrect error: '%s', want prefix: '%s'", errs[i], prefix)
+		}
+		if errs[i].Rule != wantRules[i] {
+			t.Errorf("incorrect rule for '%s': '%s', want: '%s'", errs[i], errs[i].Rule, wantRules[i])
+		}
+	}
+}
+
+func TestNormalize_ReconfiguresPlugins(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	zones := map[string]*models.Zone{
+		"one.": {Config: &models.Config{RecordOrder: models.RecordOrderName}, ResourceRecords: map[string]*models.ResourceRecord{}},
+		"two.": {Config: &models.Config{RecordOrder: models.RecordOrderType}, ResourceRecords: map[string]*models.ResourceRecord{}},
+	}
+
+	// Every zone is normalized before any of them are validated, the plugins have to be configured for the zone again
+	var calls []string
+	for _, p := range []*plugins.MockZoneMgrPlugin{mockAPlugin, mockCNAMEPlugin} {
+		p.EXPECT().Configure(gomock.Any()).DoAndReturn(func(config *models.Config) error {
+			calls = append(calls, "configure "+string(config.RecordOrder))
+			return nil
+		}).AnyTimes()
+		p.EXPECT().ValidateZone(gomock.Any(), gomock.Any()).DoAndReturn(func(name string, _ *models.Zone) error {
+			calls = append(calls, "validate "+name)
+			return nil
+		}).AnyTimes()
+	}
+	mockFs.EXPECT().ToAbsoluteFilePath("").Return("", nil).Times(2)
+
+	if err := PluginNormalizer(mockPlugins, mockMetadata, nil).Normalize(zones); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []string{
+		"configure name", "configure name",
+		"configure type", "configure type",
+		"configure name", "configure name", "validate one.", "validate one.",
+		"configure type", "configure type", "validate two.", "validate two.",
+	}
+	if diff := cmp.Diff(want, calls); diff != "" {
+		t.Errorf("incorrect calls (-want +got):\n%s", diff)
+	}
+}
+
+func TestNormalize_Warnings(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	zones := map[string]*models.Zone{
+		"one.": {ResourceRecords: map[string]*models.ResourceRecord{}},
+		"two.": {ResourceRecords: map[string]*models.ResourceRecord{}},
+	}
+
+	for _, p := range []*plugins.MockZoneMgrPlugin{mockAPlugin, mockCNAMEPlugin} {
+		p.EXPECT().Configure(gomock.Any()).AnyTimes()
+	}
+	mockAPlugin.EXPECT().ValidateZone("one.", gomock.Any()).Return(plugins.NewRecordWarning("", errors.New("one-warning")))
+	mockAPlugin.EXPECT().ValidateZone("two.", gomock.Any()).Return(errors.Join(plugins.NewRecordWarning("", errors.New("two-warning")), errors.New("two-error")))
+	mockCNAMEPlugin.EXPECT().ValidateZone(gomock.Any(), gomock.Any()).Times(2)
+	mockFs.EXPECT().ToAbsoluteFilePath("").Return("", nil).Times(2)
+
+	// A zone with an error makes the zones invalid, the warnings are returned along with the error
+	n := PluginNormalizer(mockPlugins, mockMetadata, nil)
+	var errs models.ValidationErrors
+	if err := n.Normalize(zones); !errors.As(err, &errs) {
+		t.Fatalf("expected validation errors, found: %v", err)
+	}
+	want := []string{"warning: zone 'one.': one-warning", "warning: zone 'two.': two-warning", "zone 'two.': two-error"}
+	var got []string
+	for _, err := range errs {
+		got = append(got, err.Error())
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("incorrect errors (-want +got):\n%s", diff)
+	}
+	if len(n.Warnings()) != 0 {
+		t.Errorf("unexpected warnings: %v", n.Warnings())
+	}
+
+	// Warnings alone don't
+	delete(zones, "two.")
+	mockAPlugin.EXPECT().ValidateZone("one.", gomock.Any()).Return(plugins.NewRecordWarning("", errors.New("one-warning")))
+	mockCNAMEPlugin.EXPECT().ValidateZone(gomock.Any(), gomock.Any())
+	mockFs.EXPECT().ToAbsoluteFilePath("").Return("", nil)
+	if err := n.Normalize(zones); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if warnings := n.Warnings(); len(warnings) != 1 || warnings[0].Error() != want[0] || warnings[0].Rule != "A/validate-zone" {
+		t.Errorf("incorrect warnings: %v, want: %s", warnings, want[0])
+	}
+}
+
+func TestValidateZoneErrors(t *testing.T) {
+	zone := &models.Zone{ResourceRecords: map[string]*models.ResourceRecord{"www": {}, "mail": {}}}
+	zone.SetPosition(&models.SourcePosition{File: "zones.yaml", Line: 1})
+	zone.SetResourceRecordPosition("www", &models.SourcePosition{File: "zones.yaml", Line: 3})
+	zone.SetResourceRecordPosition("mail", &models.SourcePosition{File: "zones.yaml", Line: 5})
+
+	testCases := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{
+			name: "record-error",
+			err:  errors.Join(plugins.NewRecordError("www", errors.New("not found")), plugins.NewRecordError("mail", errors.New("is a CNAME"))),
+			want: []string{"zones.yaml:3 www not found", "zones.yaml:5 mail is a CNAME"},
+		},
+		{
+			// The message doesn't matter when the identifier is known
+			name: "record-error-message",
+			err:  plugins.NewRecordError("www", errors.New("identifier: 'mail'")),
+			want: []string{"zones.yaml:3 www identifier: 'mail'"},
+		},
+		{
+			name: "record-error-unknown-identifier",
+			err:  plugins.NewRecordError("missing", errors.New("not found")),
+			want: []string{"zones.yaml:1  not found"},
+		},
+		{
+			name: "zone-error",
+			err:  errors.New("missing SOA record"),
+			want: []string{"zones.yaml:1  missing SOA record"},
+		},
+		{
+			// The identifier in the message isn't used, only a plugins.RecordError is reported at the record
+			name: "message-identifier",
+			err:  errors.Join(errors.New("invalid A record, identifier: 'www'"), errors.New("missing SOA record")),
+			want: []string{"zones.yaml:1  invalid A record, identifier: 'www'", "zones.yaml:1  missing SOA record"},
+		},
+	}
+
+	for _, tc := range testCases {
+		var got []string
+		for _, err := range validateZoneErrors("example.com.", zone, "A/validate-zone", tc.err) {
+			got = append(got, fmt.Sprintf("%s %s %s", err.Position, err.Identifier, err.Err))
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s - incorrect errors (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
@@ -17,14 +17,19 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package dns
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/bcurnow/zonemgr/models"
 	"github.com/bcurnow/zonemgr/utils"
 )
 
 type ZoneParser interface {
-	Parse(inputFile string) (map[string]*models.Zone, error)
+	// Parses and normalizes the zones of every input, an input can be a file, a glob or a directory (see utils.ExpandInputFiles)
+	Parse(inputs []string) (map[string]*models.Zone, error)
+	// The warnings found while normalizing the zones, they don't stop the zones from being parsed
+	Warnings() models.ValidationErrors
 }
 
 type yamlZoneParser struct {
@@ -33,25 +38,69 @@ type yamlZoneParser struct {
 	reader     *utils.ZoneYamlFile
 }
 
+var expandInputFiles = utils.ExpandInputFiles
+
 func YamlZoneParser(normalizer Normalizer) ZoneParser {
 	return &yamlZoneParser{normalizer: normalizer, reader: &utils.ZoneYamlFile{}}
 }
 
-func (p *yamlZoneParser) Parse(inputFile string) (map[string]*models.Zone, error) {
-	zones, err := p.reader.Read(inputFile)
+func (p *yamlZoneParser) Warnings() models.ValidationErrors {
+	return p.normalizer.Warnings()
+}
+
+func (p *yamlZoneParser) Parse(inputs []string) (map[string]*models.Zone, error) {
+	inputFiles, err := expandInputFiles(inputs)
 	if err != nil {
 		return nil, err
 	}
 
+	// Every file is read so the problems in all of them are reported at once
+	zones := make(map[string]*models.Zone)
+	templates := make(map[string]*models.Template)
+	var errs models.ValidationErrors
+	for _, inputFile := range inputFiles {
+		zoneFile, err := p.reader.ReadZoneFile(inputFile)
+		if err != nil {
+			var fileErrs models.ValidationErrors
+			if !errors.As(err, &fileErrs) {
+				return nil, err
+			}
+			errs = append(errs, fileErrs...)
+			continue
+		}
+
+		errs = append(errs, mergeTemplates(templates, zoneFile.Templates)...)
+		// ReadZoneFile reports a zone without any information, so every zone here is set
+		models.WithSortedZones(zoneFile.Zones, func(name string, zone *models.Zone) error {
+			errs = append(errs, mergeZone(zones, name, zone)...)
+			return nil
+		})
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
 	if len(zones) == 0 {
 		return nil, fmt.Errorf("no zones found in input file")
 	}
 
-	for name, zone := range zones {
-		// It is possible for the zone itself to be nil, this happens if a file is parsed which only contains the name of the zone and no other info
-		if zone == nil {
-			return nil, fmt.Errorf("invalid input file %s, no zone information for zone %s", inputFile, name)
-		}
+	// The templates are included and the zones extended before the zones are normalized so the records are normalized
+	// as part of each zone
+	if errs := includeTemplates(zones, templates); len(errs) > 0 {
+		return nil, errs
+	}
+	if errs := extendZones(zones, templates); len(errs) > 0 {
+		errs.SortByPosition()
+		return nil, errs
+	}
+	// The generated records are expanded once the zones have all of their records so a generate can come from a template
+	if errs := expandGenerateRecords(zones); len(errs) > 0 {
+		errs.SortByPosition()
+		return nil, errs
+	}
+	if errs := expandSOARecords(zones); len(errs) > 0 {
+		errs.SortByPosition()
+		return nil, errs
 	}
 
 	// Normalize the zones
@@ -60,3 +109,100 @@ func (p *yamlZoneParser) Parse(inputFile string) (map[string]*models.Zone, error
 	}
 	return zones, nil
 }
+
+// A zone can be split across files, only one of them can set the zone settings (everything but the resource records)
+// and each identifier can only be used once. The zone keeps the position of the file that sets the zone settings, or else
+// the first file.
+func mergeZone(zones map[string]*models.Zone, name string, zone *models.Zone) models.ValidationErrors {
+	existing, ok := zones[name]
+	if !ok {
+		zones[name] = zone
+		return nil
+	}
+
+	var errs models.ValidationErrors
+	switch {
+	case hasZoneSettings(zone) && hasZoneSettings(existing):
+		errs = append(errs, &models.ValidationError{
+			Position: zone.Position(),
+			Zone:     name,
+			Rule:     models.RuleDuplicate,
+			Err:      fmt.Errorf("duplicate zone '%s', already defined at %s, only one file can set the settings of a zone, everything but the resource_records", name, positionString(existing.Position())),
+		})
+	case hasZoneSettings(zone):
+		existing.Config = zone.Config
+		existing.TTL = zone.TTL
+		existing.Include = zone.Include
+		existing.Extends = zone.Extends
+		existing.Remove = zone.Remove
+		existing.SOADefaults = zone.SOADefaults
+		existing.SetPosition(zone.Position())
+	}
+
+	if nil == existing.ResourceRecords {
+		existing.ResourceRecords = make(map[string]*models.ResourceRecord, len(zone.ResourceRecords))
+	}
+	for _, identifier := range sortedIdentifiers(zone) {
+		if _, ok := existing.ResourceRecords[identifier]; ok {
+			errs = append(errs, &models.ValidationError{
+				Position:   zone.ResourceRecordPosition(identifier),
+				Zone:       name,
+				Identifier: identifier,
+				Rule:       models.RuleDuplicate,
+				Err:        fmt.Errorf("duplicate identifier '%s', already defined at %s", identifier, positionString(existing.ResourceRecordPosition(identifier))),
+			})
+			continue
+		}
+		existing.ResourceRecords[identifier] = zone.ResourceRecords[identifier]
+		existing.SetResourceRecordPosition(identifier, zone.ResourceRecordPosition(identifier))
+	}
+	return errs
+}
+
+func hasZoneSettings(zone *models.Zone) bool {
+	return zone.Config != nil || zone.TTL != nil || len(zone.Include) > 0 || zone.Extends != "" || len(zone.Remove) > 0 || zone.SOADefaults != nil
+}
+
+// Templates are shared by every file but each name can only be defined once
+func mergeTemplates(templates map[string]*models.Template, fileTemplates map[string]*models.Template) models.ValidationErrors {
+	var errs models.ValidationErrors
+	for _, name := range sortedKeys(fileTemplates) {
+		template := fileTemplates[name]
+		if existing, ok := templates[name]; ok {
+			errs = append(errs, &models.ValidationError{
+				Position: template.Position(),
+				Template: name,
+				Rule:     models.RuleDuplicate,
+				Err:      fmt.Errorf("duplicate template '%s', already defined at %s", name, positionString(existing.Position())),
+			})
+			continue
+		}
+		templates[name] = template
+	}
+	return errs
+}
+
+func sortedIdentifiers(zone *models.Zone) []string {
+	var identifiers []string
+	zone.WithSortedResourceRecords(func(identifier string, _ *models.ResourceRecord) error {
+		identifiers = append(identifiers, identifier)
+		return nil
+	})
+	return identifiers
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func positionString(position *models.SourcePosition) string {
+	if position == nil {
+		return "an unknown position"
+	}
+	return position.String()
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/bcurnow/zonemgr/models"
 	"go.uber.org/mock/gomock"
 )
 
@@ -44,18 +45,18 @@ func TestParse(t *testing.T) {
 		err       string
 	}{
 		{0, "empty.zones.yaml", "no zones found in input file"},
-		{0, "only-zone-name.zones.yaml", "invalid input file only-zone-name.zones.yaml, no zone information for zone and_now_for_something_completely_unexpected"},
-		{0, "invalid.zones.yaml", "failed to parse input YAML: yaml: unmarshal errors:\n  line 21: cannot unmarshal !!str `hello!` into bool"},
+		{0, "only-zone-name.zones.yaml", "only-zone-name.zones.yaml:19:1: zone 'and_now_for_something_completely_unexpected': no zone information for zone"},
+		{0, "invalid.zones.yaml", "invalid.zones.yaml:21:5: zone 'example.com.': cannot unmarshal !!str `hello!` into bool"},
 		{1, "minimal.zones.yaml", ""},
 		{5, "multiple.zones.yaml", ""},
 		{5, "missing.zones.yaml", "failed to open 'missing.zones.yaml': open missing.zones.yaml: no such file or directory"},
-		{0, "unknown-key.zones.yaml", "failed to parse input YAML: yaml: unmarshal errors:\n  line 21: field generate_reverse_lokup_zones not found in type models.Config"},
+		{0, "unknown-key.zones.yaml", "unknown-key.zones.yaml:21:5: zone 'example.com.': field generate_reverse_lokup_zones not found in type models.Config"},
 	}
 
 	mockNormalizer.EXPECT().Normalize(gomock.Any()).MaxTimes(len(testCases))
 
 	for _, tc := range testCases {
-		zones, err := YamlZoneParser(mockNormalizer).Parse(tc.inputFile)
+		zones, err := YamlZoneParser(mockNormalizer).Parse([]string{tc.inputFile})
 
 		if err != nil {
 			if tc.err == "" {
@@ -79,13 +80,94 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParse_MultipleInputs(t *testing.T) {
+	testZoneYamlParserSetup(t)
+	defer dnsTeardown(t)
+
+	testCases := []struct {
+		inputs    []string
+		zoneCount int
+		err       string
+	}{
+		{inputs: []string{"split-records.zones.yaml", "split-settings.zones.yaml"}, zoneCount: 2},
+		{inputs: []string{"split-settings.zones.yaml", "split-records.zones.yaml"}, zoneCount: 2},
+		{inputs: []string{"split-*.zones.yaml"}, err: "found 2 errors:\n" +
+			"  split-settings.zones.yaml:18:1: zone 'example.com.': duplicate zone 'example.com.', already defined at split-duplicate.zones.yaml:18:1, only one file can set the settings of a zone, everything but the resource_records\n" +
+			"  split-settings.zones.yaml:24:5: zone 'example.com.', identifier 'www': duplicate identifier 'www', already defined at split-duplicate.zones.yaml:22:5"},
+		{inputs: []string{"missing-*.zones.yaml"}, err: "no input files match 'missing-*.zones.yaml'"},
+	}
+
+	for _, tc := range testCases {
+		if tc.err == "" {
+			mockNormalizer.EXPECT().Normalize(gomock.Any())
+		}
+
+		zones, err := YamlZoneParser(mockNormalizer).Parse(tc.inputs)
+		if err != nil {
+			if err.Error() != tc.err {
+				t.Errorf("%v, incorrect error: %s, want %s", tc.inputs, err, tc.err)
+			}
+			continue
+		}
+
+		if tc.err != "" {
+			t.Errorf("%v, expected error '%s', found none", tc.inputs, tc.err)
+		}
+
+		if len(zones) != tc.zoneCount {
+			t.Errorf("%v, zone count=%d, want %d", tc.inputs, len(zones), tc.zoneCount)
+		}
+
+		zone := zones["example.com."]
+		if zone.Config == nil || zone.Config.GenerateSerial != true || zone.TTL == nil || *zone.TTL.Value != 3600 {
+			t.Errorf("%v, zone settings not merged: %v", tc.inputs, zone)
+		}
+		if len(zone.ResourceRecords) != 2 || zone.ResourceRecords["www"] == nil || zone.ResourceRecords["mail"] == nil {
+			t.Errorf("%v, records not merged: %v", tc.inputs, zone.ResourceRecords)
+		}
+		if position := zone.ResourceRecordPosition("mail"); position == nil || position.File != "split-records.zones.yaml" {
+			t.Errorf("%v, incorrect position for mail: %v", tc.inputs, position)
+		}
+		if position := zone.Position(); position == nil || position.File != "split-settings.zones.yaml" {
+			t.Errorf("%v, incorrect zone position: %v", tc.inputs, position)
+		}
+	}
+}
+
+func TestParse_Templates(t *testing.T) {
+	testZoneYamlParserSetup(t)
+	defer dnsTeardown(t)
+
+	mockNormalizer.EXPECT().Normalize(gomock.Any()).Do(func(zones map[string]*models.Zone) {
+		// The templates are included before the zones are normalized
+		for _, name := range []string{"example.com.", "other.com."} {
+			if rr := zones[name].ResourceRecords["ns1"]; rr == nil || rr.Value != "ns1.example.com." {
+				t.Errorf("%s, expected ns1 to be included, found: %v", name, zones[name].ResourceRecords)
+			}
+		}
+		if zones["example.com."].ResourceRecords["ns1"] == zones["other.com."].ResourceRecords["ns1"] {
+			t.Error("expected each zone to have its own copy of ns1")
+		}
+	})
+
+	if _, err := YamlZoneParser(mockNormalizer).Parse([]string{"templates.zones.yaml"}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	_, err := YamlZoneParser(mockNormalizer).Parse([]string{"templates.zones.yaml", "templates-duplicate.zones.yaml"})
+	want := "templates-duplicate.zones.yaml:19:3: template 'common': duplicate template 'common', already defined at templates.zones.yaml:19:3"
+	if err == nil || err.Error() != want {
+		t.Errorf("incorrect error: %v, want %s", err, want)
+	}
+}
+
 func TestParse_NormalizerError(t *testing.T) {
 	testZoneYamlParserSetup(t)
 	defer dnsTeardown(t)
 
 	mockNormalizer.EXPECT().Normalize(gomock.Any()).Return(fmt.Errorf("testing normalizer error"))
 
-	_, err := YamlZoneParser(mockNormalizer).Parse("minimal.zones.yaml")
+	_, err := YamlZoneParser(mockNormalizer).Parse([]string{"minimal.zones.yaml"})
 	if err == nil {
 		t.Errorf("expected error")
 	} else {
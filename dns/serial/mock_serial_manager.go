@@ -1,5 +1,10 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: dns/serial/serial_manager.go
+//
+// Generated by this command:
+//
+//	mockgen -source=dns/serial/serial_manager.go -package serial -self_package github.com/bcurnow/zonemgr/dns/serial
+//
 
 // Package serial is a generated GoMock package.
 package serial
@@ -14,6 +19,7 @@ import (
 type MockSerialManager struct {
 	ctrl     *gomock.Controller
 	recorder *MockSerialManagerMockRecorder
+	isgomock struct{}
 }
 
 // MockSerialManagerMockRecorder is the mock recorder for MockSerialManager.
@@ -33,17 +39,47 @@ func (m *MockSerialManager) EXPECT() *MockSerialManagerMockRecorder {
 	return m.recorder
 }
 
-// Next mocks base method.
-func (m *MockSerialManager) Next(zoneName string) (string, error) {
+// Commit mocks base method.
+func (m *MockSerialManager) Commit(zoneName, serial, contentHash string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Next", zoneName)
+	ret := m.ctrl.Call(m, "Commit", zoneName, serial, contentHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockSerialManagerMockRecorder) Commit(zoneName, serial, contentHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockSerialManager)(nil).Commit), zoneName, serial, contentHash)
+}
+
+// Current mocks base method.
+func (m *MockSerialManager) Current(zoneName string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Current", zoneName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Current indicates an expected call of Current.
+func (mr *MockSerialManagerMockRecorder) Current(zoneName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Current", reflect.TypeOf((*MockSerialManager)(nil).Current), zoneName)
+}
+
+// Peek mocks base method.
+func (m *MockSerialManager) Peek(zoneName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Peek", zoneName)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Next indicates an expected call of Next.
-func (mr *MockSerialManagerMockRecorder) Next(zoneName interface{}) *gomock.Call {
+// Peek indicates an expected call of Peek.
+func (mr *MockSerialManagerMockRecorder) Peek(zoneName any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockSerialManager)(nil).Next), zoneName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peek", reflect.TypeOf((*MockSerialManager)(nil).Peek), zoneName)
 }
@@ -33,8 +33,19 @@ const (
 	initialChangeIndex       uint32 = 1
 )
 
+// Serial numbers are handed out in two steps so that reading the next serial number never has a side effect,
+// only an explicit Commit (done once the zone files have been written) changes the change index.
 type SerialManager interface {
-	Next(zoneName string) (string, error)
+	// Returns the serial number the zone would be given next, without changing the change index file.
+	// This can be called any number of times, it will keep returning the same serial number until it is committed.
+	Peek(zoneName string) (string, error)
+	// Returns the last committed serial number and the content hash it was committed with, both are empty if nothing
+	// has been committed for the zone yet.
+	Current(zoneName string) (serial string, contentHash string, err error)
+	// Persists the change index for a serial number previously returned by Peek so the next Peek returns a new one.
+	// The contentHash is stored with it so unchanged zones can keep their serial number.
+	// Fails if the change index has changed (e.g. by another run) since the serial number was peeked.
+	Commit(zoneName string, serial string, contentHash string) error
 }
 
 var (
@@ -55,25 +66,22 @@ func FileSerialManager(changeIndexDirectory string) SerialManager {
 	return &fileSerialManager{changeIndexDirectory: changeIndexDirectory, indexFile: &utils.SerialIndexYamlFile{}}
 }
 
-func (m *fileSerialManager) Next(zoneName string) (string, error) {
-	if err := fs.MkdirAll(m.changeIndexDirectory, 0750); err != nil {
-		return "", err
-	}
-
-	path := filepath.Join(m.changeIndexDirectory, fmt.Sprintf("%s.%s", zoneName, changeIndexFileExtension))
+func (m *fileSerialManager) Peek(zoneName string) (string, error) {
+	path := m.indexPath(zoneName)
 
-	// Make sure the file exists
 	var serialIndex *models.SerialIndex
 	if fs.Exists(path) {
 		logger().Trace("serial change index file exists, processing", "file", path)
-		si, err := m.incrementAndUpdate(path)
-		if err != nil {
+		if err := m.withLock(path, func() error {
+			si, err := m.incrementedIndex(path)
+			serialIndex = si
+			return err
+		}); err != nil {
 			return "", err
 		}
-		serialIndex = si
 	} else {
-		logger().Trace("serial change index file does not exist, creating new one", "file", path)
-		si, err := m.initFile(path)
+		logger().Trace("serial change index file does not exist, using the initial change index", "file", path)
+		si, err := m.initialIndex()
 		if err != nil {
 			return "", err
 		}
@@ -88,15 +96,82 @@ func (m *fileSerialManager) Next(zoneName string) (string, error) {
 	return serialNumber, nil
 }
 
-func (m *fileSerialManager) initFile(path string) (*models.SerialIndex, error) {
-	logger().Debug("creating new serial file", "file", path)
-	//Lock the file so no other process modifies it while we're updating
+func (m *fileSerialManager) Current(zoneName string) (string, string, error) {
+	path := m.indexPath(zoneName)
+	if !fs.Exists(path) {
+		logger().Trace("serial change index file does not exist, nothing has been committed", "file", path)
+		return "", "", nil
+	}
+
+	var serialIndex *models.SerialIndex
+	if err := m.withLock(path, func() error {
+		si, err := m.readIndex(path)
+		serialIndex = si
+		return err
+	}); err != nil {
+		return "", "", err
+	}
+
+	serialNumber, err := generator.FromSerialIndex(serialIndex)
+	if err != nil {
+		return "", "", err
+	}
+	return serialNumber, serialIndex.ContentHash, nil
+}
+
+func (m *fileSerialManager) Commit(zoneName string, serial string, contentHash string) error {
+	if err := fs.MkdirAll(m.changeIndexDirectory, 0750); err != nil {
+		return err
+	}
+
+	path := m.indexPath(zoneName)
+	// This has to be checked before locking as the lock creates the file
+	exists := fs.Exists(path)
+
+	return m.withLock(path, func() error {
+		var serialIndex *models.SerialIndex
+		var err error
+		if exists {
+			serialIndex, err = m.incrementedIndex(path)
+		} else {
+			logger().Debug("creating new serial file", "file", path)
+			serialIndex, err = m.initialIndex()
+		}
+		if err != nil {
+			return err
+		}
+
+		serialNumber, err := generator.FromSerialIndex(serialIndex)
+		if err != nil {
+			return err
+		}
+		if serialNumber != serial {
+			return fmt.Errorf("unable to commit serial number '%s' for zone '%s', the change index has changed since it was reserved, the next serial number is now '%s'", serial, zoneName, serialNumber)
+		}
+
+		serialIndex.ContentHash = contentHash
+		logger().Trace("writing updated serial change index file", "file", path, "baseSerialNumber", *serialIndex.Base, "changeIndex", *serialIndex.ChangeIndex, "contentHash", contentHash)
+		return m.indexFile.Write(path, serialIndex)
+	})
+}
+
+func (m *fileSerialManager) indexPath(zoneName string) string {
+	return filepath.Join(m.changeIndexDirectory, fmt.Sprintf("%s.%s", zoneName, changeIndexFileExtension))
+}
+
+// Locks the file so no other process modifies it while fn is running
+func (m *fileSerialManager) withLock(path string, fn func() error) error {
 	fileLock, err := fs.Flock(path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer fileLock.Unlock() //nolint:errcheck // unlock errors are not critical in defer
 
+	return fn()
+}
+
+// Returns the change index for a zone that doesn't have a change index file yet
+func (m *fileSerialManager) initialIndex() (*models.SerialIndex, error) {
 	base, err := generator.GenerateBase()
 	if err != nil {
 		return nil, err
@@ -105,24 +180,25 @@ func (m *fileSerialManager) initFile(path string) (*models.SerialIndex, error) {
 	// This is a bit strange, however, I don't want an initial value that can be changed
 	// Since you can't get a pointer to a constant, this is the work around
 	changeIndex := initialChangeIndex
-	// Create a new SerialIndex structure. We hardcode 1 as this is a new file
-	serialIndex := &models.SerialIndex{Base: base, ChangeIndex: &changeIndex}
-	if err := m.indexFile.Write(path, serialIndex); err != nil {
-		return nil, err
-	}
-
-	return serialIndex, nil
+	return &models.SerialIndex{Base: base, ChangeIndex: &changeIndex}, nil
 }
 
-func (m *fileSerialManager) incrementAndUpdate(path string) (*models.SerialIndex, error) {
-	//Lock the file so no other process modifies it while we're updating
-	fileLock, err := fs.Flock(path)
+// Reads and validates the change index file, the caller must hold the lock on the file.
+func (m *fileSerialManager) readIndex(path string) (*models.SerialIndex, error) {
+	serialIndex, err := m.indexFile.Read(path)
 	if err != nil {
 		return nil, err
 	}
-	defer fileLock.Unlock() //nolint:errcheck // unlock errors are not critical in defer
+	if serialIndex == nil || serialIndex.Base == nil || serialIndex.ChangeIndex == nil {
+		return nil, fmt.Errorf("invalid serial change index file '%s', it must contain base_serial_number and change_index", path)
+	}
+	return serialIndex, nil
+}
 
-	serialIndex, err := m.indexFile.Read(path)
+// Reads the change index file and returns the change index that follows it, the file itself is not updated.
+// The caller must hold the lock on the file.
+func (m *fileSerialManager) incrementedIndex(path string) (*models.SerialIndex, error) {
+	serialIndex, err := m.readIndex(path)
 	if err != nil {
 		return nil, err
 	}
@@ -136,20 +212,11 @@ func (m *fileSerialManager) incrementAndUpdate(path string) (*models.SerialIndex
 
 	logger().Trace("comparing base serial numbers", "current", *serialIndex.Base, "new", *newBase)
 	if *serialIndex.Base != *newBase {
-		serialIndex.Base = newBase
 		// Again with the constant/pointer workaround
 		changeIndex := initialChangeIndex
-		serialIndex.ChangeIndex = &changeIndex
-	} else {
-		*serialIndex.ChangeIndex++
-	}
-
-	logger().Trace("writing updated serial change index file", "file", path, "baseSerialNumber", *serialIndex.Base, "changeIndex", *serialIndex.ChangeIndex)
-	// Write the updated values back to the file
-	err = m.indexFile.Write(path, serialIndex)
-	if err != nil {
-		return nil, err
+		return &models.SerialIndex{Base: newBase, ChangeIndex: &changeIndex}, nil
 	}
 
-	return serialIndex, nil
+	changeIndex := *serialIndex.ChangeIndex + 1
+	return &models.SerialIndex{Base: serialIndex.Base, ChangeIndex: &changeIndex}, nil
 }
@@ -36,19 +36,25 @@ import (
 type TestYamlFile struct {
 	readErr  bool
 	writeErr bool
+	invalid  bool
+	written  *models.SerialIndex
 }
 
 func (t *TestYamlFile) Read(path string) (*models.SerialIndex, error) {
 	if t.readErr {
 		return nil, errors.New("readErr")
 	}
-	return &models.SerialIndex{Base: toUint32Ptr(12345678), ChangeIndex: toUint32Ptr(32)}, nil
+	if t.invalid {
+		return &models.SerialIndex{}, nil
+	}
+	return &models.SerialIndex{Base: toUint32Ptr(12345678), ChangeIndex: toUint32Ptr(32), ContentHash: "stored-hash"}, nil
 }
 
 func (t *TestYamlFile) Write(path string, content *models.SerialIndex) error {
 	if t.writeErr {
 		return errors.New("writeErr")
 	}
+	t.written = content
 	return nil
 }
 
@@ -74,276 +80,372 @@ func setupGenerator(t *testing.T) (*gomock.Controller, *MockGenerator) {
 	return ctrl, mockGen
 }
 
-func TestNext(t *testing.T) {
+func TestPeek(t *testing.T) {
 	t.Run("success-new-file", func(t *testing.T) {
 		_, mockGen := setupGenerator(t)
 		fsm := newFsm(t)
-		fs = &utils.FileSystem{}
-		defer func() { fs = &utils.FileSystem{} }()
 
 		mockGen.EXPECT().GenerateBase().Return(toUint32Ptr(12345678), nil)
 		mockGen.EXPECT().FromSerialIndex(&models.SerialIndex{Base: toUint32Ptr(12345678), ChangeIndex: toUint32Ptr(1)}).Return("1234567801", nil)
 
-		serial, err := fsm.Next(testZoneName)
+		serial, err := fsm.Peek(testZoneName)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 		if serial != "1234567801" {
 			t.Errorf("got serial %q, want %q", serial, "1234567801")
 		}
+		// Peek must never create the change index file
+		if _, err := os.Stat(serialFilePath(fsm)); !os.IsNotExist(err) {
+			t.Errorf("expected the serial file to not exist, stat returned: %v", err)
+		}
 	})
 
-	t.Run("success-existing-file", func(t *testing.T) {
+	t.Run("success-new-directory", func(t *testing.T) {
 		_, mockGen := setupGenerator(t)
-		fsm := newFsm(t)
-		fs = &utils.FileSystem{}
-		defer func() { fs = &utils.FileSystem{} }()
-
-		// Pre-create the serial file so Exists returns true
-		filePath := serialFilePath(fsm)
-		if err := os.WriteFile(filePath, []byte{}, 0600); err != nil {
-			t.Fatalf("failed to create serial file: %v", err)
-		}
+		fsm := fileSerialManager{changeIndexDirectory: filepath.Join(t.TempDir(), "missing"), indexFile: &TestYamlFile{}}
 
 		mockGen.EXPECT().GenerateBase().Return(toUint32Ptr(12345678), nil)
-		mockGen.EXPECT().FromSerialIndex(&models.SerialIndex{Base: toUint32Ptr(12345678), ChangeIndex: toUint32Ptr(33)}).Return("1234567833", nil)
+		mockGen.EXPECT().FromSerialIndex(gomock.Any()).Return("1234567801", nil)
 
-		serial, err := fsm.Next(testZoneName)
-		if err != nil {
+		if _, err := fsm.Peek(testZoneName); err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if serial != "1234567833" {
-			t.Errorf("got serial %q, want %q", serial, "1234567833")
+		if _, err := os.Stat(fsm.changeIndexDirectory); !os.IsNotExist(err) {
+			t.Errorf("expected the change index directory to not exist, stat returned: %v", err)
 		}
 	})
 
-	t.Run("mkdir-error", func(t *testing.T) {
-		fs = &utils.FileSystem{}
-		defer func() { fs = &utils.FileSystem{} }()
+	t.Run("success-existing-file", func(t *testing.T) {
+		_, mockGen := setupGenerator(t)
+		indexFile := &TestYamlFile{}
+		fsm := fileSerialManager{changeIndexDirectory: t.TempDir(), indexFile: indexFile}
 
-		// Create a file at the directory path so MkdirAll fails
-		conflictPath := filepath.Join(t.TempDir(), "conflict")
-		if err := os.WriteFile(conflictPath, []byte("conflict"), 0600); err != nil {
-			t.Fatalf("failed to create conflict file: %v", err)
+		// Pre-create the serial file so Exists returns true
+		if err := os.WriteFile(serialFilePath(fsm), []byte{}, 0600); err != nil {
+			t.Fatalf("failed to create serial file: %v", err)
 		}
-		fsm := fileSerialManager{changeIndexDirectory: conflictPath, indexFile: &TestYamlFile{}}
 
-		_, err := fsm.Next(testZoneName)
-		if err == nil {
-			t.Error("expected an error, got none")
-		}
-	})
+		// Peeking twice must return the same serial number
+		mockGen.EXPECT().GenerateBase().Return(toUint32Ptr(12345678), nil).Times(2)
+		mockGen.EXPECT().FromSerialIndex(&models.SerialIndex{Base: toUint32Ptr(12345678), ChangeIndex: toUint32Ptr(33)}).Return("1234567833", nil).Times(2)
 
-	t.Run("flock-error-new-file", func(t *testing.T) {
-		fs = &utils.FileSystem{}
-		defer func() { fs = &utils.FileSystem{} }()
-
-		// Make the directory read-only so Flock can't create the file
-		readOnlyDir := t.TempDir()
-		if err := os.Chmod(readOnlyDir, 0555); err != nil {
-			t.Fatalf("failed to chmod: %v", err)
+		for range 2 {
+			serial, err := fsm.Peek(testZoneName)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if serial != "1234567833" {
+				t.Errorf("got serial %q, want %q", serial, "1234567833")
+			}
 		}
-		t.Cleanup(func() { os.Chmod(readOnlyDir, 0755) })
-		fsm := fileSerialManager{changeIndexDirectory: readOnlyDir, indexFile: &TestYamlFile{}}
-
-		_, err := fsm.Next(testZoneName)
-		if err == nil {
-			t.Error("expected an error, got none")
+		if indexFile.written != nil {
+			t.Errorf("expected no writes, found: %v", indexFile.written)
 		}
 	})
 
 	t.Run("flock-error-existing-file", func(t *testing.T) {
-		fs = &utils.FileSystem{}
-		defer func() { fs = &utils.FileSystem{} }()
-
 		fsm := newFsm(t)
 		// Create a directory at the serial file path so Exists returns true and Flock fails
-		dirAtFilePath := serialFilePath(fsm)
-		if err := os.MkdirAll(dirAtFilePath, 0755); err != nil {
+		if err := os.MkdirAll(serialFilePath(fsm), 0755); err != nil {
 			t.Fatalf("failed to create dir at file path: %v", err)
 		}
 
-		_, err := fsm.Next(testZoneName)
-		if err == nil {
+		if _, err := fsm.Peek(testZoneName); err == nil {
 			t.Error("expected an error, got none")
 		}
 	})
 
+	t.Run("initial-index-error", func(t *testing.T) {
+		_, mockGen := setupGenerator(t)
+		fsm := newFsm(t)
+
+		mockGen.EXPECT().GenerateBase().Return(nil, errors.New("generateBaseErr"))
+
+		_, err := fsm.Peek(testZoneName)
+		if err == nil || err.Error() != "generateBaseErr" {
+			t.Errorf("got error %v, want %q", err, "generateBaseErr")
+		}
+	})
+
 	t.Run("generate-error", func(t *testing.T) {
 		_, mockGen := setupGenerator(t)
 		fsm := newFsm(t)
-		fs = &utils.FileSystem{}
-		defer func() { fs = &utils.FileSystem{} }()
 
 		mockGen.EXPECT().GenerateBase().Return(toUint32Ptr(12345678), nil)
 		mockGen.EXPECT().FromSerialIndex(gomock.Any()).Return("", errors.New("generateErr"))
 
-		_, err := fsm.Next(testZoneName)
+		_, err := fsm.Peek(testZoneName)
 		if err == nil || err.Error() != "generateErr" {
 			t.Errorf("got error %v, want %q", err, "generateErr")
 		}
 	})
 }
 
-func TestInitFile(t *testing.T) {
+func TestCurrent(t *testing.T) {
+	t.Run("no-file", func(t *testing.T) {
+		fsm := newFsm(t)
+
+		serial, contentHash, err := fsm.Current(testZoneName)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if serial != "" || contentHash != "" {
+			t.Errorf("expected an empty serial and content hash, got %q and %q", serial, contentHash)
+		}
+	})
+
+	t.Run("existing-file", func(t *testing.T) {
+		_, mockGen := setupGenerator(t)
+		fsm := newFsm(t)
+		if err := os.WriteFile(serialFilePath(fsm), []byte{}, 0600); err != nil {
+			t.Fatalf("failed to create serial file: %v", err)
+		}
+
+		// The committed change index is returned as is, not incremented
+		mockGen.EXPECT().FromSerialIndex(&models.SerialIndex{Base: toUint32Ptr(12345678), ChangeIndex: toUint32Ptr(32), ContentHash: "stored-hash"}).Return("1234567832", nil)
+
+		serial, contentHash, err := fsm.Current(testZoneName)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if serial != "1234567832" || contentHash != "stored-hash" {
+			t.Errorf("got %q and %q, want %q and %q", serial, contentHash, "1234567832", "stored-hash")
+		}
+	})
+
+	testCases := []struct {
+		name      string
+		flockErr  bool
+		readErr   bool
+		serialErr bool
+	}{
+		{name: "flock-error", flockErr: true},
+		{name: "read-error", readErr: true},
+		{name: "serial-error", serialErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, mockGen := setupGenerator(t)
+			fsm := fileSerialManager{changeIndexDirectory: t.TempDir(), indexFile: &TestYamlFile{readErr: tc.readErr}}
+			if tc.flockErr {
+				// Create a directory at the serial file path so Exists returns true and Flock fails
+				if err := os.MkdirAll(serialFilePath(fsm), 0755); err != nil {
+					t.Fatalf("failed to create dir at file path: %v", err)
+				}
+			} else if err := os.WriteFile(serialFilePath(fsm), []byte{}, 0600); err != nil {
+				t.Fatalf("failed to create serial file: %v", err)
+			}
+			if tc.serialErr {
+				mockGen.EXPECT().FromSerialIndex(gomock.Any()).Return("", errors.New("serialErr"))
+			}
+
+			if _, _, err := fsm.Current(testZoneName); err == nil {
+				t.Error("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestCommit(t *testing.T) {
+	t.Run("success-new-file", func(t *testing.T) {
+		_, mockGen := setupGenerator(t)
+		indexFile := &TestYamlFile{}
+		fsm := fileSerialManager{changeIndexDirectory: filepath.Join(t.TempDir(), "new"), indexFile: indexFile}
+
+		mockGen.EXPECT().GenerateBase().Return(toUint32Ptr(12345678), nil)
+		mockGen.EXPECT().FromSerialIndex(gomock.Any()).Return("1234567801", nil)
+
+		if err := fsm.Commit(testZoneName, "1234567801", "new-hash"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := &models.SerialIndex{Base: toUint32Ptr(12345678), ChangeIndex: toUint32Ptr(1), ContentHash: "new-hash"}
+		if !cmp.Equal(indexFile.written, want) {
+			t.Errorf("incorrect index written:\n%s", cmp.Diff(indexFile.written, want))
+		}
+	})
+
+	t.Run("success-existing-file", func(t *testing.T) {
+		_, mockGen := setupGenerator(t)
+		indexFile := &TestYamlFile{}
+		fsm := fileSerialManager{changeIndexDirectory: t.TempDir(), indexFile: indexFile}
+		if err := os.WriteFile(serialFilePath(fsm), []byte{}, 0600); err != nil {
+			t.Fatalf("failed to create serial file: %v", err)
+		}
+
+		mockGen.EXPECT().GenerateBase().Return(toUint32Ptr(12345678), nil)
+		mockGen.EXPECT().FromSerialIndex(gomock.Any()).Return("1234567833", nil)
+
+		if err := fsm.Commit(testZoneName, "1234567833", "new-hash"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := &models.SerialIndex{Base: toUint32Ptr(12345678), ChangeIndex: toUint32Ptr(33), ContentHash: "new-hash"}
+		if !cmp.Equal(indexFile.written, want) {
+			t.Errorf("incorrect index written:\n%s", cmp.Diff(indexFile.written, want))
+		}
+	})
+
+	t.Run("changed-since-peek", func(t *testing.T) {
+		_, mockGen := setupGenerator(t)
+		indexFile := &TestYamlFile{}
+		fsm := fileSerialManager{changeIndexDirectory: t.TempDir(), indexFile: indexFile}
+
+		mockGen.EXPECT().GenerateBase().Return(toUint32Ptr(12345678), nil)
+		mockGen.EXPECT().FromSerialIndex(gomock.Any()).Return("1234567802", nil)
+
+		err := fsm.Commit(testZoneName, "1234567801", "new-hash")
+		want := "unable to commit serial number '1234567801' for zone 'testing', the change index has changed since it was reserved, the next serial number is now '1234567802'"
+		if err == nil || err.Error() != want {
+			t.Errorf("got error %v, want %q", err, want)
+		}
+		if indexFile.written != nil {
+			t.Errorf("expected no writes, found: %v", indexFile.written)
+		}
+	})
+
+	t.Run("mkdir-error", func(t *testing.T) {
+		// Create a file at the directory path so MkdirAll fails
+		conflictPath := filepath.Join(t.TempDir(), "conflict")
+		if err := os.WriteFile(conflictPath, []byte("conflict"), 0600); err != nil {
+			t.Fatalf("failed to create conflict file: %v", err)
+		}
+		fsm := fileSerialManager{changeIndexDirectory: conflictPath, indexFile: &TestYamlFile{}}
+
+		if err := fsm.Commit(testZoneName, "1234567801", "new-hash"); err == nil {
+			t.Error("expected an error, got none")
+		}
+	})
+
+	t.Run("flock-error", func(t *testing.T) {
+		fsm := newFsm(t)
+		// Create a directory at the serial file path so Flock fails
+		if err := os.MkdirAll(serialFilePath(fsm), 0755); err != nil {
+			t.Fatalf("failed to create dir at file path: %v", err)
+		}
+
+		if err := fsm.Commit(testZoneName, "1234567801", "new-hash"); err == nil {
+			t.Error("expected an error, got none")
+		}
+	})
+
 	testCases := []struct {
 		name        string
-		flockErr    bool
+		existing    bool
+		readErr     bool
 		generateErr bool
+		serialErr   bool
 		writeErr    bool
+		wantErr     string
 	}{
-		{name: "success"},
-		{name: "flock-error", flockErr: true},
-		{name: "generate-error", generateErr: true},
-		{name: "write-error", writeErr: true},
+		{name: "initial-index-error", generateErr: true, wantErr: "generateBaseErr"},
+		{name: "incremented-index-error", existing: true, readErr: true, wantErr: "readErr"},
+		{name: "serial-error", serialErr: true, wantErr: "serialErr"},
+		{name: "write-error", writeErr: true, wantErr: "writeErr"},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			ctrl, mockGen := setupGenerator(t)
-			defer ctrl.Finish()
-			fs = &utils.FileSystem{}
-			defer func() { fs = &utils.FileSystem{} }()
-
-			var path string
-			if tc.flockErr {
-				// Create a directory at the path to make Flock fail
-				dirPath := filepath.Join(t.TempDir(), "test.serial")
-				if err := os.MkdirAll(dirPath, 0755); err != nil {
-					t.Fatalf("failed to create dir: %v", err)
+			_, mockGen := setupGenerator(t)
+			fsm := fileSerialManager{changeIndexDirectory: t.TempDir(), indexFile: &TestYamlFile{readErr: tc.readErr, writeErr: tc.writeErr}}
+			if tc.existing {
+				if err := os.WriteFile(serialFilePath(fsm), []byte{}, 0600); err != nil {
+					t.Fatalf("failed to create serial file: %v", err)
 				}
-				path = dirPath
-			} else {
-				path = filepath.Join(t.TempDir(), "test.serial")
 			}
 
-			fsm := fileSerialManager{changeIndexDirectory: t.TempDir(), indexFile: &TestYamlFile{}}
-			if !tc.flockErr {
+			if !tc.readErr {
 				call := mockGen.EXPECT().GenerateBase()
 				if tc.generateErr {
-					call.Return(nil, errors.New("generateErr"))
+					call.Return(nil, errors.New("generateBaseErr"))
 				} else {
 					call.Return(toUint32Ptr(12345678), nil)
-					if tc.writeErr {
-						fsm.indexFile = &TestYamlFile{writeErr: true}
+					serialCall := mockGen.EXPECT().FromSerialIndex(gomock.Any())
+					if tc.serialErr {
+						serialCall.Return("", errors.New("serialErr"))
+					} else {
+						serialCall.Return("1234567801", nil)
 					}
 				}
 			}
 
-			si, err := fsm.initFile(path)
-			if err != nil {
-				if tc.flockErr || tc.generateErr {
-					return // expected error, any message is fine for filesystem errors
-				}
-				if tc.writeErr {
-					if err.Error() != "writeErr" {
-						t.Errorf("got error %q, want %q", err.Error(), "writeErr")
-					}
-					return
-				}
-				t.Errorf("unexpected error: %v", err)
-			} else {
-				if tc.flockErr || tc.generateErr || tc.writeErr {
-					t.Error("expected an error, got none")
-				} else {
-					want := &models.SerialIndex{Base: toUint32Ptr(12345678), ChangeIndex: toUint32Ptr(1)}
-					if !cmp.Equal(si, want) {
-						t.Errorf("incorrect result:\n%s", cmp.Diff(si, want))
-					}
-				}
+			err := fsm.Commit(testZoneName, "1234567801", "new-hash")
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("got error %v, want %q", err, tc.wantErr)
 			}
 		})
 	}
 }
 
-func TestIncrementAndUpdate(t *testing.T) {
+func TestInitialIndex(t *testing.T) {
+	_, mockGen := setupGenerator(t)
+	fsm := newFsm(t)
+
+	mockGen.EXPECT().GenerateBase().Return(toUint32Ptr(12345678), nil)
+	si, err := fsm.initialIndex()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &models.SerialIndex{Base: toUint32Ptr(12345678), ChangeIndex: toUint32Ptr(1)}
+	if !cmp.Equal(si, want) {
+		t.Errorf("incorrect result:\n%s", cmp.Diff(si, want))
+	}
+
+	mockGen.EXPECT().GenerateBase().Return(nil, errors.New("generateErr"))
+	if _, err := fsm.initialIndex(); err == nil || err.Error() != "generateErr" {
+		t.Errorf("got error %v, want %q", err, "generateErr")
+	}
+}
+
+func TestIncrementedIndex(t *testing.T) {
 	testCases := []struct {
 		name        string
-		flockErr    bool
 		readErr     bool
+		invalid     bool
 		generateErr bool
 		diffBase    bool
-		writeErr    bool
+		wantErr     string
 	}{
 		{name: "success"},
 		{name: "different-base", diffBase: true},
-		{name: "flock-error", flockErr: true},
-		{name: "read-error", readErr: true},
-		{name: "generate-error", generateErr: true},
-		{name: "write-error", writeErr: true},
+		{name: "read-error", readErr: true, wantErr: "readErr"},
+		{name: "invalid-file", invalid: true, wantErr: "invalid serial change index file 'test.serial', it must contain base_serial_number and change_index"},
+		{name: "generate-error", generateErr: true, wantErr: "generateErr"},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			ctrl, mockGen := setupGenerator(t)
-			defer ctrl.Finish()
-			fs = &utils.FileSystem{}
-			defer func() { fs = &utils.FileSystem{} }()
-
-			var path string
-			if tc.flockErr {
-				dirPath := filepath.Join(t.TempDir(), "test.serial")
-				if err := os.MkdirAll(dirPath, 0755); err != nil {
-					t.Fatalf("failed to create dir: %v", err)
-				}
-				path = dirPath
-			} else {
-				path = filepath.Join(t.TempDir(), "test.serial")
-			}
+			_, mockGen := setupGenerator(t)
+			fsm := fileSerialManager{changeIndexDirectory: t.TempDir(), indexFile: &TestYamlFile{readErr: tc.readErr, invalid: tc.invalid}}
 
-			indexFile := &TestYamlFile{}
-			if tc.readErr {
-				indexFile = &TestYamlFile{readErr: true}
-			}
-			fsm := fileSerialManager{changeIndexDirectory: t.TempDir(), indexFile: indexFile}
-
-			if !tc.flockErr && !tc.readErr {
+			if !tc.readErr && !tc.invalid {
 				call := mockGen.EXPECT().GenerateBase()
 				if tc.generateErr {
 					call.Return(nil, errors.New("generateErr"))
+				} else if tc.diffBase {
+					call.Return(toUint32Ptr(123456789), nil)
 				} else {
-					if tc.diffBase {
-						call.Return(toUint32Ptr(123456789), nil)
-					} else {
-						call.Return(toUint32Ptr(12345678), nil)
-					}
-					if tc.writeErr {
-						fsm.indexFile = &TestYamlFile{writeErr: true}
-					}
+					call.Return(toUint32Ptr(12345678), nil)
 				}
 			}
 
-			si, err := fsm.incrementAndUpdate(path)
+			si, err := fsm.incrementedIndex("test.serial")
 			if err != nil {
-				if tc.flockErr || tc.generateErr {
-					return // expected, OS-specific message
-				}
-				if tc.readErr {
-					if err.Error() != "readErr" {
-						t.Errorf("got error %q, want %q", err.Error(), "readErr")
-					}
-					return
-				}
-				if tc.writeErr {
-					if err.Error() != "writeErr" {
-						t.Errorf("got error %q, want %q", err.Error(), "writeErr")
-					}
-					return
-				}
-				t.Errorf("unexpected error: %v", err)
-			} else {
-				if tc.flockErr || tc.generateErr || tc.writeErr {
-					t.Error("expected an error, got none")
-				} else {
-					want := &models.SerialIndex{Base: toUint32Ptr(12345678), ChangeIndex: toUint32Ptr(33)}
-					if tc.diffBase {
-						want.Base = toUint32Ptr(123456789)
-						want.ChangeIndex = toUint32Ptr(1)
-					}
-					if !cmp.Equal(si, want) {
-						t.Errorf("incorrect result:\n%s", cmp.Diff(si, want))
-					}
+				if err.Error() != tc.wantErr {
+					t.Errorf("got error %q, want %q", err.Error(), tc.wantErr)
 				}
+				return
+			}
+			if tc.wantErr != "" {
+				t.Fatalf("expected error %q, got none", tc.wantErr)
+			}
+
+			want := &models.SerialIndex{Base: toUint32Ptr(12345678), ChangeIndex: toUint32Ptr(33)}
+			if tc.diffBase {
+				want = &models.SerialIndex{Base: toUint32Ptr(123456789), ChangeIndex: toUint32Ptr(1)}
+			}
+			if !cmp.Equal(si, want) {
+				t.Errorf("incorrect result:\n%s", cmp.Diff(si, want))
 			}
 		})
 	}
@@ -0,0 +1,79 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bcurnow/zonemgr/models"
+)
+
+// Turns the keyed form of every SOA record into the positional values the SOA plugin expects, the fields the record
+// doesn't set are taken from the soa_defaults of the zone. An SOA record that sets neither is built from the
+// soa_defaults alone, when the zone doesn't have any, the record is left for the SOA plugin to report. The name of the SOA record can be @ which is replaced with the name of the zone, so an SOA record
+// can be shared through a template or an extended zone.
+func expandSOARecords(zones map[string]*models.Zone) models.ValidationErrors {
+	var errs models.ValidationErrors
+	models.WithSortedZones(zones, func(name string, zone *models.Zone) error {
+		zone.WithSortedResourceRecords(func(identifier string, rr *models.ResourceRecord) error {
+			if rr == nil {
+				return nil
+			}
+			if err := expandSOARecord(name, zone, rr); err != nil {
+				errs = append(errs, recordError(name, zone, identifier, models.RuleSOA, err))
+			}
+			return nil
+		})
+		return nil
+	})
+	return errs
+}
+
+func expandSOARecord(name string, zone *models.Zone, rr *models.ResourceRecord) error {
+	if rr.Type != models.SOA {
+		if rr.SOA != nil {
+			return errors.New("the soa field can only be used on SOA records")
+		}
+		return nil
+	}
+
+	if rr.Name == "@" {
+		rr.Name = name
+	}
+
+	if len(rr.Values) > 0 {
+		if rr.SOA != nil {
+			return errors.New("the soa and values fields cannot be used together, use one or the other")
+		}
+		return nil
+	}
+	if rr.SOA == nil && zone.SOADefaults == nil {
+		return nil
+	}
+
+	values, err := rr.SOA.WithDefaults(zone.SOADefaults).Values()
+	if err != nil {
+		return fmt.Errorf("invalid SOA record, %w", err)
+	}
+	rr.Values = values
+	rr.SOA = nil
+	return nil
+}
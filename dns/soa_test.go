@@ -0,0 +1,123 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"testing"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpandSOARecords(t *testing.T) {
+	defaults := &models.SOAFields{
+		MName:   &models.SOAField{Value: "ns1.example.com."},
+		RName:   &models.SOAField{Value: "hostmaster.example.com."},
+		Refresh: &models.SOAField{Value: "7200"},
+		Retry:   &models.SOAField{Value: "600"},
+		Expire:  &models.SOAField{Value: "3600000"},
+		Minimum: &models.SOAField{Value: "172800"},
+	}
+	positional := []*models.ResourceRecordValue{{Value: "ns1.example.com."}, {Value: "hostmaster.example.com."}, {Value: "7200"}, {Value: "600"}, {Value: "3600000"}, {Value: "172800"}}
+
+	testCases := []struct {
+		name        string
+		rr          *models.ResourceRecord
+		soaDefaults *models.SOAFields
+		want        *models.ResourceRecord
+		err         string
+	}{
+		{
+			name: "keyed",
+			rr: &models.ResourceRecord{Name: "@", Type: models.SOA, SOA: &models.SOAFields{
+				MName:   &models.SOAField{Value: "ns1.example.com."},
+				RName:   &models.SOAField{Value: "hostmaster.example.com."},
+				Serial:  &models.SOAField{Value: "2025010101", Comment: "serial"},
+				Refresh: &models.SOAField{Value: "7200"},
+				Retry:   &models.SOAField{Value: "600"},
+				Expire:  &models.SOAField{Value: "3600000"},
+				Minimum: &models.SOAField{Value: "172800"},
+			}},
+			want: &models.ResourceRecord{Name: "example.com.", Type: models.SOA, Values: []*models.ResourceRecordValue{
+				{Value: "ns1.example.com."}, {Value: "hostmaster.example.com."}, {Value: "2025010101", Comment: "serial"}, {Value: "7200"}, {Value: "600"}, {Value: "3600000"}, {Value: "172800"},
+			}},
+		},
+		{
+			name:        "keyed-with-defaults",
+			rr:          &models.ResourceRecord{Name: "example.com.", Type: models.SOA, SOA: &models.SOAFields{RName: &models.SOAField{Value: "admin.example.com."}}},
+			soaDefaults: defaults,
+			want: &models.ResourceRecord{Name: "example.com.", Type: models.SOA, Values: []*models.ResourceRecordValue{
+				{Value: "ns1.example.com."}, {Value: "admin.example.com."}, {Value: "7200"}, {Value: "600"}, {Value: "3600000"}, {Value: "172800"},
+			}},
+		},
+		{
+			name:        "defaults-only",
+			rr:          &models.ResourceRecord{Name: "@", Type: models.SOA},
+			soaDefaults: defaults,
+			want:        &models.ResourceRecord{Name: "example.com.", Type: models.SOA, Values: positional},
+		},
+		{
+			name:        "positional",
+			rr:          &models.ResourceRecord{Name: "example.com.", Type: models.SOA, Values: positional},
+			soaDefaults: defaults,
+			want:        &models.ResourceRecord{Name: "example.com.", Type: models.SOA, Values: positional},
+		},
+		{
+			// The SOA plugin reports the missing values
+			name: "neither",
+			rr:   &models.ResourceRecord{Name: "example.com.", Type: models.SOA},
+			want: &models.ResourceRecord{Name: "example.com.", Type: models.SOA},
+		},
+		{name: "not-soa", rr: &models.ResourceRecord{Name: "www", Type: models.A, Value: "192.0.2.1"}, want: &models.ResourceRecord{Name: "www", Type: models.A, Value: "192.0.2.1"}},
+		{
+			name: "both-forms",
+			rr:   &models.ResourceRecord{Type: models.SOA, Values: positional, SOA: defaults},
+			err:  "zone 'example.com.', identifier 'soa': the soa and values fields cannot be used together, use one or the other",
+		},
+		{
+			name: "missing-fields",
+			rr:   &models.ResourceRecord{Type: models.SOA, SOA: &models.SOAFields{MName: &models.SOAField{Value: "ns1.example.com."}}},
+			err:  "zone 'example.com.', identifier 'soa': invalid SOA record, missing rname, refresh, retry, expire, minimum, set them on the SOA record or in the soa_defaults of the zone",
+		},
+		{
+			name: "soa-on-other-type",
+			rr:   &models.ResourceRecord{Type: models.A, SOA: defaults},
+			err:  "zone 'example.com.', identifier 'soa': the soa field can only be used on SOA records",
+		},
+	}
+
+	for _, tc := range testCases {
+		zone := &models.Zone{SOADefaults: tc.soaDefaults, ResourceRecords: map[string]*models.ResourceRecord{"soa": tc.rr}}
+		errs := expandSOARecords(map[string]*models.Zone{"example.com.": zone})
+		if tc.err != "" {
+			if errs.Error() != tc.err {
+				t.Errorf("%s - incorrect error: '%s', want: '%s'", tc.name, errs, tc.err)
+			}
+			continue
+		}
+		if len(errs) > 0 {
+			t.Errorf("%s - unexpected error: %s", tc.name, errs)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, zone.ResourceRecords["soa"], cmp.AllowUnexported(models.ResourceRecord{})); diff != "" {
+			t.Errorf("%s - incorrect SOA record:\n%s", tc.name, diff)
+		}
+	}
+}
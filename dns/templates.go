@@ -0,0 +1,86 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bcurnow/zonemgr/models"
+)
+
+// Adds the resource records of the templates each zone includes, in the order they are included. A resource record of
+// the zone itself replaces the template resource record with the same identifier, when two included templates use the
+// same identifier, the zone has to define it to pick one. The resource records are copied so the normalizer can change
+// them in one zone without changing them in every other zone that includes the same template.
+func includeTemplates(zones map[string]*models.Zone, templates map[string]*models.Template) models.ValidationErrors {
+	var errs models.ValidationErrors
+	models.WithSortedZones(zones, func(name string, zone *models.Zone) error {
+		if len(zone.Include) == 0 {
+			return nil
+		}
+		if nil == zone.ResourceRecords {
+			zone.ResourceRecords = make(map[string]*models.ResourceRecord)
+		}
+
+		own := sortedKeys(zone.ResourceRecords)
+		// The template each identifier was included from
+		includedFrom := make(map[string]string)
+		for i, templateName := range zone.Include {
+			template, ok := templates[templateName]
+			switch {
+			case !ok:
+				errs = append(errs, templateError(name, zone, templateName, errors.New("unknown template")))
+				continue
+			case slices.Contains(zone.Include[:i], templateName):
+				errs = append(errs, templateError(name, zone, templateName, errors.New("template is included more than once")))
+				continue
+			}
+
+			for _, identifier := range sortedKeys(template.ResourceRecords) {
+				if slices.Contains(own, identifier) {
+					logger().Trace("zone overrides template resource record", "zone", name, "template", templateName, "identifier", identifier)
+					continue
+				}
+				if other, ok := includedFrom[identifier]; ok {
+					errs = append(errs, &models.ValidationError{
+						Position:   zone.Position(),
+						Zone:       name,
+						Identifier: identifier,
+						Rule:       models.RuleTemplate,
+						Err:        fmt.Errorf("identifier '%s' is included from both template '%s' and template '%s', define it in the zone to choose one", identifier, other, templateName),
+					})
+					continue
+				}
+
+				includedFrom[identifier] = templateName
+				zone.ResourceRecords[identifier] = template.ResourceRecords[identifier].Clone()
+				zone.SetResourceRecordPosition(identifier, template.ResourceRecordPosition(identifier))
+			}
+		}
+		return nil
+	})
+	return errs
+}
+
+func templateError(zoneName string, zone *models.Zone, templateName string, err error) *models.ValidationError {
+	return &models.ValidationError{Position: zone.Position(), Zone: zoneName, Template: templateName, Rule: models.RuleTemplate, Err: err}
+}
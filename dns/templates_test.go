@@ -0,0 +1,109 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"testing"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIncludeTemplates(t *testing.T) {
+	templatePosition := &models.SourcePosition{File: "templates.yaml", Line: 2, Column: 3}
+	ns1Position := &models.SourcePosition{File: "templates.yaml", Line: 4, Column: 7}
+	zonePosition := &models.SourcePosition{File: "zones.yaml", Line: 1, Column: 1}
+
+	newTemplates := func() map[string]*models.Template {
+		common := &models.Template{ResourceRecords: map[string]*models.ResourceRecord{
+			"ns1": {Name: "@", Type: models.NS, Value: "ns1.example.com."},
+			"mx":  {Name: "@", Type: models.MX, Values: []*models.ResourceRecordValue{{Value: "10"}, {Value: "mail.example.com."}}},
+		}}
+		common.SetPosition(templatePosition)
+		common.SetResourceRecordPosition("ns1", ns1Position)
+		spf := &models.Template{ResourceRecords: map[string]*models.ResourceRecord{
+			"spf": {Name: "@", Type: models.TXT, Value: "v=spf1 -all"},
+			"mx":  {Name: "@", Type: models.MX, Values: []*models.ResourceRecordValue{{Value: "20"}, {Value: "mail.example.com."}}},
+		}}
+		return map[string]*models.Template{"common": common, "spf": spf}
+	}
+
+	testCases := []struct {
+		name        string
+		include     []string
+		records     map[string]*models.ResourceRecord
+		identifiers []string
+		want        string
+	}{
+		{name: "no-include", records: map[string]*models.ResourceRecord{"www": {Type: models.A}}, identifiers: []string{"www"}},
+		{name: "include", include: []string{"common"}, identifiers: []string{"mx", "ns1"}},
+		{name: "override", include: []string{"common", "spf"}, records: map[string]*models.ResourceRecord{"mx": {Type: models.MX}}, identifiers: []string{"mx", "ns1", "spf"}},
+		{name: "conflict", include: []string{"common", "spf"}, want: "zones.yaml:1:1: zone 'example.com.', identifier 'mx': identifier 'mx' is included from both template 'common' and template 'spf', define it in the zone to choose one"},
+		{name: "unknown", include: []string{"missing"}, want: "zones.yaml:1:1: zone 'example.com.', template 'missing': unknown template"},
+		{name: "included-twice", include: []string{"common", "common"}, want: "zones.yaml:1:1: zone 'example.com.', template 'common': template is included more than once"},
+	}
+
+	for _, tc := range testCases {
+		templates := newTemplates()
+		zone := &models.Zone{Include: tc.include, ResourceRecords: tc.records}
+		zone.SetPosition(zonePosition)
+		zones := map[string]*models.Zone{"example.com.": zone}
+
+		errs := includeTemplates(zones, templates)
+		if tc.want != "" {
+			if errs.Error() != tc.want {
+				t.Errorf("%s - incorrect error: '%s', want: '%s'", tc.name, errs, tc.want)
+			}
+			continue
+		}
+		if len(errs) > 0 {
+			t.Errorf("%s - unexpected error: %s", tc.name, errs)
+			continue
+		}
+
+		if diff := cmp.Diff(tc.identifiers, sortedKeys(zone.ResourceRecords)); diff != "" {
+			t.Errorf("%s - incorrect identifiers:\n%s", tc.name, diff)
+		}
+
+		for identifier, rr := range zone.ResourceRecords {
+			if tc.records[identifier] != nil {
+				if rr != tc.records[identifier] {
+					t.Errorf("%s - expected the zone's own '%s' record to be kept", tc.name, identifier)
+				}
+				continue
+			}
+			// Included records are copies, changing them doesn't change the template
+			for _, template := range templates {
+				if template.ResourceRecords[identifier] == rr {
+					t.Errorf("%s - expected '%s' to be a copy of the template record", tc.name, identifier)
+				}
+			}
+		}
+
+		if rr, ok := zone.ResourceRecords["ns1"]; ok {
+			if diff := cmp.Diff(templates["common"].ResourceRecords["ns1"], rr, cmp.AllowUnexported(models.ResourceRecord{})); diff != "" {
+				t.Errorf("%s - incorrect included record:\n%s", tc.name, diff)
+			}
+			if zone.ResourceRecordPosition("ns1") != ns1Position {
+				t.Errorf("%s - incorrect position for ns1: %s, want: %s", tc.name, zone.ResourceRecordPosition("ns1"), ns1Position)
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bcurnow/zonemgr/models"
+)
+
+// A record of the zone as the checks see it, names are canonical so records written differently can be compared
+type checkedRecord struct {
+	identifier string
+	rr         *models.ResourceRecord
+	owner      string
+	rrset      string
+	data       string
+	ttl        *int32
+}
+
+// Checks the records of the zone against each other whatever their types are, these are the problems a name server
+// refuses to load a zone for or that make the answers depend on which record it happens to pick:
+//   - A CNAME record can't be at the apex and can't share its name with any other record (RFC 1034 3.6.2)
+//   - The same record can't be defined more than once
+//   - The records of an RRset must have the same TTL (RFC 2181 5.2)
+func checkZone(name string, zone *models.Zone) models.ValidationErrors {
+	apex := models.AbsoluteName("@", name)
+
+	var records []*checkedRecord
+	byOwner := make(map[string][]*checkedRecord)
+	byRRset := make(map[string][]*checkedRecord)
+	zone.WithSortedResourceRecords(func(identifier string, rr *models.ResourceRecord) error {
+		record := newCheckedRecord(name, zone, identifier, rr)
+		records = append(records, record)
+		byOwner[record.owner] = append(byOwner[record.owner], record)
+		byRRset[record.rrset] = append(byRRset[record.rrset], record)
+		return nil
+	})
+
+	var errs models.ValidationErrors
+	for _, record := range records {
+		if record.rr.Type == models.CNAME {
+			if record.owner == apex {
+				errs = append(errs, recordError(name, zone, record.identifier, models.RuleCNAMEApex, fmt.Errorf("invalid CNAME record, '%s' is at the apex of the zone which must have SOA and NS records (RFC 1034 3.6.2)", record.identifier)))
+			} else if others := otherIdentifiers(byOwner[record.owner], record); len(others) > 0 {
+				errs = append(errs, recordError(name, zone, record.identifier, models.RuleCNAMEConflict, fmt.Errorf("invalid CNAME record, '%s' has other records with the same name (%s), a CNAME record can't have any other data (RFC 1034 3.6.2)", record.identifier, strings.Join(others, ", "))))
+			}
+		}
+
+		rrset := byRRset[record.rrset]
+		for _, other := range rrset {
+			if other == record {
+				break
+			}
+			if other.data == record.data {
+				errs = append(errs, recordError(name, zone, record.identifier, models.RuleDuplicateRecord, fmt.Errorf("invalid %s record, '%s' is a duplicate of '%s', they have the same name, class and data", record.rr.Type, record.identifier, other.identifier)))
+				break
+			}
+		}
+
+		// Every record is compared to the first record of its RRset so only the records that differ are reported
+		if first := rrset[0]; first != record && ttlString(first.ttl) != ttlString(record.ttl) {
+			errs = append(errs, recordError(name, zone, record.identifier, models.RuleTTLMismatch, fmt.Errorf("invalid %s record, '%s' has %s but '%s' of the same RRset has %s, the records of an RRset must have the same TTL (RFC 2181 5.2)", record.rr.Type, record.identifier, ttlDescription(record.ttl), first.identifier, ttlDescription(first.ttl))))
+		}
+	}
+	return errs
+}
+
+func newCheckedRecord(name string, zone *models.Zone, identifier string, rr *models.ResourceRecord) *checkedRecord {
+	class := rr.Class
+	if class == "" {
+		class = models.INTERNET
+	}
+	owner := models.AbsoluteName(rr.Name, name)
+
+	// The domain names in the data are compared the same way as the owner names
+	var fields []string
+	if len(rr.Values) > 0 {
+		for _, v := range rr.Values {
+			fields = append(fields, v.Value)
+		}
+	} else {
+		fields = []string{rr.Value}
+		if len(rr.Type.DomainNameFields()) > 0 {
+			fields = strings.Fields(rr.Value)
+		}
+	}
+	for _, field := range rr.Type.DomainNameFields() {
+		if field < len(fields) {
+			fields[field] = models.AbsoluteName(fields[field], name)
+		}
+	}
+
+	// A record without a TTL of its own uses the TTL of the zone
+	ttl := rr.TTL
+	if ttl == nil && zone.TTL != nil {
+		ttl = zone.TTL.Value
+	}
+
+	return &checkedRecord{
+		identifier: identifier,
+		rr:         rr,
+		owner:      owner,
+		rrset:      fmt.Sprintf("%s %s %s", owner, class, rr.Type),
+		data:       strings.Join(fields, " "),
+		ttl:        ttl,
+	}
+}
+
+// The quoted identifiers of the records other than record
+func otherIdentifiers(records []*checkedRecord, record *checkedRecord) []string {
+	var identifiers []string
+	for _, other := range records {
+		if other != record {
+			identifiers = append(identifiers, "'"+other.identifier+"'")
+		}
+	}
+	return identifiers
+}
+
+func ttlString(ttl *int32) string {
+	if ttl == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d", *ttl)
+}
+
+func ttlDescription(ttl *int32) string {
+	if ttl == nil {
+		return "no TTL"
+	}
+	return "a TTL of " + ttlString(ttl)
+}
@@ -0,0 +1,136 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"testing"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckZone(t *testing.T) {
+	testCases := []struct {
+		name      string
+		ttl       *models.TTL
+		records   map[string]*models.ResourceRecord
+		wantErr   []string
+		wantRules []string
+	}{
+		{
+			name: "valid",
+			records: map[string]*models.ResourceRecord{
+				"soa":  {Type: models.SOA, Name: "example.com.", Value: "SOA"},
+				"ns1":  {Type: models.NS, Name: "@", Value: "ns1.example.com."},
+				"ns2":  {Type: models.NS, Name: "@", Value: "ns2.example.com."},
+				"www":  {Type: models.A, Name: "www", Value: "192.0.2.1"},
+				"www2": {Type: models.A, Name: "www", Value: "192.0.2.2"},
+				"web":  {Type: models.CNAME, Name: "web", Value: "www"},
+			},
+		},
+		{
+			name: "cname-apex",
+			records: map[string]*models.ResourceRecord{
+				"soa":  {Type: models.SOA, Name: "example.com.", Value: "SOA"},
+				"apex": {Type: models.CNAME, Name: "@", Value: "www.example.net."},
+			},
+			wantErr:   []string{"zone 'example.com.', identifier 'apex': invalid CNAME record, 'apex' is at the apex of the zone which must have SOA and NS records (RFC 1034 3.6.2)"},
+			wantRules: []string{models.RuleCNAMEApex},
+		},
+		{
+			name: "cname-conflict",
+			records: map[string]*models.ResourceRecord{
+				"www":     {Type: models.CNAME, Name: "www", Value: "web"},
+				"www-a":   {Type: models.A, Name: "WWW.example.com.", Value: "192.0.2.1"},
+				"www-txt": {Type: models.TXT, Name: "www", Value: "text"},
+				"web":     {Type: models.A, Name: "web", Value: "192.0.2.2"},
+			},
+			wantErr:   []string{"zone 'example.com.', identifier 'www': invalid CNAME record, 'www' has other records with the same name ('www-a', 'www-txt'), a CNAME record can't have any other data (RFC 1034 3.6.2)"},
+			wantRules: []string{models.RuleCNAMEConflict},
+		},
+		{
+			name: "more-than-one-cname",
+			records: map[string]*models.ResourceRecord{
+				"www":   {Type: models.CNAME, Name: "www", Value: "web1"},
+				"www-2": {Type: models.CNAME, Name: "www", Value: "web2"},
+			},
+			wantErr: []string{
+				"zone 'example.com.', identifier 'www': invalid CNAME record, 'www' has other records with the same name ('www-2'), a CNAME record can't have any other data (RFC 1034 3.6.2)",
+				"zone 'example.com.', identifier 'www-2': invalid CNAME record, 'www-2' has other records with the same name ('www'), a CNAME record can't have any other data (RFC 1034 3.6.2)",
+			},
+			wantRules: []string{models.RuleCNAMEConflict, models.RuleCNAMEConflict},
+		},
+		{
+			name: "duplicates",
+			records: map[string]*models.ResourceRecord{
+				"www":     {Type: models.A, Name: "www", Value: "192.0.2.1"},
+				"www-2":   {Type: models.A, Name: "www.example.com.", Class: models.INTERNET, Value: "192.0.2.1"},
+				"www-3":   {Type: models.A, Name: "www", Value: "192.0.2.1"},
+				"mx":      {Type: models.MX, Name: "@", Value: "10 mail"},
+				"mx-2":    {Type: models.MX, Name: "@", Values: []*models.ResourceRecordValue{{Value: "10"}, {Value: "Mail.Example.Com."}}},
+				"txt":     {Type: models.TXT, Name: "txt", Value: "Text"},
+				"txt-2":   {Type: models.TXT, Name: "txt", Value: "text"},
+				"chaos-a": {Type: models.A, Name: "www", Class: models.CHAOS, Value: "192.0.2.1"},
+			},
+			wantErr: []string{
+				"zone 'example.com.', identifier 'mx-2': invalid MX record, 'mx-2' is a duplicate of 'mx', they have the same name, class and data",
+				"zone 'example.com.', identifier 'www-2': invalid A record, 'www-2' is a duplicate of 'www', they have the same name, class and data",
+				"zone 'example.com.', identifier 'www-3': invalid A record, 'www-3' is a duplicate of 'www', they have the same name, class and data",
+			},
+			wantRules: []string{models.RuleDuplicateRecord, models.RuleDuplicateRecord, models.RuleDuplicateRecord},
+		},
+		{
+			name: "ttls",
+			ttl:  &models.TTL{Value: toInt32Ptr(300)},
+			records: map[string]*models.ResourceRecord{
+				"a1": {Type: models.A, Name: "www", Value: "192.0.2.1"},
+				"a2": {Type: models.A, Name: "www", Value: "192.0.2.2", TTL: toInt32Ptr(300)},
+				"a3": {Type: models.A, Name: "www", Value: "192.0.2.3", TTL: toInt32Ptr(60)},
+				"b1": {Type: models.A, Name: "other", Value: "192.0.2.4", TTL: toInt32Ptr(60)},
+			},
+			wantErr:   []string{"zone 'example.com.', identifier 'a3': invalid A record, 'a3' has a TTL of 60 but 'a1' of the same RRset has a TTL of 300, the records of an RRset must have the same TTL (RFC 2181 5.2)"},
+			wantRules: []string{models.RuleTTLMismatch},
+		},
+		{
+			name: "ttls-without-zone-ttl",
+			records: map[string]*models.ResourceRecord{
+				"a1": {Type: models.A, Name: "www", Value: "192.0.2.1"},
+				"a2": {Type: models.A, Name: "www", Value: "192.0.2.2", TTL: toInt32Ptr(300)},
+			},
+			wantErr:   []string{"zone 'example.com.', identifier 'a2': invalid A record, 'a2' has a TTL of 300 but 'a1' of the same RRset has no TTL, the records of an RRset must have the same TTL (RFC 2181 5.2)"},
+			wantRules: []string{models.RuleTTLMismatch},
+		},
+	}
+
+	for _, tc := range testCases {
+		zone := &models.Zone{TTL: tc.ttl, ResourceRecords: tc.records}
+		var got, gotRules []string
+		for _, err := range checkZone("example.com.", zone) {
+			got = append(got, err.Error())
+			gotRules = append(gotRules, err.Rule)
+		}
+		if diff := cmp.Diff(tc.wantErr, got); diff != "" {
+			t.Errorf("%s - incorrect errors (-want +got):\n%s", tc.name, diff)
+		}
+		if diff := cmp.Diff(tc.wantRules, gotRules); diff != "" {
+			t.Errorf("%s - incorrect rules (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
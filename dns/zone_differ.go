@@ -0,0 +1,205 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bcurnow/zonemgr/models"
+)
+
+type ZoneDiffer interface {
+	// Compares the zone file at path with the rendered content of the zone at the resource record level, whitespace,
+	// comments and the SOA serial number are ignored. If the file at path doesn't exist, every record is added.
+	Diff(zoneName string, path string, rendered []byte) ([]*RecordChange, error)
+}
+
+type RecordChangeType string
+
+const (
+	RecordAdded   RecordChangeType = "added"
+	RecordRemoved RecordChangeType = "removed"
+	RecordChanged RecordChangeType = "changed"
+)
+
+// A single resource record difference, Old is empty for added records and New is empty for removed records
+type RecordChange struct {
+	Type RecordChangeType
+	Old  string
+	New  string
+}
+
+func (c *RecordChange) String() string {
+	switch c.Type {
+	case RecordAdded:
+		return "+ " + c.New
+	case RecordRemoved:
+		return "- " + c.Old
+	default:
+		return fmt.Sprintf("~ %s\n  => %s", c.Old, c.New)
+	}
+}
+
+type masterFileZoneDiffer struct {
+	ZoneDiffer
+}
+
+func MasterFileZoneDiffer() ZoneDiffer {
+	return &masterFileZoneDiffer{}
+}
+
+// A record as it is compared, the key ignores everything that doesn't change what the name server serves
+type diffRecord struct {
+	rrset   string
+	key     string
+	display string
+}
+
+func (d *masterFileZoneDiffer) Diff(zoneName string, path string, rendered []byte) ([]*RecordChange, error) {
+	var existing []*diffRecord
+	if fs.Exists(path) {
+		content, err := readFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open '%s': %w", path, err)
+		}
+		existing, err = diffRecords(zoneName, path, content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	generated, err := diffRecords(zoneName, zoneName, rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffRRsets(existing, generated), nil
+}
+
+func diffRecords(zoneName string, path string, content []byte) ([]*diffRecord, error) {
+	var records []*importedRecord
+	var zoneTTL *models.TTL
+	if err := (&bindZoneImporter{}).importContent(path, content, &importState{origin: zoneName}, &records, &zoneTTL, 0); err != nil {
+		return nil, err
+	}
+
+	diffRecords := make([]*diffRecord, 0, len(records))
+	for _, record := range records {
+		class := record.class
+		if class == "" {
+			class = models.INTERNET
+		}
+		ttl := ""
+		if record.ttl != nil {
+			ttl = fmt.Sprintf("%d ", *record.ttl)
+		}
+
+		domainNameFields := record.rrType.DomainNameFields()
+		keyData := make([]string, len(record.rdata))
+		displayData := make([]string, len(record.rdata))
+		for i, token := range record.rdata {
+			text := token.text
+			if token.quoted {
+				text = `"` + text + `"`
+			}
+			displayData[i] = text
+
+			switch {
+			case record.rrType == models.SOA && i == 2:
+				// The serial number changes on every change, it isn't a difference in its own right
+				keyData[i] = ""
+			case record.rrType == models.SOA && i > 2:
+				// The time intervals are the same whether or not they use units
+				keyData[i] = models.TimeIntervalInSeconds(text)
+			case slices.Contains(domainNameFields, i):
+				keyData[i] = strings.ToLower(text)
+			default:
+				keyData[i] = text
+			}
+		}
+
+		rrset := fmt.Sprintf("%s %s %s", strings.ToLower(record.name), class, record.rrType)
+		diffRecords = append(diffRecords, &diffRecord{
+			rrset:   rrset,
+			key:     ttl + strings.Join(keyData, " "),
+			display: fmt.Sprintf("%s %s%s %s %s", record.name, ttl, class, record.rrType, strings.Join(displayData, " ")),
+		})
+	}
+	return diffRecords, nil
+}
+
+// Compares the records RRset by RRset, when an RRset has exactly one record on each side that differ, it's reported
+// as a change, otherwise the records only found on one side are reported as removed or added
+func diffRRsets(existing []*diffRecord, generated []*diffRecord) []*RecordChange {
+	existingByRRset := groupByRRset(existing)
+	generatedByRRset := groupByRRset(generated)
+
+	var rrsets []string
+	for rrset := range existingByRRset {
+		rrsets = append(rrsets, rrset)
+	}
+	for rrset := range generatedByRRset {
+		if _, ok := existingByRRset[rrset]; !ok {
+			rrsets = append(rrsets, rrset)
+		}
+	}
+	sort.Strings(rrsets)
+
+	var changes []*RecordChange
+	for _, rrset := range rrsets {
+		removed := withoutKeys(existingByRRset[rrset], generatedByRRset[rrset])
+		added := withoutKeys(generatedByRRset[rrset], existingByRRset[rrset])
+
+		if len(removed) == 1 && len(added) == 1 {
+			changes = append(changes, &RecordChange{Type: RecordChanged, Old: removed[0].display, New: added[0].display})
+			continue
+		}
+		for _, r := range removed {
+			changes = append(changes, &RecordChange{Type: RecordRemoved, Old: r.display})
+		}
+		for _, r := range added {
+			changes = append(changes, &RecordChange{Type: RecordAdded, New: r.display})
+		}
+	}
+	return changes
+}
+
+func groupByRRset(records []*diffRecord) map[string][]*diffRecord {
+	grouped := make(map[string][]*diffRecord)
+	for _, r := range records {
+		grouped[r.rrset] = append(grouped[r.rrset], r)
+	}
+	return grouped
+}
+
+// Returns the records whose key isn't found in other, sorted by their key so the output is stable
+func withoutKeys(records []*diffRecord, other []*diffRecord) []*diffRecord {
+	var result []*diffRecord
+	for _, r := range records {
+		if !slices.ContainsFunc(other, func(o *diffRecord) bool { return o.key == r.key }) {
+			result = append(result, r)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].key < result[j].key })
+	return result
+}
@@ -0,0 +1,214 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bcurnow/zonemgr/utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+const existingZoneFile = `$ORIGIN example.com.
+$TTL 3600
+example.com. SOA ( ns1.example.com. admin.example.com.
+                   2025080301 ; serial
+                   3600 900 604800 300 )
+@      NS   ns1.example.com.
+ns1    A    192.0.2.1
+www    300 IN A 192.0.2.10
+mail   MX   10 mail.example.com.
+multi  A    192.0.2.20
+multi  A    192.0.2.21
+old    A    192.0.2.30
+`
+
+func TestMasterFileZoneDiffer(t *testing.T) {
+	res1 := MasterFileZoneDiffer()
+	res2 := MasterFileZoneDiffer()
+
+	if res1 == res2 {
+		t.Errorf("expected a new instance on each call, got same instance")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	fs = &utils.FileSystem{}
+	path := filepath.Join(t.TempDir(), "example.com.")
+	if err := os.WriteFile(path, []byte(existingZoneFile), 0600); err != nil {
+		t.Fatalf("unable to write the existing zone file: %s", err)
+	}
+
+	testCases := []struct {
+		name     string
+		rendered string
+		want     []*RecordChange
+	}{
+		{
+			// Whitespace, comments, the serial number, relative vs absolute names and name case don't matter
+			name: "no-changes",
+			rendered: `$ORIGIN example.com.
+$TTL 3600 ;one hour
+example.com.                             SOA    (
+                                                    ns1.example.com.
+                                                    admin.example.com.
+                                                    2025080399 ;Zonemgr generated serial number
+                                                    3600
+                                                    900
+                                                    604800
+                                                    300
+                                                )
+@                                        NS     NS1.example.com.
+ns1.example.com.                         A      192.0.2.1 ;a comment
+www                                      300 IN A      192.0.2.10
+mail                                     MX     10 mail
+multi                                    A      192.0.2.21
+multi                                    A      192.0.2.20
+old                                      A      192.0.2.30
+`,
+		},
+		{
+			// Time intervals with units are the same as the number of seconds
+			name: "time-units",
+			rendered: `$ORIGIN example.com.
+$TTL 1h
+example.com.                             SOA    (
+                                                    ns1.example.com.
+                                                    admin.example.com.
+                                                    2025080399
+                                                    1h
+                                                    15m
+                                                    1w
+                                                    5m
+                                                )
+@                                        NS     ns1.example.com.
+ns1                                      A      192.0.2.1
+www                                      5m IN A 192.0.2.10
+mail                                     MX     10 mail
+multi                                    A      192.0.2.20
+multi                                    A      192.0.2.21
+old                                      A      192.0.2.30
+`,
+		},
+		{
+			name: "changes",
+			rendered: `$ORIGIN example.com.
+$TTL 3600
+example.com. SOA ns1.example.com. admin.example.com. 1 7200 900 604800 300
+@      NS   ns1.example.com.
+ns1    A    192.0.2.2
+www    600 IN A 192.0.2.10
+mail   MX   10 mail.example.com.
+multi  A    192.0.2.20
+multi  A    192.0.2.22
+multi  A    192.0.2.23
+new    A    192.0.2.40
+`,
+			want: []*RecordChange{
+				{Type: RecordChanged, Old: "example.com. 3600 IN SOA ns1.example.com. admin.example.com. 2025080301 3600 900 604800 300", New: "example.com. 3600 IN SOA ns1.example.com. admin.example.com. 1 7200 900 604800 300"},
+				{Type: RecordRemoved, Old: "multi.example.com. 3600 IN A 192.0.2.21"},
+				{Type: RecordAdded, New: "multi.example.com. 3600 IN A 192.0.2.22"},
+				{Type: RecordAdded, New: "multi.example.com. 3600 IN A 192.0.2.23"},
+				{Type: RecordAdded, New: "new.example.com. 3600 IN A 192.0.2.40"},
+				{Type: RecordChanged, Old: "ns1.example.com. 3600 IN A 192.0.2.1", New: "ns1.example.com. 3600 IN A 192.0.2.2"},
+				{Type: RecordRemoved, Old: "old.example.com. 3600 IN A 192.0.2.30"},
+				{Type: RecordChanged, Old: "www.example.com. 300 IN A 192.0.2.10", New: "www.example.com. 600 IN A 192.0.2.10"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		changes, err := MasterFileZoneDiffer().Diff("example.com.", path, []byte(tc.rendered))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.name, err)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, changes); diff != "" {
+			t.Errorf("%s: incorrect changes (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestDiff_NoExistingFile(t *testing.T) {
+	fs = &utils.FileSystem{}
+	changes, err := MasterFileZoneDiffer().Diff("example.com.", filepath.Join(t.TempDir(), "missing"), []byte("$ORIGIN example.com.\nwww A 192.0.2.1\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []*RecordChange{{Type: RecordAdded, New: "www.example.com. IN A 192.0.2.1"}}
+	if diff := cmp.Diff(want, changes); diff != "" {
+		t.Errorf("incorrect changes (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiff_Errors(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+	defer func() { readFile = os.ReadFile }()
+
+	testCases := []struct {
+		name     string
+		readErr  bool
+		existing string
+		rendered string
+		want     string
+	}{
+		{name: "read", readErr: true, want: "failed to open 'testing': readErr"},
+		{name: "existing", existing: "www A (\n", want: "testing: line 2: unbalanced '(', missing ')'"},
+		{name: "rendered", existing: "www A 192.0.2.1\n", rendered: "www\n", want: "example.com.:1: resource record for 'www.example.com.' is missing a type"},
+	}
+
+	for _, tc := range testCases {
+		mockFs.EXPECT().Exists("testing").Return(true)
+		readFile = func(_ string) ([]byte, error) {
+			if tc.readErr {
+				return nil, errors.New("readErr")
+			}
+			return []byte(tc.existing), nil
+		}
+
+		_, err := MasterFileZoneDiffer().Diff("example.com.", "testing", []byte(tc.rendered))
+		if err == nil || err.Error() != tc.want {
+			t.Errorf("%s: incorrect error: '%v', want: '%s'", tc.name, err, tc.want)
+		}
+	}
+	fs = &utils.FileSystem{}
+}
+
+func TestString_RecordChange(t *testing.T) {
+	testCases := []struct {
+		change *RecordChange
+		want   string
+	}{
+		{&RecordChange{Type: RecordAdded, New: "new"}, "+ new"},
+		{&RecordChange{Type: RecordRemoved, Old: "old"}, "- old"},
+		{&RecordChange{Type: RecordChanged, Old: "old", New: "new"}, "~ old\n  => new"},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.change.String(); got != tc.want {
+			t.Errorf("incorrect string: %q, want: %q", got, tc.want)
+		}
+	}
+}
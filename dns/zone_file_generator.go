@@ -27,10 +27,14 @@ import (
 
 	"github.com/bcurnow/zonemgr/models"
 	"github.com/bcurnow/zonemgr/plugins"
+	"github.com/bcurnow/zonemgr/utils"
 )
 
 type ZoneFileGenerator interface {
-	GenerateZone(name string, zone *models.Zone, outputDir string) error
+	// Renders the zone and stages the zone file in the transaction, the zone file is only written when the transaction is committed
+	GenerateZone(name string, zone *models.Zone, outputDir string, tx utils.FileTransaction) error
+	// Renders the zone file content without writing anything
+	RenderZone(name string, zone *models.Zone) ([]byte, error)
 }
 type pluginZoneFileGenerator struct {
 	ZoneFileGenerator
@@ -42,18 +46,31 @@ func PluginZoneFileGenerator(plugins map[plugins.Type]plugins.ZoneMgrPlugin, met
 	return &pluginZoneFileGenerator{plugins: plugins, metadata: metadata}
 }
 
-func (zfg *pluginZoneFileGenerator) GenerateZone(name string, zone *models.Zone, outputDir string) error {
-	outputFileName := filepath.Join(outputDir, name)
-	rel, err := filepath.Rel(outputDir, outputFileName)
-	if err != nil || strings.HasPrefix(rel, "..") {
-		return fmt.Errorf("zone name %q resolves outside output directory", name)
+func (zfg *pluginZoneFileGenerator) GenerateZone(name string, zone *models.Zone, outputDir string, tx utils.FileTransaction) error {
+	outputFileName, err := ZoneFilePath(name, outputDir)
+	if err != nil {
+		return err
 	}
-	return fs.CreateFile(outputFileName, 0640, func() ([]byte, error) {
+	return tx.StageFile(outputFileName, 0640, func() ([]byte, error) {
 		logger().Info("generating zone file", "outputFile", outputFileName, "zone", name)
 		return zfg.generate(name, zone)
 	})
 }
 
+func (zfg *pluginZoneFileGenerator) RenderZone(name string, zone *models.Zone) ([]byte, error) {
+	return zfg.generate(name, zone)
+}
+
+// Returns the path of the zone file for the zone in outputDir, the zone name must not resolve outside of outputDir
+func ZoneFilePath(name string, outputDir string) (string, error) {
+	outputFileName := filepath.Join(outputDir, name)
+	rel, err := filepath.Rel(outputDir, outputFileName)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("zone name %q resolves outside output directory", name)
+	}
+	return outputFileName, nil
+}
+
 func (zfg *pluginZoneFileGenerator) generate(name string, zone *models.Zone) ([]byte, error) {
 	var content bytes.Buffer
 	// Write out the origin
@@ -70,7 +87,24 @@ func (zfg *pluginZoneFileGenerator) generate(name string, zone *models.Zone) ([]
 		return nil, err
 	}
 
-	if err := zone.WithSortedResourceRecords(func(identifier string, rr *models.ResourceRecord) error {
+	var order models.RecordOrder
+	if zone.Config != nil {
+		order = zone.Config.RecordOrder
+	}
+
+	// The records expanded from a generate that is rendered as a directive are rendered as the directive instead, where
+	// the first of them would have been
+	directives := make(map[*models.ResourceRecord]bool)
+	if err := zone.WithRenderOrderedResourceRecords(name, order, func(identifier string, rr *models.ResourceRecord) error {
+		if generatedFrom := zone.GeneratedFrom(identifier); generatedFrom != nil && generatedFrom.Generate.Directive {
+			if !directives[generatedFrom] {
+				directives[generatedFrom] = true
+				content.WriteString(renderGenerateDirective(generatedFrom, rr))
+				content.WriteString("\n")
+			}
+			return nil
+		}
+
 		// We're takiing advantage of the fact that we have plugin types that match standard resource record types
 		// so we can cast directly
 		plugin := zfg.plugins[plugins.Type(rr.Type)]
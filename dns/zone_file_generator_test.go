@@ -21,11 +21,15 @@ package dns
 import (
 	"errors"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/bcurnow/zonemgr/internal/plugins/builtin"
 	"github.com/bcurnow/zonemgr/models"
 	"github.com/bcurnow/zonemgr/plugins"
 	"github.com/bcurnow/zonemgr/utils"
+	"go.uber.org/mock/gomock"
 )
 
 func TestPluginZoneFileGenerator(t *testing.T) {
@@ -43,24 +47,57 @@ func TestPluginZoneFileGenerator(t *testing.T) {
 func TestGenerateZone(t *testing.T) {
 	dnsSetup(t)
 	defer dnsTeardown(t)
-	// We want to use the actual implementation for this test
-	fs = &utils.FileSystem{}
 	mockAPlugin.EXPECT().Configure(testZone.Config)
 	mockCNAMEPlugin.EXPECT().Configure(testZone.Config)
 	mockAPlugin.EXPECT().Render("record1", &models.ResourceRecord{Type: models.A, Value: "1.2.3.4"}).Return("record1", nil)
 	mockCNAMEPlugin.EXPECT().Render("record2", &models.ResourceRecord{Type: models.CNAME, Value: "record1"}).Return("record2", nil)
 
-	if err := PluginZoneFileGenerator(mockPlugins, mockMetadata).GenerateZone("testing", testZone, "."); err != nil {
-		t.Errorf("unexpected error: %s", err)
+	outputDir := t.TempDir()
+	// We want to use the actual implementation for this test
+	tx := (&utils.FileSystem{}).NewTransaction()
+	if err := PluginZoneFileGenerator(mockPlugins, mockMetadata).GenerateZone("testing", testZone, outputDir, tx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Nothing is written until the transaction is committed
+	if _, err := os.Stat(filepath.Join(outputDir, "testing")); !os.IsNotExist(err) {
+		t.Errorf("expected the zone file to not exist before the commit, stat returned: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "testing"))
+	if err != nil {
+		t.Fatalf("unable to read the zone file: %s", err)
+	}
+	if want := "$ORIGIN testing\n$TTL 30 ;testZone-TTL\nrecord1\nrecord2\n"; string(content) != want {
+		t.Errorf("incorrect zone file: %q, want: %q", content, want)
+	}
+}
+
+func TestGenerateZone_RenderError(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	mockTx := utils.NewMockFileTransaction(mockController)
+	mockTx.EXPECT().StageFile(filepath.Join("out", "testing"), os.FileMode(0640), gomock.Any()).DoAndReturn(func(_ string, _ os.FileMode, contentFn func() ([]byte, error)) error {
+		_, err := contentFn()
+		return err
+	})
+	mockAPlugin.EXPECT().Configure(testZone.Config).Return(errors.New("configureErr"))
+
+	err := PluginZoneFileGenerator(mockPlugins, mockMetadata).GenerateZone("testing", testZone, "out", mockTx)
+	if err == nil || err.Error() != "configureErr" {
+		t.Errorf("incorrect error: '%v', want: 'configureErr'", err)
 	}
-	defer os.Remove("./testing")
 }
 
 func TestGenerateZone_PathTraversal(t *testing.T) {
 	dnsSetup(t)
 	defer dnsTeardown(t)
 
-	err := PluginZoneFileGenerator(mockPlugins, mockMetadata).GenerateZone("../../etc/passwd", testZone, ".")
+	err := PluginZoneFileGenerator(mockPlugins, mockMetadata).GenerateZone("../../etc/passwd", testZone, ".", nil)
 	if err == nil {
 		t.Fatal("expected an error for path traversal, found none")
 	}
@@ -70,6 +107,47 @@ func TestGenerateZone_PathTraversal(t *testing.T) {
 	}
 }
 
+func TestRenderZone(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+	mockAPlugin.EXPECT().Configure(testZone.Config)
+	mockCNAMEPlugin.EXPECT().Configure(testZone.Config)
+	mockAPlugin.EXPECT().Render("record1", &models.ResourceRecord{Type: models.A, Value: "1.2.3.4"}).Return("record1", nil)
+	mockCNAMEPlugin.EXPECT().Render("record2", &models.ResourceRecord{Type: models.CNAME, Value: "record1"}).Return("record2", nil)
+
+	content, err := PluginZoneFileGenerator(mockPlugins, mockMetadata).RenderZone("testing", testZone)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "$ORIGIN testing\n$TTL 30 ;testZone-TTL\nrecord1\nrecord2\n"; string(content) != want {
+		t.Errorf("incorrect zone file: %q, want: %q", content, want)
+	}
+}
+
+func TestZoneFilePath(t *testing.T) {
+	testCases := []struct {
+		name string
+		want string
+		err  string
+	}{
+		{name: "example.com.", want: filepath.Join("out", "example.com.")},
+		{name: "../example.com.", err: `zone name "../example.com." resolves outside output directory`},
+	}
+
+	for _, tc := range testCases {
+		path, err := ZoneFilePath(tc.name, "out")
+		if err != nil {
+			if err.Error() != tc.err {
+				t.Errorf("%s: incorrect error: '%s', want: '%s'", tc.name, err, tc.err)
+			}
+			continue
+		}
+		if path != tc.want {
+			t.Errorf("%s: incorrect path: '%s', want: '%s'", tc.name, path, tc.want)
+		}
+	}
+}
+
 func TestGenerate_MissingPluginMetadata(t *testing.T) {
 	dnsSetup(t)
 	defer dnsTeardown(t)
@@ -176,3 +254,98 @@ func TestGenerate_WithTTL(t *testing.T) {
 		t.Errorf("unexpected content:\n'%s'\nwant\n'%s'\n", string(content), want)
 	}
 }
+
+func TestGenerate_RecordOrder(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	g := &pluginZoneFileGenerator{
+		plugins: map[plugins.Type]plugins.ZoneMgrPlugin{
+			plugins.A:  &builtin.BuiltinPluginA{},
+			plugins.NS: &builtin.BuiltinPluginNS{},
+			plugins.MX: &builtin.BuiltinPluginMX{},
+		},
+		metadata: map[plugins.Type]*plugins.Metadata{
+			plugins.A:  {Name: string(plugins.A), Command: "Built In", BuiltIn: true},
+			plugins.NS: {Name: string(plugins.NS), Command: "Built In", BuiltIn: true},
+			plugins.MX: {Name: string(plugins.MX), Command: "Built In", BuiltIn: true},
+		},
+	}
+
+	testCases := []struct {
+		order models.RecordOrder
+		want  []string
+	}{
+		{order: "", want: []string{"@ NS", "a-host A", "sub NS", "@ MX", "mail A"}},
+		{order: models.RecordOrderName, want: []string{"@ NS", "@ MX", "a-host A", "mail A", "sub NS"}},
+		{order: models.RecordOrderType, want: []string{"@ NS", "a-host A", "mail A", "@ MX", "sub NS"}},
+	}
+
+	for _, tc := range testCases {
+		zone := &models.Zone{
+			Config: &models.Config{RecordOrder: tc.order},
+			ResourceRecords: map[string]*models.ResourceRecord{
+				"a-host": {Name: "a-host", Type: models.A, Value: "1.2.3.4"},
+				"b-sub":  {Name: "sub", Type: models.NS, Value: "ns.sub.example.com."},
+				"c-mx":   {Name: "@", Type: models.MX, Value: "10 mail.example.com."},
+				"mail":   {Name: "mail", Type: models.A, Value: "1.2.3.5"},
+				"z-ns":   {Name: "@", Type: models.NS, Value: "ns.example.com."},
+			},
+		}
+
+		content, err := g.generate("example.com.", zone)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		var got []string
+		for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n")[1:] {
+			fields := strings.Fields(line)
+			got = append(got, fields[0]+" "+fields[1])
+		}
+
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("incorrect order for '%s': %v, want %v", tc.order, got, tc.want)
+		}
+	}
+}
+
+func TestGenerate_GenerateDirective(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	g := &pluginZoneFileGenerator{
+		plugins:  map[plugins.Type]plugins.ZoneMgrPlugin{plugins.A: &builtin.BuiltinPluginA{}},
+		metadata: map[plugins.Type]*plugins.Metadata{plugins.A: {Name: string(plugins.A), Command: "Built In", BuiltIn: true}},
+	}
+
+	zone := &models.Zone{
+		Config: &models.Config{RecordOrder: models.RecordOrderName},
+		ResourceRecords: map[string]*models.ResourceRecord{
+			"hosts": {Name: "host-$", Type: models.A, Value: "192.0.2.$", Generate: &models.Generate{Range: "1-2", Directive: true}},
+			"pool":  {Name: "pool-$", Type: models.A, Value: "192.0.2.1$", Generate: &models.Generate{Range: "1-2"}},
+			"www":   {Name: "www", Type: models.A, Value: "192.0.2.100"},
+		},
+	}
+	if errs := expandGenerateRecords(map[string]*models.Zone{"example.com.": zone}); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %s", errs)
+	}
+	// The records are normalized before they're rendered
+	for _, rr := range zone.ResourceRecords {
+		rr.Class = models.INTERNET
+	}
+
+	content, err := g.generate("example.com.", zone)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n")[1:] {
+		got = append(got, strings.Join(strings.Fields(line), " "))
+	}
+	want := []string{"$GENERATE 1-2 host-$ IN A 192.0.2.$", "pool-1 IN A 192.0.2.11", "pool-2 IN A 192.0.2.12", "www IN A 192.0.2.100"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("incorrect content: %v, want %v", got, want)
+	}
+}
@@ -21,6 +21,8 @@ package dns
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/bcurnow/zonemgr/models"
 	"github.com/bcurnow/zonemgr/plugins"
@@ -41,48 +43,219 @@ func Reverser() ZoneReverser {
 	return &zoneReverser{}
 }
 
+// An A or AAAA record that a PTR record can point at
+type ptrCandidate struct {
+	identifier string
+	ip         utils.IP
+	rr         *models.ResourceRecord
+	target     string
+}
+
 func (zr *zoneReverser) ReverseZone(sourceZoneName string, zone *models.Zone) (map[string]*models.Zone, error) {
 	reverseLookupZones := make(map[string]*models.Zone)
 
-	for _, rr := range zone.ResourceRecords {
+	// The records are grouped by address so the PTR records of an address used by more than one record can be chosen
+	var addresses []string
+	candidatesByAddress := make(map[string][]*ptrCandidate)
+	if err := zone.WithSortedResourceRecords(func(identifier string, rr *models.ResourceRecord) error {
 		// We only care about A and AAAA records as they're the ones we're trying to reverse
-		if rr.Type == models.A || rr.Type == models.AAAA {
-			ip, err := utils.ParseIP(rr.Value)
-			if err != nil {
-				return nil, fmt.Errorf("invalid IP address in %s record %q: %w", rr.Type, rr.Name, err)
+		if rr.Type != models.A && rr.Type != models.AAAA {
+			return nil
+		}
+
+		if rr.Reverse != nil && !*rr.Reverse {
+			logger().Trace("skipping record that opted out of reverse lookup", "identifier", identifier, "name", rr.Name, "type", rr.Type)
+			return nil
+		}
+
+		// A wildcard isn't a name that can be pointed at, there's nothing to reverse unless a name is given
+		if rr.IsWildcard() && rr.PTRName == "" {
+			logger().Trace("skipping wildcard record for reverse lookup", "name", rr.Name, "type", rr.Type)
+			return nil
+		}
+
+		ip, err := utils.ParseIP(rr.Value)
+		if err != nil {
+			return fmt.Errorf("invalid IP address in %s record %q: %w", rr.Type, rr.Name, err)
+		}
+
+		address := ip.StringExpanded()
+		if _, ok := candidatesByAddress[address]; !ok {
+			addresses = append(addresses, address)
+		}
+		candidatesByAddress[address] = append(candidatesByAddress[address], &ptrCandidate{identifier: identifier, ip: ip, rr: rr, target: ptrTarget(sourceZoneName, rr)})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	policy := models.PTRPolicyError
+	var configuredZones []string
+	if zone.Config != nil {
+		if zone.Config.PTRPolicy != "" {
+			policy = zone.Config.PTRPolicy
+		}
+		configuredZones = zone.Config.ReverseZones
+	}
+
+	reverseZones, err := parseReverseZones(configuredZones)
+	if err != nil {
+		return nil, fmt.Errorf("zone '%s': %w", sourceZoneName, err)
+	}
+
+	// The classless zones whose parent zone points at them
+	delegated := make(map[string]bool)
+	for _, address := range addresses {
+		chosen, err := choosePTRCandidates(policy, candidatesByAddress[address])
+		if err != nil {
+			return nil, fmt.Errorf("zone '%s': %w", sourceZoneName, err)
+		}
+
+		rz := reverseZoneOf(reverseZones, chosen[0].ip)
+		reverseZone, ok := reverseLookupZones[rz.Name()]
+		if !ok {
+			reverseZone = newReverseLookupZone(rz.Name(), zone)
+			reverseLookupZones[rz.Name()] = reverseZone
+			if rz.IsClassless() {
+				delegated[rz.Name()] = delegateClasslessZone(sourceZoneName, zone, rz, reverseZone, reverseLookupZones)
 			}
-			zoneName := ip.ReverseZoneName()
-			reverseZone, ok := reverseLookupZones[zoneName]
-			if !ok {
-				reverseZone = &models.Zone{
-					Config:          zone.Config,
-					ResourceRecords: make(map[string]*models.ResourceRecord),
-					TTL:             zone.TTL,
-				}
-
-				// Add the SOA record for the zone
-				sourceSOA := zone.SOARecord()
-				reverseZone.ResourceRecords[zoneName] = &models.ResourceRecord{
-					// Copy the values from the SOA record in the source zone
-					Name:    zoneName,
-					Type:    models.SOA,
-					Class:   sourceSOA.Class,
-					TTL:     sourceSOA.TTL,
-					Values:  copyResourceRecordValues(sourceSOA.Values),
-					Value:   sourceSOA.Value,
-					Comment: sourceSOA.Comment,
-				}
-				reverseLookupZones[zoneName] = reverseZone
+		}
+
+		// A classless zone only resolves when its parent zone points each address at it (RFC 2317)
+		if delegated[rz.Name()] {
+			name, target := rz.ClasslessCNAME(chosen[0].ip)
+			reverseLookupZones[rz.ParentName()].ResourceRecords[name] = &models.ResourceRecord{
+				Name:   name,
+				Type:   models.CNAME,
+				Class:  zone.SOARecord().Class,
+				Values: []*models.ResourceRecordValue{},
+				Value:  target,
 			}
+		}
 
-			ptr := zr.toPTR(sourceZoneName, ip, rr)
-			reverseZone.ResourceRecords[ptr.Name] = ptr
+		for i, candidate := range chosen {
+			ptr := zr.toPTR(sourceZoneName, rz.PTRRecordName(candidate.ip), candidate.rr)
+			// Every PTR record of an address has the same name, only the first can use it as its identifier
+			identifier := ptr.Name
+			if i > 0 {
+				identifier = ptr.Name + " " + ptr.Value
+			}
+			reverseZone.ResourceRecords[identifier] = ptr
 		}
 	}
 
 	return reverseLookupZones, nil
 }
 
+// Parses the configured reverse zones, the most specific zones come first so the first zone that contains an address
+// is the one it belongs to
+func parseReverseZones(configuredZones []string) ([]utils.ReverseZone, error) {
+	reverseZones := make([]utils.ReverseZone, 0, len(configuredZones))
+	for _, configuredZone := range configuredZones {
+		rz, err := utils.ParseReverseZone(configuredZone)
+		if err != nil {
+			return nil, err
+		}
+		reverseZones = append(reverseZones, rz)
+	}
+	slices.SortStableFunc(reverseZones, func(a, b utils.ReverseZone) int { return b.Bits() - a.Bits() })
+	return reverseZones, nil
+}
+
+// The most specific configured zone that contains ip, the default zone if none of them do
+func reverseZoneOf(reverseZones []utils.ReverseZone, ip utils.IP) utils.ReverseZone {
+	for _, rz := range reverseZones {
+		if rz.Contains(ip) {
+			return rz
+		}
+	}
+	return utils.DefaultReverseZone(ip)
+}
+
+// Creates an empty reverse lookup zone with an SOA record copied from the source zone
+func newReverseLookupZone(zoneName string, zone *models.Zone) *models.Zone {
+	reverseZone := &models.Zone{
+		Config:          zone.Config,
+		ResourceRecords: make(map[string]*models.ResourceRecord),
+		TTL:             zone.TTL,
+	}
+
+	sourceSOA := zone.SOARecord()
+	reverseZone.ResourceRecords[zoneName] = &models.ResourceRecord{
+		// Copy the values from the SOA record in the source zone
+		Name:    zoneName,
+		Type:    models.SOA,
+		Class:   sourceSOA.Class,
+		TTL:     sourceSOA.TTL,
+		Values:  copyResourceRecordValues(sourceSOA.Values),
+		Value:   sourceSOA.Value,
+		Comment: sourceSOA.Comment,
+	}
+	return reverseZone
+}
+
+// The name servers of the source zone serve the classless zone and its parent zone delegates the classless zone to them
+// (RFC 2317), the CNAME records are added to the parent zone as the addresses are reversed. Without name servers there's
+// nothing to delegate to so the parent zone isn't generated, returns whether the classless zone was delegated.
+func delegateClasslessZone(sourceZoneName string, zone *models.Zone, rz utils.ReverseZone, classlessZone *models.Zone, reverseLookupZones map[string]*models.Zone) bool {
+	nameServers := recordsAt(sourceZoneName, zone, models.AbsoluteName("@", sourceZoneName), models.NS)
+	if len(nameServers) == 0 {
+		logger().Warn("the parent zone of a classless reverse lookup zone isn't generated, the zone doesn't have any NS records to delegate it to", "zoneName", sourceZoneName, "reverseZoneName", rz.Name(), "parentZoneName", rz.ParentName())
+		return false
+	}
+
+	for _, nameServer := range nameServers {
+		ns := nameServer.rr.Clone()
+		ns.Name = rz.Name()
+		ns.Values = []*models.ResourceRecordValue{}
+		ns.Value = models.AbsoluteName(nameServer.rr.RetrieveSingleValue(), sourceZoneName)
+		classlessZone.ResourceRecords[fmt.Sprintf("%s %s %s", ns.Name, ns.Type, ns.Value)] = ns
+	}
+
+	parentZone, ok := reverseLookupZones[rz.ParentName()]
+	if !ok {
+		parentZone = newReverseLookupZone(rz.ParentName(), zone)
+		reverseLookupZones[rz.ParentName()] = parentZone
+	}
+	addDelegationRecords(rz.ParentName(), parentZone, recordsAt(rz.Name(), classlessZone, rz.Name(), models.NS))
+	return true
+}
+
+// Chooses the records that get a PTR record for an address. When any of the records set reverse to true, only those
+// records are considered. Records that point at the same name only need a single PTR record, if there is more than
+// one name left the policy decides.
+func choosePTRCandidates(policy models.PTRPolicy, candidates []*ptrCandidate) ([]*ptrCandidate, error) {
+	var canonical []*ptrCandidate
+	for _, candidate := range candidates {
+		if candidate.rr.Reverse != nil && *candidate.rr.Reverse {
+			canonical = append(canonical, candidate)
+		}
+	}
+	if len(canonical) > 0 {
+		candidates = canonical
+	}
+
+	var distinct []*ptrCandidate
+	for _, candidate := range candidates {
+		if !slices.ContainsFunc(distinct, func(d *ptrCandidate) bool { return strings.EqualFold(d.target, candidate.target) }) {
+			distinct = append(distinct, candidate)
+		}
+	}
+
+	switch {
+	case len(distinct) == 1 || policy == models.PTRPolicyAll:
+		return distinct, nil
+	case policy == models.PTRPolicyFirst:
+		return distinct[:1], nil
+	default:
+		identifiers := make([]string, len(distinct))
+		for i, candidate := range distinct {
+			identifiers[i] = "'" + candidate.identifier + "'"
+		}
+		return nil, fmt.Errorf("address %s is used by more than one record (%s), set reverse to true on the record the PTR record should point at, reverse to false on the others or set the ptr_policy of the zone to first or all", distinct[0].ip, strings.Join(identifiers, ", "))
+	}
+}
+
 // copyResourceRecordValues returns a deep copy of values so a reverse zone's SOA record doesn't share
 // backing storage with the source zone's SOA record; normalizing one would otherwise mutate the other.
 func copyResourceRecordValues(values []*models.ResourceRecordValue) []*models.ResourceRecordValue {
@@ -97,21 +270,33 @@ func copyResourceRecordValues(values []*models.ResourceRecordValue) []*models.Re
 	return copied
 }
 
-func (zr *zoneReverser) toPTR(sourceZoneName string, ip utils.IP, rr *models.ResourceRecord) *models.ResourceRecord {
-	// The PTR record must be fully qualified
-	ptrName := rr.Name
-	if err := validations.EnsureFullyQualified("generated record", ptrName, rr.Type); err != nil {
-		ptrName = validations.EnsureTrailingDot(ptrName + "." + sourceZoneName)
-	}
-
+// Creates the PTR record named name (relative to the reverse lookup zone) that points at rr
+func (zr *zoneReverser) toPTR(sourceZoneName string, name string, rr *models.ResourceRecord) *models.ResourceRecord {
 	return &models.ResourceRecord{
-		Name:   ip.PTRRecordValue(),
+		Name:   name,
 		Type:   models.PTR,
 		Class:  rr.Class,
 		TTL:    rr.TTL,
 		Values: []*models.ResourceRecordValue{},
 		// Each value must be fully qualified
-		Value:   ptrName,
+		Value:   ptrTarget(sourceZoneName, rr),
 		Comment: rr.Comment,
 	}
 }
+
+// The fully qualified name the PTR record of rr points at, the name of the record unless ptr_name is set
+func ptrTarget(sourceZoneName string, rr *models.ResourceRecord) string {
+	name := rr.Name
+	if rr.PTRName != "" {
+		name = rr.PTRName
+	}
+
+	if name == "@" {
+		return validations.EnsureTrailingDot(sourceZoneName)
+	}
+	// The PTR record must be fully qualified
+	if err := validations.EnsureFullyQualified("generated record", name, rr.Type); err != nil {
+		return validations.EnsureTrailingDot(name + "." + sourceZoneName)
+	}
+	return name
+}
@@ -63,10 +63,10 @@ func TestToPTR(t *testing.T) {
 		if err != nil {
 			t.Fatalf("failed to parse test IP %q: %v", tc.rr.Value, err)
 		}
-		ptr := (&zoneReverser{}).toPTR("example.com", ip, tc.rr)
+		ptr := (&zoneReverser{}).toPTR("example.com", ip.PTRRecordValue(), tc.rr)
 
-		if !cmp.Equal(ptr, tc.want) {
-			t.Errorf("unexpected result for %s:\n%s", tc.name, cmp.Diff(ptr, tc.want))
+		if !cmp.Equal(ptr, tc.want, cmp.AllowUnexported(models.ResourceRecord{})) {
+			t.Errorf("unexpected result for %s:\n%s", tc.name, cmp.Diff(ptr, tc.want, cmp.AllowUnexported(models.ResourceRecord{})))
 		}
 	}
 }
@@ -84,6 +84,7 @@ func TestReverseZone(t *testing.T) {
 			"record3": {Type: models.AAAA, Name: "three", Value: "fdda:5cc1:23:4::1f"},
 			"record4": {Type: models.NS, Name: "doesn't matter", Value: "also doesn't matter"},
 			"record5": {Type: models.SOA, Name: "SOA", Value: "SOA"},
+			"record6": {Type: models.A, Name: "*.apps", Value: "1.2.3.9"},
 		},
 	}
 
@@ -129,8 +130,8 @@ func TestReverseZone(t *testing.T) {
 			t.Errorf("expected to find zone '%s' but was missing", zoneName)
 		}
 
-		if !cmp.Equal(reverseZone, wantedReverseZone, cmpopts.IgnoreUnexported(models.Zone{})) {
-			t.Errorf("incorrect reverse zone:\n%s", cmp.Diff(reverseZone, wantedReverseZone, cmpopts.IgnoreUnexported(models.Zone{})))
+		if !cmp.Equal(reverseZone, wantedReverseZone, cmpopts.IgnoreUnexported(models.Zone{}, models.ResourceRecord{}, models.TTL{})) {
+			t.Errorf("incorrect reverse zone:\n%s", cmp.Diff(reverseZone, wantedReverseZone, cmpopts.IgnoreUnexported(models.Zone{}, models.ResourceRecord{}, models.TTL{})))
 		}
 	}
 }
@@ -220,3 +221,257 @@ func TestReverser(t *testing.T) {
 		t.Error("expected two different instances")
 	}
 }
+
+func TestReverseZone_SharedAddresses(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	testCases := []struct {
+		name    string
+		policy  models.PTRPolicy
+		records map[string]*models.ResourceRecord
+		want    map[string]string
+		err     string
+	}{
+		{
+			name: "opt-out",
+			records: map[string]*models.ResourceRecord{
+				"vip": {Type: models.A, Name: "vip", Value: "1.2.3.4", Reverse: toBoolPtr(false)},
+				"www": {Type: models.A, Name: "www", Value: "1.2.3.4"},
+			},
+			want: map[string]string{"4": "www.example.com."},
+		},
+		{
+			name: "ptr-name",
+			records: map[string]*models.ResourceRecord{
+				"apex":     {Type: models.A, Name: "@", Value: "1.2.3.4"},
+				"www":      {Type: models.A, Name: "www", Value: "1.2.3.5", PTRName: "host5.example.net."},
+				"wildcard": {Type: models.A, Name: "*.apps", Value: "1.2.3.6", PTRName: "apps"},
+			},
+			want: map[string]string{"4": "example.com.", "5": "host5.example.net.", "6": "apps.example.com."},
+		},
+		{
+			name: "same-target",
+			records: map[string]*models.ResourceRecord{
+				"web":   {Type: models.A, Name: "web", Value: "1.2.3.4", PTRName: "www"},
+				"www":   {Type: models.A, Name: "www", Value: "1.2.3.4"},
+				"www-2": {Type: models.A, Name: "WWW.example.com.", Value: "1.2.3.4"},
+			},
+			want: map[string]string{"4": "www.example.com."},
+		},
+		{
+			name: "canonical",
+			records: map[string]*models.ResourceRecord{
+				"mail": {Type: models.A, Name: "mail", Value: "1.2.3.4"},
+				"www":  {Type: models.A, Name: "www", Value: "1.2.3.4", Reverse: toBoolPtr(true)},
+			},
+			want: map[string]string{"4": "www.example.com."},
+		},
+		{
+			name: "error",
+			records: map[string]*models.ResourceRecord{
+				"mail": {Type: models.A, Name: "mail", Value: "1.2.3.4"},
+				"www":  {Type: models.A, Name: "www", Value: "1.2.3.4"},
+			},
+			err: "zone 'example.com.': address 1.2.3.4 is used by more than one record ('mail', 'www'), set reverse to true on the record the PTR record should point at, reverse to false on the others or set the ptr_policy of the zone to first or all",
+		},
+		{
+			name:   "more-than-one-canonical",
+			policy: models.PTRPolicyError,
+			records: map[string]*models.ResourceRecord{
+				"mail": {Type: models.A, Name: "mail", Value: "1.2.3.4", Reverse: toBoolPtr(true)},
+				"www":  {Type: models.A, Name: "www", Value: "1.2.3.4", Reverse: toBoolPtr(true)},
+				"ftp":  {Type: models.A, Name: "ftp", Value: "1.2.3.4"},
+			},
+			err: "zone 'example.com.': address 1.2.3.4 is used by more than one record ('mail', 'www'), set reverse to true on the record the PTR record should point at, reverse to false on the others or set the ptr_policy of the zone to first or all",
+		},
+		{
+			name:   "first",
+			policy: models.PTRPolicyFirst,
+			records: map[string]*models.ResourceRecord{
+				"www":  {Type: models.A, Name: "www", Value: "1.2.3.4"},
+				"mail": {Type: models.A, Name: "mail", Value: "1.2.3.4"},
+			},
+			want: map[string]string{"4": "mail.example.com."},
+		},
+		{
+			name:   "all",
+			policy: models.PTRPolicyAll,
+			records: map[string]*models.ResourceRecord{
+				"www":  {Type: models.A, Name: "www", Value: "1.2.3.4"},
+				"mail": {Type: models.A, Name: "mail", Value: "1.2.3.4"},
+			},
+			want: map[string]string{"4": "mail.example.com.", "4 www.example.com.": "www.example.com."},
+		},
+	}
+
+	for _, tc := range testCases {
+		records := map[string]*models.ResourceRecord{"soa": {Type: models.SOA, Name: "example.com.", Value: "SOA"}}
+		for identifier, rr := range tc.records {
+			records[identifier] = rr
+		}
+		zone := &models.Zone{Config: &models.Config{PTRPolicy: tc.policy}, ResourceRecords: records}
+
+		reverseZones, err := (&zoneReverser{}).ReverseZone("example.com.", zone)
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("%s - incorrect error: '%v', want: '%s'", tc.name, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s - unexpected error: %s", tc.name, err)
+			continue
+		}
+
+		got := make(map[string]string)
+		for identifier, rr := range reverseZones["3.2.1.in-addr.arpa."].ResourceRecords {
+			if rr.Type == models.PTR {
+				got[identifier] = rr.Value
+			}
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s - incorrect PTR records (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestReverseZone_ReverseZones(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	zone := &models.Zone{
+		Config: &models.Config{ReverseZones: []string{"10.0.0.0/8", "10.1.0.0/16", "192.0.2.0/30", "2001:db8::/48"}},
+		ResourceRecords: map[string]*models.ResourceRecord{
+			"soa":       {Type: models.SOA, Name: "example.com.", Class: models.INTERNET, Value: "SOA"},
+			"ns1":       {Type: models.NS, Name: "@", Value: "ns1"},
+			"ns2":       {Type: models.NS, Name: "example.com.", Values: []*models.ResourceRecordValue{{Value: "ns2.example.net."}}},
+			"internal":  {Type: models.A, Name: "internal", Value: "10.1.2.3"},
+			"legacy":    {Type: models.A, Name: "legacy", Value: "10.200.0.1"},
+			"delegated": {Type: models.A, Name: "delegated", Value: "192.0.2.1"},
+			"other":     {Type: models.A, Name: "other", Value: "192.0.2.10"},
+			"v6":        {Type: models.AAAA, Name: "v6", Value: "2001:db8:0:1::1"},
+			"default":   {Type: models.AAAA, Name: "default", Value: "2001:db9::1"},
+		},
+	}
+
+	reverseZones, err := (&zoneReverser{}).ReverseZone("example.com.", zone)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got := make(map[string]map[string]string)
+	for zoneName, reverseZone := range reverseZones {
+		got[zoneName] = make(map[string]string)
+		for identifier, rr := range reverseZone.ResourceRecords {
+			if rr.Type != models.SOA {
+				got[zoneName][identifier] = string(rr.Type) + " " + rr.Value
+			}
+		}
+		if reverseZone.ResourceRecords[zoneName] == nil || reverseZone.ResourceRecords[zoneName].Type != models.SOA {
+			t.Errorf("missing SOA record in '%s'", zoneName)
+		}
+	}
+
+	want := map[string]map[string]string{
+		"1.10.in-addr.arpa.": {"3.2": "PTR internal.example.com."},
+		"10.in-addr.arpa.":   {"1.0.200": "PTR legacy.example.com."},
+		"0-3.2.0.192.in-addr.arpa.": {
+			"0-3.2.0.192.in-addr.arpa. NS ns1.example.com.": "NS ns1.example.com.",
+			"0-3.2.0.192.in-addr.arpa. NS ns2.example.net.": "NS ns2.example.net.",
+			"1": "PTR delegated.example.com.",
+		},
+		"2.0.192.in-addr.arpa.": {
+			"0-3 NS ns1.example.com.": "NS ns1.example.com.",
+			"0-3 NS ns2.example.net.": "NS ns2.example.net.",
+			"1":                       "CNAME 1.0-3.2.0.192.in-addr.arpa.",
+			"10":                      "PTR other.example.com.",
+		},
+		"0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.":         {"1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.1.0.0.0": "PTR v6.example.com."},
+		"0.0.0.0.0.0.0.0.9.b.d.0.1.0.0.2.ip6.arpa.": {"1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0": "PTR default.example.com."},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("incorrect reverse zones (-want +got):\n%s", diff)
+	}
+}
+
+func TestReverseZone_ClasslessDelegation(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	zone := &models.Zone{
+		Config: &models.Config{ReverseZones: []string{"192.0.2.0/26"}, CNAMEPolicy: models.CNAMEPolicyStrict},
+		ResourceRecords: map[string]*models.ResourceRecord{
+			"soa":  {Type: models.SOA, Name: "example.com.", Class: models.INTERNET, Value: "SOA"},
+			"ns1":  {Type: models.NS, Name: "@", Value: "ns1"},
+			"www":  {Type: models.A, Name: "www", Value: "192.0.2.5"},
+			"mail": {Type: models.A, Name: "mail", Value: "192.0.2.6"},
+		},
+	}
+
+	reverseZones, err := (&zoneReverser{}).ReverseZone("example.com.", zone)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	parentName, childName := "2.0.192.in-addr.arpa.", "0-63.2.0.192.in-addr.arpa."
+	if reverseZones[parentName] == nil || reverseZones[childName] == nil {
+		t.Fatalf("missing parent or classless zone: %v", reverseZones)
+	}
+	if errs := checkCNAMETargets(reverseZones, nil); len(errs) > 0 {
+		t.Errorf("unexpected errors: %s", errs)
+	}
+
+	// The parent zone hands the classless zone to its name servers and each address leads to its PTR record there
+	managed := newManagedZones(reverseZones)
+	if !managed[parentName].isDelegated(childName) {
+		t.Errorf("'%s' isn't delegated from '%s'", childName, parentName)
+	}
+	for _, want := range []struct{ address, ptr string }{{"5", "www.example.com."}, {"6", "mail.example.com."}} {
+		cname := reverseZones[parentName].ResourceRecords[want.address]
+		if cname == nil || cname.Type != models.CNAME {
+			t.Errorf("missing CNAME record for '%s' in '%s'", want.address, parentName)
+			continue
+		}
+		mz := managed.zoneOf(cname.Value)
+		if mz == nil || mz.Apex() != childName {
+			t.Errorf("'%s' doesn't resolve in '%s'", cname.Value, childName)
+			continue
+		}
+		if !mz.Owns(cname.Value, models.PTR) {
+			t.Errorf("'%s' doesn't have a PTR record", cname.Value)
+		}
+		if ptr := reverseZones[childName].ResourceRecords[want.address]; ptr == nil || ptr.Value != want.ptr {
+			t.Errorf("incorrect PTR record for '%s': %v, want: '%s'", cname.Value, ptr, want.ptr)
+		}
+	}
+
+	// Without name servers there's nothing to delegate the classless zone to
+	delete(zone.ResourceRecords, "ns1")
+	reverseZones, err = (&zoneReverser{}).ReverseZone("example.com.", zone)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if reverseZones[parentName] != nil || reverseZones[childName] == nil {
+		t.Errorf("incorrect reverse zones without name servers: %v", reverseZones)
+	}
+}
+
+func TestReverseZone_InvalidReverseZones(t *testing.T) {
+	dnsSetup(t)
+	defer dnsTeardown(t)
+
+	zone := &models.Zone{
+		Config: &models.Config{ReverseZones: []string{"10.0.0.0/12"}},
+		ResourceRecords: map[string]*models.ResourceRecord{
+			"soa": {Type: models.SOA, Name: "example.com.", Value: "SOA"},
+			"www": {Type: models.A, Name: "www", Value: "10.1.2.3"},
+		},
+	}
+
+	_, err := (&zoneReverser{}).ReverseZone("example.com.", zone)
+	want := "zone 'example.com.': invalid reverse zone '10.0.0.0/12', IPv4 reverse zones must be a /8, /16, /24 or between a /25 and a /31"
+	if err == nil || err.Error() != want {
+		t.Errorf("incorrect error: '%v', want: '%s'", err, want)
+	}
+}
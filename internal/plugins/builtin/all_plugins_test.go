@@ -33,10 +33,13 @@ import (
 var allPlugins = map[plugins.Type]plugins.ZoneMgrPlugin{
 	plugins.A:     &BuiltinPluginA{},
 	plugins.AAAA:  &BuiltinPluginAAAA{},
+	plugins.CAA:   &BuiltinPluginCAA{},
 	plugins.CNAME: &BuiltinPluginCNAME{},
+	plugins.MX:    &BuiltinPluginMX{},
 	plugins.NS:    &BuiltinPluginNS{},
 	plugins.PTR:   &BuiltinPluginPTR{},
 	plugins.SOA:   &BuiltinPluginSOA{},
+	plugins.SRV:   &BuiltinPluginSRV{},
 	plugins.TXT:   &BuiltinPluginTXT{},
 }
 
@@ -76,10 +79,13 @@ func TestConfigure(t *testing.T) {
 	}{
 		plugins.A:     {plugin: &BuiltinPluginA{}, expectedConfig: nil},
 		plugins.AAAA:  {plugin: &BuiltinPluginAAAA{}, expectedConfig: nil},
+		plugins.CAA:   {plugin: &BuiltinPluginCAA{}, expectedConfig: nil},
 		plugins.CNAME: {plugin: &BuiltinPluginCNAME{}, expectedConfig: nil},
-		plugins.NS:    {plugin: &BuiltinPluginNS{}, expectedConfig: nil},
+		plugins.MX:    {plugin: &BuiltinPluginMX{}, expectedConfig: nil},
+		plugins.NS:    {plugin: &BuiltinPluginNS{}, expectedConfig: config},
 		plugins.PTR:   {plugin: &BuiltinPluginPTR{}, expectedConfig: nil},
 		plugins.SOA:   {plugin: &BuiltinPluginSOA{}, expectedConfig: config},
+		plugins.SRV:   {plugin: &BuiltinPluginSRV{}, expectedConfig: nil},
 		plugins.TXT:   {plugin: &BuiltinPluginTXT{}, expectedConfig: nil},
 	}
 
@@ -109,10 +115,9 @@ func TestConfigure(t *testing.T) {
 }
 
 func TestValidateZone(t *testing.T) {
-	// NOTE: CNAME and SOA are not in this list because they actually have a ValidateZone implementation
+	// NOTE: CAA, CNAME, MX, NS, SOA and SRV are not in this list because they actually have a ValidateZone implementation
 	pluginsToTest := map[plugins.Type]plugins.ZoneMgrPlugin{
 		plugins.A:   &BuiltinPluginA{},
-		plugins.NS:  &BuiltinPluginNS{},
 		plugins.PTR: &BuiltinPluginPTR{},
 		plugins.TXT: &BuiltinPluginTXT{},
 	}
@@ -37,6 +37,11 @@ func TestNormalize_APlugin(t *testing.T) {
 			identifier: "record1",
 			rr:         &models.ResourceRecord{Type: models.A, Name: "host.example.com", Value: "1.2.3.4"},
 		},
+		{
+			name:       "wildcard",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.A, Name: "*.apps", Value: "1.2.3.4"},
+		},
 		{
 			name:       "name-defaulting",
 			identifier: "host.example.com",
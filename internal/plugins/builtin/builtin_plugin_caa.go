@@ -0,0 +1,244 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package builtin
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/bcurnow/zonemgr/plugins"
+	"github.com/bcurnow/zonemgr/utils"
+)
+
+// The property tags defined by RFC8659, tags are matched case insensitively
+const (
+	caaTagIssue     = "issue"
+	caaTagIssueWild = "issuewild"
+	caaTagIodef     = "iodef"
+
+	// The issuer critical flag (RFC8659 4.1)
+	caaFlagCritical = 128
+)
+
+var (
+	// RFC8659 4.1, a tag is up to 15 ASCII letters and numbers
+	caaTagRegex = regexp.MustCompile(`^[A-Za-z0-9]{1,15}$`)
+	// RFC8659 4.2, the issuer-domain-name of an issue or issuewild property
+	caaIssuerDomainRegex = regexp.MustCompile(`^[A-Za-z0-9](?:-*[A-Za-z0-9])*(?:\.[A-Za-z0-9](?:-*[A-Za-z0-9])*)*$`)
+	// RFC8659 4.2, a parameter of an issue or issuewild property (e.g. account=230123)
+	caaParameterRegex = regexp.MustCompile(`^[A-Za-z0-9](?:-*[A-Za-z0-9])*[ \t]*=[ \t]*[\x21-\x3A\x3C-\x7E]*$`)
+	// The single value shortcut, e.g. 0 issue "ca.example.net; account=230123"
+	caaValueRegex = regexp.MustCompile(`^\s*(\S+)\s+(\S+)\s+(.*?)\s*$`)
+
+	// Make sure we're correctly implementing the ZonmgrPlugin interface
+	_ plugins.ZoneMgrPlugin = &BuiltinPluginCAA{}
+)
+
+type BuiltinPluginCAA struct {
+	plugins.ZoneMgrPlugin
+}
+
+func (p *BuiltinPluginCAA) PluginVersion() (string, error) {
+	return utils.Version(), nil
+}
+
+func (p *BuiltinPluginCAA) PluginTypes() ([]plugins.Type, error) {
+	return plugins.PluginTypes(plugins.CAA), nil
+}
+
+func (p *BuiltinPluginCAA) Configure(config *models.Config) error {
+	// no config
+	return nil
+}
+
+func (p *BuiltinPluginCAA) Normalize(identifier string, rr *models.ResourceRecord) error {
+	if err := validations.CommonValidations(identifier, rr, plugins.CAA); err != nil {
+		return err
+	}
+
+	if rr.Name == "" {
+		rr.Name = identifier
+	}
+
+	if err := validations.EnsureValidNameOrWildcard(identifier, rr.Name, rr.Type); err != nil {
+		return err
+	}
+
+	_, tag, value, err := caaValues(identifier, rr)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(tag) {
+	case caaTagIssue, caaTagIssueWild:
+		return ensureValidCAAIssuer(identifier, value, rr.Type)
+	case caaTagIodef:
+		return ensureValidCAAIodef(identifier, value, rr.Type)
+	}
+
+	return nil
+}
+
+// Unknown property tags are allowed (RFC8659 4.1) but they're usually a typo so they're reported as warnings
+func (p *BuiltinPluginCAA) ValidateZone(name string, zone *models.Zone) error {
+	var errs []error
+	zone.WithSortedResourceRecords(func(identifier string, rr *models.ResourceRecord) error {
+		if rr.Type != models.CAA {
+			return nil
+		}
+		flags, tag, _, err := caaValues(identifier, rr)
+		if err != nil {
+			errs = append(errs, plugins.NewRecordError(identifier, err))
+			return nil
+		}
+
+		if isKnownCAATag(tag) {
+			return nil
+		}
+
+		if isCriticalCAAFlags(flags) {
+			errs = append(errs, plugins.NewRecordWarning(identifier, fmt.Errorf("CAA record has an unknown property tag '%s' with the critical flag set, certificate authorities that don't recognize it will refuse to issue, identifier: '%s'", tag, identifier)))
+		} else {
+			errs = append(errs, plugins.NewRecordWarning(identifier, fmt.Errorf("CAA record has an unknown property tag '%s', certificate authorities that don't recognize it will ignore it, identifier: '%s'", tag, identifier)))
+		}
+		return nil
+	})
+
+	return errors.Join(errs...)
+}
+
+func (p *BuiltinPluginCAA) Render(identifier string, rr *models.ResourceRecord) (string, error) {
+	if err := validations.EnsureSupportedPluginType(identifier, rr.Type, plugins.CAA); err != nil {
+		return "", err
+	}
+
+	flags, tag, value, err := caaValues(identifier, rr)
+	if err != nil {
+		return "", err
+	}
+
+	var record strings.Builder
+	record.WriteString(rr.RenderResourceWithoutValue())
+	record.WriteString(flags)
+	record.WriteString(" ")
+	record.WriteString(tag)
+	record.WriteString(" ")
+	record.WriteString(quoteTXTValue(value))
+
+	if comment := rdataComment(rr); comment != "" {
+		record.WriteString(" ;")
+		record.WriteString(comment)
+	}
+
+	return record.String(), nil
+}
+
+// Returns the flags, tag and value of the resource record. These are either the three entries in Values or the
+// single Value shortcut written the way it would be in a zone file, in both cases the value may be quoted.
+func caaValues(identifier string, rr *models.ResourceRecord) (string, string, string, error) {
+	var fields []string
+	if len(rr.Values) > 0 {
+		var err error
+		if fields, err = rdataFields(identifier, rr, "flags", "tag", "value"); err != nil {
+			return "", "", "", err
+		}
+	} else if matches := caaValueRegex.FindStringSubmatch(rr.Value); matches != nil {
+		fields = matches[1:]
+	} else {
+		return "", "", "", fmt.Errorf("invalid %s record, must have exactly 3 values (flags, tag, value), found %d, identifier: '%s'", rr.Type, len(strings.Fields(rr.Value)), identifier)
+	}
+
+	flags, tag, value := fields[0], fields[1], fields[2]
+	if _, err := strconv.ParseUint(flags, 10, 8); err != nil {
+		return "", "", "", fmt.Errorf("invalid %s record, flags must be a number between 0 and 255: '%s', identifier: '%s'", rr.Type, flags, identifier)
+	}
+
+	if !caaTagRegex.MatchString(tag) {
+		return "", "", "", fmt.Errorf("invalid %s record, tag must be between 1 and 15 letters and numbers: '%s', identifier: '%s'", rr.Type, tag, identifier)
+	}
+
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		value = value[1 : len(value)-1]
+	}
+
+	return flags, tag, value, nil
+}
+
+// Returns true if the tag is one of the property tags defined by RFC8659
+func isKnownCAATag(tag string) bool {
+	switch strings.ToLower(tag) {
+	case caaTagIssue, caaTagIssueWild, caaTagIodef:
+		return true
+	}
+	return false
+}
+
+// Returns true if the issuer critical flag (bit 0, the most significant bit) is set (RFC8659 4.1)
+func isCriticalCAAFlags(flags string) bool {
+	value, err := strconv.ParseUint(flags, 10, 8)
+	return err == nil && value&caaFlagCritical != 0
+}
+
+// Validates the value of an issue or issuewild property, an optional issuer domain name followed by optional parameters
+// (e.g. "ca.example.net; account=230123"). An empty issuer domain name (e.g. ";") means no CA may issue (RFC8659 4.2).
+func ensureValidCAAIssuer(identifier string, value string, rrType models.ResourceRecordType) error {
+	issuer, parameters, hasParameters := strings.Cut(value, ";")
+
+	if issuer = strings.TrimSpace(issuer); issuer != "" && !caaIssuerDomainRegex.MatchString(issuer) {
+		return fmt.Errorf("invalid %s record, '%s' is not a valid issuer domain name, identifier: '%s'", rrType, issuer, identifier)
+	}
+
+	if !hasParameters || strings.TrimSpace(parameters) == "" {
+		return nil
+	}
+
+	for _, parameter := range strings.Split(parameters, ";") {
+		if parameter = strings.TrimSpace(parameter); !caaParameterRegex.MatchString(parameter) {
+			return fmt.Errorf("invalid %s record, '%s' is not a valid issuer parameter, identifier: '%s'", rrType, parameter, identifier)
+		}
+	}
+	return nil
+}
+
+// Validates the value of an iodef property, a URL where violations can be reported (RFC8659 4.4)
+func ensureValidCAAIodef(identifier string, value string, rrType models.ResourceRecordType) error {
+	u, err := url.Parse(value)
+	if err == nil {
+		switch strings.ToLower(u.Scheme) {
+		case "mailto":
+			if strings.Contains(u.Opaque, "@") {
+				return nil
+			}
+		case "http", "https":
+			if u.Host != "" {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("invalid %s record, iodef must be a mailto:, http: or https: URL: '%s', identifier: '%s'", rrType, value, identifier)
+}
+
+func init() {
+	registerBuiltIn(plugins.CAA, &BuiltinPluginCAA{})
+}
@@ -0,0 +1,282 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package builtin
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/bcurnow/zonemgr/plugins"
+)
+
+func caaRenderPrefix(name string) string {
+	return fmt.Sprintf(models.ResourceRecordNameFormatString, name) + " " + fmt.Sprintf(models.ResourceRecordTypeFormatString, models.CAA) + " "
+}
+
+func caaValuesOf(flags string, tag string, value string) []*models.ResourceRecordValue {
+	return []*models.ResourceRecordValue{{Value: flags}, {Value: tag}, {Value: value}}
+}
+
+func TestCAANormalize(t *testing.T) {
+	testCases := []struct {
+		name       string
+		identifier string
+		rr         *models.ResourceRecord
+		wantName   string
+		wantErr    string
+	}{
+		{
+			name:       "issue-values",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Values: caaValuesOf("0", "issue", "letsencrypt.org")},
+			wantName:   "@",
+		},
+		{
+			name:       "issue-value-quoted-with-parameters",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Value: `0 issue "ca.example.net; account=230123; policy=ev"`},
+			wantName:   "@",
+		},
+		{
+			name:       "issuewild-no-issuer",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Value: `128 issuewild ";"`},
+			wantName:   "@",
+		},
+		{
+			name:       "tag-is-case-insensitive",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Value: "0 ISSUE ca.example.net"},
+			wantName:   "@",
+		},
+		{
+			name:       "iodef-mailto",
+			identifier: "example.com.",
+			rr:         &models.ResourceRecord{Type: models.CAA, Value: `0 iodef "mailto:security@example.com"`},
+			wantName:   "example.com.",
+		},
+		{
+			name:       "iodef-https",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Values: caaValuesOf("0", "iodef", "https://iodef.example.com/report")},
+			wantName:   "@",
+		},
+		{
+			name:       "unknown-tag-is-allowed",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Value: `0 contactemail "security@example.com"`},
+			wantName:   "@",
+		},
+		{
+			name:       "wrong-type",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.A, Name: "@", Value: "0 issue ca.example.net"},
+			wantErr:    "this plugin does not handle resource records of type 'A' only '[CAA]', identifier: 'record1'",
+		},
+		{
+			name:       "invalid-name",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "-invalid", Value: "0 issue ca.example.net"},
+			wantErr:    "invalid CAA record, cannot start or end with a hyphen (-): '-invalid', identifier: 'record1'",
+		},
+		{
+			name:       "missing-value",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Value: "0 issue"},
+			wantErr:    "invalid CAA record, must have exactly 3 values (flags, tag, value), found 2, identifier: 'record1'",
+		},
+		{
+			name:       "too-many-values",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Values: append(caaValuesOf("0", "issue", "ca.example.net"), &models.ResourceRecordValue{Value: "extra"})},
+			wantErr:    "invalid CAA record, must have exactly 3 values (flags, tag, value), found 4, identifier: 'record1'",
+		},
+		{
+			name:       "invalid-flags",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Value: "256 issue ca.example.net"},
+			wantErr:    "invalid CAA record, flags must be a number between 0 and 255: '256', identifier: 'record1'",
+		},
+		{
+			name:       "invalid-tag",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Value: "0 issue-wild ca.example.net"},
+			wantErr:    "invalid CAA record, tag must be between 1 and 15 letters and numbers: 'issue-wild', identifier: 'record1'",
+		},
+		{
+			name:       "invalid-issuer",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Value: `0 issue "ca_example.net"`},
+			wantErr:    "invalid CAA record, 'ca_example.net' is not a valid issuer domain name, identifier: 'record1'",
+		},
+		{
+			name:       "invalid-issuer-trailing-dot",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Values: caaValuesOf("0", "issuewild", "ca.example.net.")},
+			wantErr:    "invalid CAA record, 'ca.example.net.' is not a valid issuer domain name, identifier: 'record1'",
+		},
+		{
+			name:       "invalid-parameter",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Value: `0 issue "ca.example.net; account"`},
+			wantErr:    "invalid CAA record, 'account' is not a valid issuer parameter, identifier: 'record1'",
+		},
+		{
+			name:       "invalid-iodef-scheme",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Value: `0 iodef "ftp://iodef.example.com/"`},
+			wantErr:    "invalid CAA record, iodef must be a mailto:, http: or https: URL: 'ftp://iodef.example.com/', identifier: 'record1'",
+		},
+		{
+			name:       "invalid-iodef-mailto",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Value: `0 iodef "mailto:security"`},
+			wantErr:    "invalid CAA record, iodef must be a mailto:, http: or https: URL: 'mailto:security', identifier: 'record1'",
+		},
+		{
+			name:       "invalid-iodef-not-a-url",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Value: `0 iodef "security@example.com"`},
+			wantErr:    "invalid CAA record, iodef must be a mailto:, http: or https: URL: 'security@example.com', identifier: 'record1'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&BuiltinPluginCAA{}).Normalize(tc.identifier, tc.rr)
+			checkErr(t, err, tc.wantErr)
+			if tc.wantErr == "" && tc.rr.Name != tc.wantName {
+				t.Errorf("incorrect name: '%s', want: '%s'", tc.rr.Name, tc.wantName)
+			}
+		})
+	}
+}
+
+func TestCAAValidateZone(t *testing.T) {
+	testCases := []struct {
+		name         string
+		records      map[string]*models.ResourceRecord
+		wantErr      string
+		wantWarnings bool
+	}{
+		{
+			name: "no-records",
+		},
+		{
+			name: "known-tags",
+			records: map[string]*models.ResourceRecord{
+				"issue":  {Type: models.CAA, Name: "@", Value: "0 issue ca.example.net"},
+				"wild":   {Type: models.CAA, Name: "@", Values: caaValuesOf("128", "IssueWild", ";")},
+				"iodef":  {Type: models.CAA, Name: "@", Value: `0 iodef "mailto:security@example.com"`},
+				"a":      {Type: models.A, Name: "www", Value: "1.2.3.4"},
+				"txtcaa": {Type: models.TXT, Name: "@", Value: "0 contactemail security@example.com"},
+			},
+		},
+		{
+			name: "unknown-tag",
+			records: map[string]*models.ResourceRecord{
+				"caa": {Type: models.CAA, Name: "@", Value: `0 contactemail "security@example.com"`},
+			},
+			wantErr:      "CAA record has an unknown property tag 'contactemail', certificate authorities that don't recognize it will ignore it, identifier: 'caa'",
+			wantWarnings: true,
+		},
+		{
+			name: "unknown-tag-critical",
+			records: map[string]*models.ResourceRecord{
+				"caa": {Type: models.CAA, Name: "@", Values: caaValuesOf("128", "isue", "ca.example.net")},
+			},
+			wantErr:      "CAA record has an unknown property tag 'isue' with the critical flag set, certificate authorities that don't recognize it will refuse to issue, identifier: 'caa'",
+			wantWarnings: true,
+		},
+		{
+			name: "invalid-record",
+			records: map[string]*models.ResourceRecord{
+				"caa": {Type: models.CAA, Name: "@", Value: "0 issue"},
+			},
+			wantErr: "invalid CAA record, must have exactly 3 values (flags, tag, value), found 2, identifier: 'caa'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&BuiltinPluginCAA{}).ValidateZone("example.com.", &models.Zone{ResourceRecords: tc.records})
+			checkErr(t, err, tc.wantErr)
+			checkRecordErrors(t, err)
+			for _, problem := range plugins.Problems(err) {
+				var recordErr *plugins.RecordError
+				if errors.As(problem, &recordErr) && (recordErr.Severity == models.SeverityWarning) != tc.wantWarnings {
+					t.Errorf("incorrect severity: '%s', want warnings: %t", recordErr.Severity, tc.wantWarnings)
+				}
+			}
+		})
+	}
+}
+
+func TestCAARender(t *testing.T) {
+	testCases := []struct {
+		name       string
+		identifier string
+		rr         *models.ResourceRecord
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "values",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Values: caaValuesOf("0", "issue", "letsencrypt.org")},
+			want:       caaRenderPrefix("@") + `0 issue "letsencrypt.org"`,
+		},
+		{
+			name:       "quoted-value-with-comment",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Value: `128 issue "ca.example.net; account=230123"`, Comment: "only our CA"},
+			want:       caaRenderPrefix("@") + `128 issue "ca.example.net; account=230123" ;only our CA`,
+		},
+		{
+			name:       "escapes-a-bare-quote",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Values: caaValuesOf("0", "custom", `say "hi" and \"bye\"`)},
+			want:       caaRenderPrefix("@") + `0 custom "say \"hi\" and \"bye\""`,
+		},
+		{
+			name:       "wrong-type",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.A, Name: "@"},
+			wantErr:    "this plugin does not handle resource records of type 'A' only '[CAA]', identifier: 'record1'",
+		},
+		{
+			name:       "invalid-values",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.CAA, Name: "@", Value: "letsencrypt.org"},
+			wantErr:    "invalid CAA record, must have exactly 3 values (flags, tag, value), found 1, identifier: 'record1'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := (&BuiltinPluginCAA{}).Render(tc.identifier, tc.rr)
+			checkErr(t, err, tc.wantErr)
+			if tc.wantErr == "" && got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
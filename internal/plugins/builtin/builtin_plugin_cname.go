@@ -20,8 +20,6 @@
 package builtin
 
 import (
-	"fmt"
-
 	"github.com/bcurnow/zonemgr/models"
 	"github.com/bcurnow/zonemgr/plugins"
 	"github.com/bcurnow/zonemgr/utils"
@@ -67,23 +65,8 @@ func (p *BuiltinPluginCNAME) Normalize(identifier string, rr *models.ResourceRec
 	return nil
 }
 
+// The targets can be in any of the zones, they're followed once every zone is normalized (see cname_policy)
 func (p *BuiltinPluginCNAME) ValidateZone(name string, zone *models.Zone) error {
-	resourceRecordsByType := zone.ResourceRecordsByType()
-	aRecords := resourceRecordsByType[models.A]
-	aRecords = p.convertToNameMap(aRecords)
-	cnameRecords := resourceRecordsByType[models.CNAME]
-
-	if len(cnameRecords) > 0 && len(aRecords) == 0 {
-		return fmt.Errorf("found CNAME records but there are no A records present, all CNAMES must reference an A record name, zone: '%s'", name)
-	}
-
-	for identifier, cnameRecord := range cnameRecords {
-		_, ok := aRecords[cnameRecord.Value]
-		if !ok {
-			return fmt.Errorf("invalid CNAME record, '%s' has a value of '%s' which does not match any defined A record name, zone: '%s'", identifier, cnameRecord.Value, name)
-		}
-	}
-
 	return nil
 }
 
@@ -94,14 +77,6 @@ func (p *BuiltinPluginCNAME) Render(identifier string, rr *models.ResourceRecord
 	return rr.RenderSingleValueResource(), nil
 }
 
-func (p *BuiltinPluginCNAME) convertToNameMap(idMap map[string]*models.ResourceRecord) map[string]*models.ResourceRecord {
-	nameMap := make(map[string]*models.ResourceRecord)
-	for _, rr := range idMap {
-		nameMap[rr.Name] = rr
-	}
-	return nameMap
-}
-
 func init() {
 	registerBuiltIn(plugins.CNAME, &BuiltinPluginCNAME{})
 }
@@ -20,7 +20,6 @@
 package builtin
 
 import (
-	"errors"
 	"testing"
 
 	"github.com/bcurnow/zonemgr/models"
@@ -71,86 +70,14 @@ func TestNormalize_CNAMEPlugin(t *testing.T) {
 }
 
 func TestValidateZone_CNAMEPlugin(t *testing.T) {
-	plugin := &BuiltinPluginCNAME{}
-	testCases := []struct {
-		zone *models.Zone
-		err  error
-	}{
-		{
-			zone: &models.Zone{ResourceRecords: map[string]*models.ResourceRecord{}},
-		},
-		{
-			zone: &models.Zone{
-				ResourceRecords: map[string]*models.ResourceRecord{
-					"lonecname": {
-						Name: "lonecname",
-						Type: models.CNAME,
-					},
-				},
-			},
-			err: errors.New("found CNAME records but there are no A records present, all CNAMES must reference an A record name, zone: 'testing'"),
-		},
-		{
-			zone: &models.Zone{
-				ResourceRecords: map[string]*models.ResourceRecord{
-					"cname": {
-						Name:  "badcname",
-						Type:  models.CNAME,
-						Value: "notgoingtofindme",
-					},
-					"arecord": {
-						Name:  "nottherightone",
-						Type:  models.A,
-						Value: "1.2.3.4",
-					},
-				},
-			},
-			err: errors.New("invalid CNAME record, 'cname' has a value of 'notgoingtofindme' which does not match any defined A record name, zone: 'testing'"),
-		},
-		{
-			zone: &models.Zone{
-				ResourceRecords: map[string]*models.ResourceRecord{
-					"arecord": {
-						Name:  "arecord",
-						Type:  models.A,
-						Value: "1.2.3.4",
-					},
-					"cname": {
-						Name:  "cname",
-						Type:  models.CNAME,
-						Value: "arecord",
-					},
-				},
-			},
-		},
-		{
-			zone: &models.Zone{
-				ResourceRecords: map[string]*models.ResourceRecord{
-					"differentidentifier": {
-						Name:  "arecord",
-						Type:  models.A,
-						Value: "1.2.3.4",
-					},
-					"cname": {
-						Name:  "cname",
-						Type:  models.CNAME,
-						Value: "arecord",
-					},
-				},
-			},
+	// The targets are checked across every zone by the normalizer, a target the zone doesn't have isn't an error here
+	zone := &models.Zone{
+		ResourceRecords: map[string]*models.ResourceRecord{
+			"cname": {Name: "cname", Type: models.CNAME, Value: "elsewhere.example.net."},
 		},
 	}
-
-	for _, tc := range testCases {
-		if err := plugin.ValidateZone("testing", tc.zone); err != nil {
-			if tc.err == nil {
-				t.Errorf("unexpected error: %s", err)
-			} else if err.Error() != tc.err.Error() {
-				t.Errorf("incorrect error: %s, want %s", err, tc.err)
-			}
-		} else if tc.err != nil {
-			t.Errorf("expected error %q, got nil", tc.err)
-		}
+	if err := (&BuiltinPluginCNAME{}).ValidateZone("testing", zone); err != nil {
+		t.Errorf("unexpected error: %s", err)
 	}
 }
 
@@ -0,0 +1,162 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package builtin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/bcurnow/zonemgr/plugins"
+	"github.com/bcurnow/zonemgr/utils"
+)
+
+// The preference is at most 5 digits (65535), padding it keeps the exchanges lined up
+const mxPreferenceFormatString = "%-5s"
+
+// Make sure we're correctly implementing the ZonmgrPlugin interface
+var _ plugins.ZoneMgrPlugin = &BuiltinPluginMX{}
+
+type BuiltinPluginMX struct {
+	plugins.ZoneMgrPlugin
+}
+
+func (p *BuiltinPluginMX) PluginVersion() (string, error) {
+	return utils.Version(), nil
+}
+
+func (p *BuiltinPluginMX) PluginTypes() ([]plugins.Type, error) {
+	return plugins.PluginTypes(plugins.MX), nil
+}
+
+func (p *BuiltinPluginMX) Configure(config *models.Config) error {
+	// no config
+	return nil
+}
+
+func (p *BuiltinPluginMX) Normalize(identifier string, rr *models.ResourceRecord) error {
+	if err := validations.CommonValidations(identifier, rr, plugins.MX); err != nil {
+		return err
+	}
+
+	if rr.Name == "" {
+		rr.Name = identifier
+	}
+
+	if err := validations.EnsureValidNameOrWildcard(identifier, rr.Name, rr.Type); err != nil {
+		return err
+	}
+
+	preference, exchange, err := mxValues(identifier, rr)
+	if err != nil {
+		return err
+	}
+
+	// The exchange must be a name, RFC2181 10.3 doesn't allow an IP address or an alias
+	if err := validations.EnsureNotIP(identifier, exchange, rr.Type); err != nil {
+		return err
+	}
+
+	if err := validations.EnsureFullyQualified(identifier, exchange, rr.Type); err != nil {
+		return err
+	}
+
+	// The keyed form is turned into values so the zone checks see the same record either way
+	if rr.MX != nil {
+		rr.Values = []*models.ResourceRecordValue{{Value: preference}, {Value: exchange, Comment: rr.Comment}}
+		rr.Comment = ""
+		rr.MX = nil
+	}
+
+	return nil
+}
+
+func (p *BuiltinPluginMX) ValidateZone(name string, zone *models.Zone) error {
+	names := models.NewZoneNames(name, zone)
+	var errs []error
+	zone.WithSortedResourceRecords(func(identifier string, rr *models.ResourceRecord) error {
+		if rr.Type != models.MX {
+			return nil
+		}
+		_, exchange, err := mxValues(identifier, rr)
+		if err != nil {
+			errs = append(errs, plugins.NewRecordError(identifier, err))
+			return nil
+		}
+
+		if names.Owns(names.Resolve(exchange), models.CNAME) {
+			errs = append(errs, plugins.NewRecordError(identifier, fmt.Errorf("invalid MX record, '%s' has an exchange of '%s' which is a CNAME, the exchange must be the name of an address record, zone: '%s'", identifier, exchange, name)))
+		}
+		return nil
+	})
+
+	return errors.Join(errs...)
+}
+
+func (p *BuiltinPluginMX) Render(identifier string, rr *models.ResourceRecord) (string, error) {
+	if err := validations.EnsureSupportedPluginType(identifier, rr.Type, plugins.MX); err != nil {
+		return "", err
+	}
+
+	preference, exchange, err := mxValues(identifier, rr)
+	if err != nil {
+		return "", err
+	}
+
+	var record strings.Builder
+	record.WriteString(rr.RenderResourceWithoutValue())
+	fmt.Fprintf(&record, mxPreferenceFormatString, preference)
+	record.WriteString(" ")
+	record.WriteString(exchange)
+
+	if comment := rdataComment(rr); comment != "" {
+		record.WriteString(" ;")
+		record.WriteString(comment)
+	}
+
+	return record.String(), nil
+}
+
+// Returns the preference and exchange of the resource record, these are either the two entries in Values, the Value
+// shortcut (e.g. "10 mail.example.com.") or the keyed mx form
+func mxValues(identifier string, rr *models.ResourceRecord) (string, string, error) {
+	var fields []string
+	if rr.MX != nil {
+		if rr.Value != "" || len(rr.Values) > 0 {
+			return "", "", fmt.Errorf("invalid %s record, mx cannot be used with value or values, identifier: '%s'", rr.Type, identifier)
+		}
+		fields = []string{rr.MX.Preference, rr.MX.Exchange}
+	} else {
+		var err error
+		if fields, err = rdataFields(identifier, rr, "preference", "exchange"); err != nil {
+			return "", "", err
+		}
+	}
+
+	if err := ensureUint16(identifier, fields[0], "preference", rr.Type); err != nil {
+		return "", "", err
+	}
+
+	return fields[0], fields[1], nil
+}
+
+func init() {
+	registerBuiltIn(plugins.MX, &BuiltinPluginMX{})
+}
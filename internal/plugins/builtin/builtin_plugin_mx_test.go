@@ -0,0 +1,291 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package builtin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func mxRenderPrefix(name string) string {
+	return fmt.Sprintf(models.ResourceRecordNameFormatString, name) + " " + fmt.Sprintf(models.ResourceRecordTypeFormatString, models.MX) + " "
+}
+
+func mxValuesOf(preference string, exchange string) []*models.ResourceRecordValue {
+	return []*models.ResourceRecordValue{{Value: preference}, {Value: exchange}}
+}
+
+func TestMXNormalize(t *testing.T) {
+	testCases := []struct {
+		name       string
+		identifier string
+		rr         *models.ResourceRecord
+		wantName   string
+		wantErr    string
+	}{
+		{
+			name:       "valid-values",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", Values: mxValuesOf("10", "mail.example.com.")},
+			wantName:   "@",
+		},
+		{
+			name:       "valid-value",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", Value: "10 mail.example.com."},
+			wantName:   "@",
+		},
+		{
+			name:       "valid-keyed",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", MX: &models.MXFields{Preference: "10", Exchange: "mail.example.com."}},
+			wantName:   "@",
+		},
+		{
+			name:       "name-defaults-to-identifier",
+			identifier: "example.com.",
+			rr:         &models.ResourceRecord{Type: models.MX, Value: "0 mail.example.com."},
+			wantName:   "example.com.",
+		},
+		{
+			name:       "wrong-type",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.A, Name: "@", Value: "10 mail.example.com."},
+			wantErr:    "this plugin does not handle resource records of type 'A' only '[MX]', identifier: 'record1'",
+		},
+		{
+			name:       "invalid-name",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "-invalid", Value: "10 mail.example.com."},
+			wantErr:    "invalid MX record, cannot start or end with a hyphen (-): '-invalid', identifier: 'record1'",
+		},
+		{
+			name:       "missing-exchange",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", Value: "10"},
+			wantErr:    "invalid MX record, must have exactly 2 values (preference, exchange), found 1, identifier: 'record1'",
+		},
+		{
+			name:       "too-many-values",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", Values: append(mxValuesOf("10", "mail.example.com."), &models.ResourceRecordValue{Value: "extra"})},
+			wantErr:    "invalid MX record, must have exactly 2 values (preference, exchange), found 3, identifier: 'record1'",
+		},
+		{
+			name:       "keyed-and-value",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", Value: "10 mail.example.com.", MX: &models.MXFields{Preference: "10", Exchange: "mail.example.com."}},
+			wantErr:    "invalid MX record, mx cannot be used with value or values, identifier: 'record1'",
+		},
+		{
+			name:       "keyed-preference-too-large",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", MX: &models.MXFields{Preference: "70000", Exchange: "mail.example.com."}},
+			wantErr:    "invalid MX record, preference must be a number between 0 and 65535: '70000', identifier: 'record1'",
+		},
+		{
+			name:       "keyed-exchange-not-fqdn",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", MX: &models.MXFields{Preference: "10", Exchange: "mail"}},
+			wantErr:    "invalid MX record, must end with a trailing dot: 'mail', identifier: 'record1'",
+		},
+		{
+			name:       "preference-not-a-number",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", Values: mxValuesOf("high", "mail.example.com.")},
+			wantErr:    "invalid MX record, preference must be a number between 0 and 65535: 'high', identifier: 'record1'",
+		},
+		{
+			name:       "preference-too-large",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", Value: "65536 mail.example.com."},
+			wantErr:    "invalid MX record, preference must be a number between 0 and 65535: '65536', identifier: 'record1'",
+		},
+		{
+			name:       "exchange-is-ip",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", Value: "10 1.2.3.4"},
+			wantErr:    "invalid MX record, '1.2.3.4' must not be an IP address, identifier: 'record1'",
+		},
+		{
+			name:       "exchange-not-fqdn",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", Value: "10 mail"},
+			wantErr:    "invalid MX record, must end with a trailing dot: 'mail', identifier: 'record1'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&BuiltinPluginMX{}).Normalize(tc.identifier, tc.rr)
+			checkErr(t, err, tc.wantErr)
+			if tc.wantErr == "" && tc.rr.Name != tc.wantName {
+				t.Errorf("incorrect name: '%s', want: '%s'", tc.rr.Name, tc.wantName)
+			}
+		})
+	}
+}
+
+func TestMXNormalize_Keyed(t *testing.T) {
+	rr := &models.ResourceRecord{Type: models.MX, Name: "@", MX: &models.MXFields{Preference: "10", Exchange: "mail.example.com."}, Comment: "primary"}
+	if err := (&BuiltinPluginMX{}).Normalize("mx", rr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := &models.ResourceRecord{Type: models.MX, Name: "@", Values: []*models.ResourceRecordValue{{Value: "10"}, {Value: "mail.example.com.", Comment: "primary"}}}
+	if diff := cmp.Diff(want, rr, cmp.AllowUnexported(models.ResourceRecord{})); diff != "" {
+		t.Errorf("incorrect record:\n%s", diff)
+	}
+}
+
+func TestMXValidateZone(t *testing.T) {
+	testCases := []struct {
+		name    string
+		records map[string]*models.ResourceRecord
+		wantErr string
+	}{
+		{
+			name: "no-records",
+		},
+		{
+			name: "exchange-is-address-record",
+			records: map[string]*models.ResourceRecord{
+				"mail": {Type: models.A, Name: "mail", Value: "1.2.3.4"},
+				"www":  {Type: models.CNAME, Name: "www", Value: "mail"},
+				"mx":   {Type: models.MX, Name: "@", Value: "10 mail.example.com."},
+			},
+		},
+		{
+			name: "exchange-is-relative-cname",
+			records: map[string]*models.ResourceRecord{
+				"mail": {Type: models.CNAME, Name: "mail", Value: "www"},
+				"mx":   {Type: models.MX, Name: "@", Value: "10 MAIL.example.com."},
+			},
+			wantErr: "invalid MX record, 'mx' has an exchange of 'MAIL.example.com.' which is a CNAME, the exchange must be the name of an address record, zone: 'example.com.'",
+		},
+		{
+			name: "exchange-is-absolute-cname",
+			records: map[string]*models.ResourceRecord{
+				"mail": {Type: models.CNAME, Name: "mail.example.com.", Value: "www"},
+				"mx":   {Type: models.MX, Name: "@", Values: mxValuesOf("10", "mail.example.com.")},
+			},
+			wantErr: "invalid MX record, 'mx' has an exchange of 'mail.example.com.' which is a CNAME, the exchange must be the name of an address record, zone: 'example.com.'",
+		},
+		{
+			name: "exchange-is-matched-by-wildcard-cname",
+			records: map[string]*models.ResourceRecord{
+				"mail": {Type: models.CNAME, Name: "*.mail", Value: "www"},
+				"mx":   {Type: models.MX, Name: "@", Value: "10 in.mail.example.com."},
+			},
+			wantErr: "invalid MX record, 'mx' has an exchange of 'in.mail.example.com.' which is a CNAME, the exchange must be the name of an address record, zone: 'example.com.'",
+		},
+		{
+			name: "keyed-exchange-is-cname",
+			records: map[string]*models.ResourceRecord{
+				"mail": {Type: models.CNAME, Name: "mail", Value: "www"},
+				"mx":   {Type: models.MX, Name: "@", MX: &models.MXFields{Preference: "10", Exchange: "mail.example.com."}},
+			},
+			wantErr: "invalid MX record, 'mx' has an exchange of 'mail.example.com.' which is a CNAME, the exchange must be the name of an address record, zone: 'example.com.'",
+		},
+		{
+			name: "exchange-is-cname-in-another-zone",
+			records: map[string]*models.ResourceRecord{
+				"mail": {Type: models.CNAME, Name: "mail", Value: "www"},
+				"mx":   {Type: models.MX, Name: "@", Value: "10 mail.example.net."},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&BuiltinPluginMX{}).ValidateZone("example.com.", &models.Zone{ResourceRecords: tc.records})
+			checkErr(t, err, tc.wantErr)
+			checkRecordErrors(t, err)
+		})
+	}
+}
+
+func TestMXRender(t *testing.T) {
+	testCases := []struct {
+		name       string
+		identifier string
+		rr         *models.ResourceRecord
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "values",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", Values: mxValuesOf("10", "mail.example.com.")},
+			want:       mxRenderPrefix("@") + "10    mail.example.com.",
+		},
+		{
+			name:       "value",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", Value: "65535   mail.example.com."},
+			want:       mxRenderPrefix("@") + "65535 mail.example.com.",
+		},
+		{
+			name:       "value-with-comment",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", Value: "5 mail.example.com.", Comment: "primary"},
+			want:       mxRenderPrefix("@") + "5     mail.example.com. ;primary",
+		},
+		{
+			name:       "values-with-comments",
+			identifier: "record1",
+			rr: &models.ResourceRecord{Type: models.MX, Name: "@", Values: []*models.ResourceRecordValue{
+				{Value: "20", Comment: "backup"},
+				{Value: "backup.example.com.", Comment: "hosted"},
+			}},
+			want: mxRenderPrefix("@") + "20    backup.example.com. ;backup hosted",
+		},
+		{
+			name:       "keyed-with-comment",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", MX: &models.MXFields{Preference: "20", Exchange: "backup.example.com."}, Comment: "backup"},
+			want:       mxRenderPrefix("@") + "20    backup.example.com. ;backup",
+		},
+		{
+			name:       "wrong-type",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.A, Name: "@"},
+			wantErr:    "this plugin does not handle resource records of type 'A' only '[MX]', identifier: 'record1'",
+		},
+		{
+			name:       "invalid-values",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.MX, Name: "@", Value: "mail.example.com."},
+			wantErr:    "invalid MX record, must have exactly 2 values (preference, exchange), found 1, identifier: 'record1'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := (&BuiltinPluginMX{}).Render(tc.identifier, tc.rr)
+			checkErr(t, err, tc.wantErr)
+			if tc.wantErr == "" && got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
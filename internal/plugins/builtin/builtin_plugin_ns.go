@@ -20,6 +20,11 @@
 package builtin
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/bcurnow/zonemgr/models"
 	"github.com/bcurnow/zonemgr/plugins"
 	"github.com/bcurnow/zonemgr/utils"
@@ -30,6 +35,7 @@ var _ plugins.ZoneMgrPlugin = &BuiltinPluginNS{}
 
 type BuiltinPluginNS struct {
 	plugins.ZoneMgrPlugin
+	config *models.Config
 }
 
 func (p *BuiltinPluginNS) PluginVersion() (string, error) {
@@ -41,7 +47,7 @@ func (p *BuiltinPluginNS) PluginTypes() ([]plugins.Type, error) {
 }
 
 func (p *BuiltinPluginNS) Configure(config *models.Config) error {
-	// We don't need to do anything with the configuration
+	p.config = config
 	return nil
 }
 
@@ -60,6 +66,11 @@ func (p *BuiltinPluginNS) Normalize(identifier string, rr *models.ResourceRecord
 		return err
 	}
 
+	// A delegation can't be synthesized from a wildcard (RFC4592 4.2)
+	if rr.IsWildcard() {
+		return fmt.Errorf("invalid %s record, a wildcard can't own an NS record: '%s', identifier: '%s'", rr.Type, rr.Name, identifier)
+	}
+
 	if rr.RetrieveSingleValue() == "" {
 		rr.Value = identifier
 	}
@@ -77,8 +88,54 @@ func (p *BuiltinPluginNS) Normalize(identifier string, rr *models.ResourceRecord
 }
 
 func (p *BuiltinPluginNS) ValidateZone(name string, zone *models.Zone) error {
-	//no-op
-	return nil
+	names := models.NewZoneNames(name, zone)
+	apex := names.Apex()
+	var apexNameServers []string
+	var errs []error
+	zone.WithSortedResourceRecords(func(identifier string, rr *models.ResourceRecord) error {
+		if rr.Type != models.NS {
+			return nil
+		}
+		owner := models.AbsoluteName(rr.Name, name)
+		nameServer := rr.RetrieveSingleValue()
+		target := strings.ToLower(nameServer)
+		if owner == apex {
+			apexNameServers = append(apexNameServers, target)
+		}
+
+		// A name server outside of the zone is found the usual way, there's nothing in this zone to check
+		resolved := names.Resolve(target)
+		switch {
+		case !names.Contains(target):
+		case names.Owns(resolved, models.CNAME):
+			// RFC2181 10.3
+			errs = append(errs, plugins.NewRecordError(identifier, fmt.Errorf("invalid NS record, '%s' has a value of '%s' which is a CNAME, the name server must be the name of an address record, zone: '%s'", identifier, nameServer, name)))
+		case names.Owns(resolved, models.A) || names.Owns(resolved, models.AAAA):
+		case owner != apex && (target == owner || strings.HasSuffix(target, "."+owner)):
+			// The name server is inside the zone it serves so it can only be found through the glue (RFC1912 2.3)
+			errs = append(errs, plugins.NewRecordError(identifier, fmt.Errorf("invalid NS record, '%s' has a value of '%s' which is below the delegation of '%s' and needs a glue A or AAAA record, zone: '%s'", identifier, nameServer, owner, name)))
+		default:
+			errs = append(errs, plugins.NewRecordError(identifier, fmt.Errorf("invalid NS record, '%s' has a value of '%s' which is in the zone but doesn't have an A or AAAA record, zone: '%s'", identifier, nameServer, name)))
+		}
+		return nil
+	})
+
+	// The primary name server should be one of the name servers of the zone (RFC1912 2.2), a catalog zone isn't
+	// served to resolvers so its name servers don't matter
+	if len(apexNameServers) > 0 && (p.config == nil || !p.config.IsCatalog) {
+		zone.WithSortedResourceRecords(func(identifier string, rr *models.ResourceRecord) error {
+			if rr.Type != models.SOA || len(rr.Values) == 0 {
+				return nil
+			}
+			if mname := rr.Values[0].Value; !slices.Contains(apexNameServers, strings.ToLower(mname)) {
+				slices.Sort(apexNameServers)
+				errs = append(errs, plugins.NewRecordError(identifier, fmt.Errorf("invalid SOA record, '%s' has a primary name server (mname) of '%s' which isn't one of the NS records of the zone (%s), zone: '%s'", identifier, mname, strings.Join(apexNameServers, ", "), name)))
+			}
+			return nil
+		})
+	}
+
+	return errors.Join(errs...)
 }
 
 func (p *BuiltinPluginNS) Render(identifier string, rr *models.ResourceRecord) (string, error) {
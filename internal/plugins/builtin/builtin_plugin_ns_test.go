@@ -59,6 +59,12 @@ func TestNSNormalize(t *testing.T) {
 			rr:         &models.ResourceRecord{Type: models.NS, Name: "-invalid", Value: "ns1.example.com."},
 			wantErr:    "invalid NS record, cannot start or end with a hyphen (-): '-invalid', identifier: 'record1'",
 		},
+		{
+			name:       "wildcard-name",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.NS, Name: "*.sub", Value: "ns1.example.com."},
+			wantErr:    "invalid NS record, a wildcard can't own an NS record: '*.sub', identifier: 'record1'",
+		},
 		{
 			name:       "value-is-ip",
 			identifier: "record1",
@@ -80,6 +86,103 @@ func TestNSNormalize(t *testing.T) {
 	}
 }
 
+func TestNSValidateZone(t *testing.T) {
+	soa := func(mname string) *models.ResourceRecord {
+		return &models.ResourceRecord{Type: models.SOA, Name: "example.com.", Values: []*models.ResourceRecordValue{{Value: mname}, {Value: "admin.example.com."}}}
+	}
+
+	testCases := []struct {
+		name    string
+		config  *models.Config
+		records map[string]*models.ResourceRecord
+		wantErr string
+	}{
+		{
+			name: "no-records",
+		},
+		{
+			name: "valid",
+			records: map[string]*models.ResourceRecord{
+				"soa":      soa("NS1.example.com."),
+				"ns1":      {Type: models.NS, Name: "@", Value: "ns1.example.com."},
+				"ns2":      {Type: models.NS, Name: "example.com.", Value: "ns.example.net."},
+				"ns1-a":    {Type: models.A, Name: "ns1", Value: "192.0.2.1"},
+				"lab":      {Type: models.NS, Name: "lab", Value: "ns.lab.example.com."},
+				"lab-glue": {Type: models.AAAA, Name: "ns.lab", Value: "2001:db8::1"},
+				"dev":      {Type: models.NS, Name: "dev", Value: "ns1.example.com."},
+			},
+		},
+		{
+			name: "name-server-matched-by-wildcard",
+			records: map[string]*models.ResourceRecord{
+				"ns":       {Type: models.NS, Name: "@", Value: "ns1.servers.example.com."},
+				"wildcard": {Type: models.A, Name: "*.servers", Value: "192.0.2.1"},
+			},
+		},
+		{
+			name: "name-server-is-cname",
+			records: map[string]*models.ResourceRecord{
+				"ns":    {Type: models.NS, Name: "@", Value: "NS.example.com."},
+				"alias": {Type: models.CNAME, Name: "ns", Value: "host"},
+				"host":  {Type: models.A, Name: "host", Value: "192.0.2.1"},
+			},
+			wantErr: "invalid NS record, 'ns' has a value of 'NS.example.com.' which is a CNAME, the name server must be the name of an address record, zone: 'example.com.'",
+		},
+		{
+			name: "name-server-without-address",
+			records: map[string]*models.ResourceRecord{
+				"ns": {Type: models.NS, Name: "@", Value: "ns1.example.com."},
+			},
+			wantErr: "invalid NS record, 'ns' has a value of 'ns1.example.com.' which is in the zone but doesn't have an A or AAAA record, zone: 'example.com.'",
+		},
+		{
+			name: "delegation-without-glue",
+			records: map[string]*models.ResourceRecord{
+				"lab":  {Type: models.NS, Name: "lab", Value: "ns.lab.example.com."},
+				"lab2": {Type: models.NS, Name: "lab", Value: "lab.example.com."},
+			},
+			wantErr: "invalid NS record, 'lab' has a value of 'ns.lab.example.com.' which is below the delegation of 'lab.example.com.' and needs a glue A or AAAA record, zone: 'example.com.'\n" +
+				"invalid NS record, 'lab2' has a value of 'lab.example.com.' which is below the delegation of 'lab.example.com.' and needs a glue A or AAAA record, zone: 'example.com.'",
+		},
+		{
+			name: "mname-not-a-name-server",
+			records: map[string]*models.ResourceRecord{
+				"soa": soa("primary.example.net."),
+				"ns1": {Type: models.NS, Name: "@", Value: "ns2.example.net."},
+				"ns2": {Type: models.NS, Name: "@", Value: "ns1.example.net."},
+				"lab": {Type: models.NS, Name: "lab", Value: "primary.example.net."},
+			},
+			wantErr: "invalid SOA record, 'soa' has a primary name server (mname) of 'primary.example.net.' which isn't one of the NS records of the zone (ns1.example.net., ns2.example.net.), zone: 'example.com.'",
+		},
+		{
+			name: "mname-without-name-servers",
+			records: map[string]*models.ResourceRecord{
+				"soa": soa("primary.example.net."),
+			},
+		},
+		{
+			name:   "mname-in-catalog-zone",
+			config: &models.Config{IsCatalog: true},
+			records: map[string]*models.ResourceRecord{
+				"soa": soa("primary.example.net."),
+				"ns":  {Type: models.NS, Name: "@", Value: "invalid."},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &BuiltinPluginNS{}
+			if tc.config != nil {
+				p.Configure(tc.config)
+			}
+			err := p.ValidateZone("example.com.", &models.Zone{ResourceRecords: tc.records})
+			checkErr(t, err, tc.wantErr)
+			checkRecordErrors(t, err)
+		})
+	}
+}
+
 func TestNSRender(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -76,11 +76,12 @@ func (p *BuiltinPluginSOA) Normalize(identifier string, rr *models.ResourceRecor
 		return fmt.Errorf("comment field cannot be used on SOA records, please use the values field, identifier: '%s'", identifier)
 	}
 
-	// Only generate the next serial number if the config option is set
+	// Only generate the next serial number if the config option is set. The serial number is only peeked at here,
+	// normalizing (e.g. to validate) must not use it up, it's committed once the zone file has been written.
 	generatedSerial := ""
 	if p.config.GenerateSerial {
 		// The name of the SOA record is also the name of the zone
-		nextSerial, err := serialIndexManager.Next(rr.Name)
+		nextSerial, err := serialIndexManager.Peek(rr.Name)
 		if err != nil {
 			return err
 		}
@@ -91,6 +92,14 @@ func (p *BuiltinPluginSOA) Normalize(identifier string, rr *models.ResourceRecor
 		return err
 	}
 
+	// The refresh, retry, expire and minimum can be written with units (e.g. 1h), these are rendered as a number of
+	// seconds unless the zone keeps them
+	if !p.config.KeepTimeUnits {
+		for _, value := range rr.Values[3:] {
+			value.Value = models.TimeIntervalInSeconds(value.Value)
+		}
+	}
+
 	return nil
 }
 
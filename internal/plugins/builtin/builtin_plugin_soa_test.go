@@ -38,6 +38,8 @@ type soaNormalization struct {
 	generateSerial          bool
 	generateSerialErr       bool
 	soaValuesNormalizerErr  bool
+	timeUnits               bool
+	keepTimeUnits           bool
 }
 
 func TestSOANormalize(t *testing.T) {
@@ -58,13 +60,15 @@ func TestSOANormalize(t *testing.T) {
 		{identifier: "value-used-error", testConfig: &soaNormalization{valueUsedErr: true}, err: errors.New("invalid SOA record, both value and values are set, identifier: 'value-used-error'")},
 		{identifier: "comment-used-error", testConfig: &soaNormalization{commentUsedErr: true}, err: errors.New("invalid SOA record, both comment and values are set, identifier: 'comment-used-error'")},
 		{identifier: "generate-serial-error", testConfig: &soaNormalization{generateSerial: true, generateSerialErr: true}, err: errTesting},
+		{identifier: "time-units", testConfig: &soaNormalization{hasSerialInValues: true, timeUnits: true}},
+		{identifier: "keep-time-units", testConfig: &soaNormalization{hasSerialInValues: true, timeUnits: true, keepTimeUnits: true}},
 		{identifier: "normalizer-error", testConfig: &soaNormalization{soaValuesNormalizerErr: true, hasSerialInValues: true}, err: errors.New("REFRESH must not be less than 0 on a SOA record, was '-1', identifier: 'normalizer-error'")},
 	}
 
 	for _, tc := range testCases {
 		rr := testSOA(*tc.testConfig)
 		setupSerialExpects(t, tc.identifier, tc.testConfig, rr)
-		config := &models.Config{GenerateSerial: tc.testConfig.generateSerial}
+		config := &models.Config{GenerateSerial: tc.testConfig.generateSerial, KeepTimeUnits: tc.testConfig.keepTimeUnits}
 		if err := plugin.Configure(config); err != nil {
 			t.Fatalf("Configure failed: %v", err)
 		}
@@ -84,6 +88,16 @@ func TestSOANormalize(t *testing.T) {
 				if rr.Name != tc.identifier {
 					t.Errorf("%s - incorrect name: %s, expected %s", tc.identifier, rr.Name, tc.identifier)
 				}
+			} else if tc.testConfig.timeUnits {
+				want := []string{"3600", "900", "604800", "300"}
+				if tc.testConfig.keepTimeUnits {
+					want = []string{"1h", "15m", "1w", "5m"}
+				}
+				for i, value := range rr.Values[3:] {
+					if value.Value != want[i] {
+						t.Errorf("%s - incorrect value %d: %s, expected %s", tc.identifier, i+3, value.Value, want[i])
+					}
+				}
 			}
 		}
 	}
@@ -158,7 +172,7 @@ func setupSerialExpects(t *testing.T, identifier string, sn *soaNormalization, r
 	if sn.identifierAsName {
 		name = identifier
 	}
-	call := mockSerialIndexManager.EXPECT().Next(name)
+	call := mockSerialIndexManager.EXPECT().Peek(name)
 	if sn.generateSerialErr {
 		call.Return("", errTesting)
 	} else {
@@ -185,6 +199,9 @@ func testSOA(sn soaNormalization) *models.ResourceRecord {
 	if sn.soaValuesNormalizerErr {
 		timerValues = []string{"-1", "900", "604800", "300"}
 	}
+	if sn.timeUnits {
+		timerValues = []string{"1h", "15m", "1w", "5m"}
+	}
 
 	if sn.hasSerialInValues {
 		soa.Values = []*models.ResourceRecordValue{
@@ -0,0 +1,166 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package builtin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/bcurnow/zonemgr/plugins"
+	"github.com/bcurnow/zonemgr/utils"
+)
+
+// Priority, weight and port are at most 5 digits (65535), padding them keeps the columns lined up
+const srvNumberFormatString = "%-5s"
+
+// A target of "." means the service is decidedly not available at this domain (RFC2782)
+const srvServiceNotAvailable = "."
+
+// Make sure we're correctly implementing the ZonmgrPlugin interface
+var _ plugins.ZoneMgrPlugin = &BuiltinPluginSRV{}
+
+type BuiltinPluginSRV struct {
+	plugins.ZoneMgrPlugin
+}
+
+func (p *BuiltinPluginSRV) PluginVersion() (string, error) {
+	return utils.Version(), nil
+}
+
+func (p *BuiltinPluginSRV) PluginTypes() ([]plugins.Type, error) {
+	return plugins.PluginTypes(plugins.SRV), nil
+}
+
+func (p *BuiltinPluginSRV) Configure(config *models.Config) error {
+	// no config
+	return nil
+}
+
+func (p *BuiltinPluginSRV) Normalize(identifier string, rr *models.ResourceRecord) error {
+	if err := validations.CommonValidations(identifier, rr, plugins.SRV); err != nil {
+		return err
+	}
+
+	if rr.Name == "" {
+		rr.Name = identifier
+	}
+
+	if err := validations.EnsureValidUnderscoreName(identifier, rr.Name, rr.Type); err != nil {
+		return err
+	}
+
+	// The name of an SRV record is always _Service._Proto.Name (RFC2782)
+	labels := strings.Split(rr.Name, ".")
+	if len(labels) < 2 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+		return fmt.Errorf("invalid %s record, name must start with the service and protocol labels (e.g. _sip._tcp): '%s', identifier: '%s'", rr.Type, rr.Name, identifier)
+	}
+
+	values, err := srvValues(identifier, rr)
+	if err != nil {
+		return err
+	}
+
+	target := values[3]
+	if target == srvServiceNotAvailable {
+		return nil
+	}
+
+	// The target must be a name, RFC2782 doesn't allow an IP address or an alias
+	if err := validations.EnsureNotIP(identifier, target, rr.Type); err != nil {
+		return err
+	}
+
+	if err := validations.EnsureFullyQualified(identifier, target, rr.Type); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *BuiltinPluginSRV) ValidateZone(name string, zone *models.Zone) error {
+	names := models.NewZoneNames(name, zone)
+	var errs []error
+	zone.WithSortedResourceRecords(func(identifier string, rr *models.ResourceRecord) error {
+		if rr.Type != models.SRV {
+			return nil
+		}
+		values, err := srvValues(identifier, rr)
+		if err != nil {
+			errs = append(errs, plugins.NewRecordError(identifier, err))
+			return nil
+		}
+
+		target := values[3]
+		if names.Owns(names.Resolve(target), models.CNAME) {
+			errs = append(errs, plugins.NewRecordError(identifier, fmt.Errorf("invalid SRV record, '%s' has a target of '%s' which is a CNAME, the target must be the name of an address record, zone: '%s'", identifier, target, name)))
+		}
+		return nil
+	})
+
+	return errors.Join(errs...)
+}
+
+func (p *BuiltinPluginSRV) Render(identifier string, rr *models.ResourceRecord) (string, error) {
+	if err := validations.EnsureSupportedPluginType(identifier, rr.Type, plugins.SRV); err != nil {
+		return "", err
+	}
+
+	values, err := srvValues(identifier, rr)
+	if err != nil {
+		return "", err
+	}
+
+	var record strings.Builder
+	record.WriteString(rr.RenderResourceWithoutValue())
+	for _, value := range values[:3] {
+		fmt.Fprintf(&record, srvNumberFormatString, value)
+		record.WriteString(" ")
+	}
+	record.WriteString(values[3])
+
+	if comment := rdataComment(rr); comment != "" {
+		record.WriteString(" ;")
+		record.WriteString(comment)
+	}
+
+	return record.String(), nil
+}
+
+// Returns the priority, weight, port and target of the resource record, in that order
+func srvValues(identifier string, rr *models.ResourceRecord) ([]string, error) {
+	fieldNames := []string{"priority", "weight", "port", "target"}
+	values, err := rdataFields(identifier, rr, fieldNames...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, fieldName := range fieldNames[:3] {
+		if err := ensureUint16(identifier, values[i], fieldName, rr.Type); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func init() {
+	registerBuiltIn(plugins.SRV, &BuiltinPluginSRV{})
+}
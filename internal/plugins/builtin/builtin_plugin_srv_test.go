@@ -0,0 +1,221 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package builtin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bcurnow/zonemgr/models"
+)
+
+func srvRenderPrefix(name string) string {
+	return fmt.Sprintf(models.ResourceRecordNameFormatString, name) + " " + fmt.Sprintf(models.ResourceRecordTypeFormatString, models.SRV) + " "
+}
+
+func srvValuesOf(priority string, weight string, port string, target string) []*models.ResourceRecordValue {
+	return []*models.ResourceRecordValue{{Value: priority}, {Value: weight}, {Value: port}, {Value: target}}
+}
+
+func TestSRVNormalize(t *testing.T) {
+	testCases := []struct {
+		name       string
+		identifier string
+		rr         *models.ResourceRecord
+		wantName   string
+		wantErr    string
+	}{
+		{
+			name:       "valid-values",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.SRV, Name: "_sip._tcp", Values: srvValuesOf("10", "60", "5060", "sip.example.com.")},
+			wantName:   "_sip._tcp",
+		},
+		{
+			name:       "valid-value",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.SRV, Name: "_ldap._tcp.example.com.", Value: "0 0 389 dc.example.com."},
+			wantName:   "_ldap._tcp.example.com.",
+		},
+		{
+			name:       "name-defaults-to-identifier",
+			identifier: "_kerberos._udp.dc",
+			rr:         &models.ResourceRecord{Type: models.SRV, Value: "0 0 88 dc.example.com."},
+			wantName:   "_kerberos._udp.dc",
+		},
+		{
+			name:       "service-not-available",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.SRV, Name: "_imap._tcp", Value: "0 0 0 ."},
+			wantName:   "_imap._tcp",
+		},
+		{
+			name:       "wrong-type",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.A, Name: "_sip._tcp", Value: "10 60 5060 sip.example.com."},
+			wantErr:    "this plugin does not handle resource records of type 'A' only '[SRV]', identifier: 'record1'",
+		},
+		{
+			name:       "invalid-name",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.SRV, Name: "_sip-._tcp", Value: "10 60 5060 sip.example.com."},
+			wantErr:    "invalid SRV record, label '_sip-' does not match regexp '^_[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?$': '_sip-._tcp', identifier: 'record1'",
+		},
+		{
+			name:       "missing-protocol",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.SRV, Name: "_sip.example.com.", Value: "10 60 5060 sip.example.com."},
+			wantErr:    "invalid SRV record, name must start with the service and protocol labels (e.g. _sip._tcp): '_sip.example.com.', identifier: 'record1'",
+		},
+		{
+			name:       "missing-service-and-protocol",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.SRV, Name: "@", Value: "10 60 5060 sip.example.com."},
+			wantErr:    "invalid SRV record, name must start with the service and protocol labels (e.g. _sip._tcp): '@', identifier: 'record1'",
+		},
+		{
+			name:       "missing-values",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.SRV, Name: "_sip._tcp", Value: "10 5060 sip.example.com."},
+			wantErr:    "invalid SRV record, must have exactly 4 values (priority, weight, port, target), found 3, identifier: 'record1'",
+		},
+		{
+			name:       "invalid-priority",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.SRV, Name: "_sip._tcp", Value: "-1 60 5060 sip.example.com."},
+			wantErr:    "invalid SRV record, priority must be a number between 0 and 65535: '-1', identifier: 'record1'",
+		},
+		{
+			name:       "invalid-weight",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.SRV, Name: "_sip._tcp", Values: srvValuesOf("10", "heavy", "5060", "sip.example.com.")},
+			wantErr:    "invalid SRV record, weight must be a number between 0 and 65535: 'heavy', identifier: 'record1'",
+		},
+		{
+			name:       "invalid-port",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.SRV, Name: "_sip._tcp", Value: "10 60 70000 sip.example.com."},
+			wantErr:    "invalid SRV record, port must be a number between 0 and 65535: '70000', identifier: 'record1'",
+		},
+		{
+			name:       "target-is-ip",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.SRV, Name: "_sip._tcp", Value: "10 60 5060 1.2.3.4"},
+			wantErr:    "invalid SRV record, '1.2.3.4' must not be an IP address, identifier: 'record1'",
+		},
+		{
+			name:       "target-not-fqdn",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.SRV, Name: "_sip._tcp", Value: "10 60 5060 sip"},
+			wantErr:    "invalid SRV record, must end with a trailing dot: 'sip', identifier: 'record1'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&BuiltinPluginSRV{}).Normalize(tc.identifier, tc.rr)
+			checkErr(t, err, tc.wantErr)
+			if tc.wantErr == "" && tc.rr.Name != tc.wantName {
+				t.Errorf("incorrect name: '%s', want: '%s'", tc.rr.Name, tc.wantName)
+			}
+		})
+	}
+}
+
+func TestSRVValidateZone(t *testing.T) {
+	testCases := []struct {
+		name    string
+		records map[string]*models.ResourceRecord
+		wantErr string
+	}{
+		{
+			name: "no-records",
+		},
+		{
+			name: "target-is-address-record",
+			records: map[string]*models.ResourceRecord{
+				"sip":      {Type: models.A, Name: "sip", Value: "1.2.3.4"},
+				"www":      {Type: models.CNAME, Name: "www", Value: "sip"},
+				"_sip":     {Type: models.SRV, Name: "_sip._tcp", Value: "10 60 5060 sip.example.com."},
+				"_sip-off": {Type: models.SRV, Name: "_sips._tcp", Value: "0 0 0 ."},
+			},
+		},
+		{
+			name: "target-is-cname",
+			records: map[string]*models.ResourceRecord{
+				"sip":  {Type: models.CNAME, Name: "sip", Value: "www"},
+				"_sip": {Type: models.SRV, Name: "_sip._tcp", Values: srvValuesOf("10", "60", "5060", "sip.example.com.")},
+			},
+			wantErr: "invalid SRV record, '_sip' has a target of 'sip.example.com.' which is a CNAME, the target must be the name of an address record, zone: 'example.com.'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&BuiltinPluginSRV{}).ValidateZone("example.com.", &models.Zone{ResourceRecords: tc.records})
+			checkErr(t, err, tc.wantErr)
+			checkRecordErrors(t, err)
+		})
+	}
+}
+
+func TestSRVRender(t *testing.T) {
+	testCases := []struct {
+		name       string
+		identifier string
+		rr         *models.ResourceRecord
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "values",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.SRV, Name: "_sip._tcp", Values: srvValuesOf("10", "60", "5060", "sip.example.com.")},
+			want:       srvRenderPrefix("_sip._tcp") + "10    60    5060  sip.example.com.",
+		},
+		{
+			name:       "value-with-comment",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.SRV, Name: "_imap._tcp", Value: "0 0 0 .", Comment: "no imap"},
+			want:       srvRenderPrefix("_imap._tcp") + "0     0     0     . ;no imap",
+		},
+		{
+			name:       "wrong-type",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.A, Name: "_sip._tcp"},
+			wantErr:    "this plugin does not handle resource records of type 'A' only '[SRV]', identifier: 'record1'",
+		},
+		{
+			name:       "invalid-values",
+			identifier: "record1",
+			rr:         &models.ResourceRecord{Type: models.SRV, Name: "_sip._tcp", Value: "sip.example.com."},
+			wantErr:    "invalid SRV record, must have exactly 4 values (priority, weight, port, target), found 1, identifier: 'record1'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := (&BuiltinPluginSRV{}).Render(tc.identifier, tc.rr)
+			checkErr(t, err, tc.wantErr)
+			if tc.wantErr == "" && got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -41,10 +41,13 @@ func TestBuiltinPlugins(t *testing.T) {
 	}{
 		{pluginType: plugins.A, expectedInterface: &BuiltinPluginA{}},
 		{pluginType: plugins.AAAA, expectedInterface: &BuiltinPluginAAAA{}},
+		{pluginType: plugins.CAA, expectedInterface: &BuiltinPluginCAA{}},
 		{pluginType: plugins.CNAME, expectedInterface: &BuiltinPluginCNAME{}},
+		{pluginType: plugins.MX, expectedInterface: &BuiltinPluginMX{}},
 		{pluginType: plugins.NS, expectedInterface: &BuiltinPluginNS{}},
 		{pluginType: plugins.PTR, expectedInterface: &BuiltinPluginPTR{}},
 		{pluginType: plugins.SOA, expectedInterface: &BuiltinPluginSOA{}},
+		{pluginType: plugins.SRV, expectedInterface: &BuiltinPluginSRV{}},
 		{pluginType: plugins.TXT, expectedInterface: &BuiltinPluginTXT{}},
 	}
 
@@ -53,7 +56,7 @@ func TestBuiltinPlugins(t *testing.T) {
 		if !ok {
 			t.Errorf("expected to find plugin of type %s", tc.pluginType)
 		} else {
-			if !cmp.Equal(p, tc.expectedInterface, cmpopts.IgnoreUnexported(BuiltinPluginSOA{})) {
+			if !cmp.Equal(p, tc.expectedInterface, cmpopts.IgnoreUnexported(BuiltinPluginNS{}, BuiltinPluginSOA{})) {
 				t.Errorf("expected plugin of type %s to implement %T, but was %T instead", tc.pluginType, tc.expectedInterface, p)
 			}
 
@@ -75,10 +78,13 @@ func TestBuiltinMetadata(t *testing.T) {
 	}{
 		{pluginType: plugins.A, expectedMetadata: &plugins.Metadata{Name: string(plugins.A), Command: "Built In", BuiltIn: true}},
 		{pluginType: plugins.AAAA, expectedMetadata: &plugins.Metadata{Name: string(plugins.AAAA), Command: "Built In", BuiltIn: true}},
+		{pluginType: plugins.CAA, expectedMetadata: &plugins.Metadata{Name: string(plugins.CAA), Command: "Built In", BuiltIn: true}},
 		{pluginType: plugins.CNAME, expectedMetadata: &plugins.Metadata{Name: string(plugins.CNAME), Command: "Built In", BuiltIn: true}},
+		{pluginType: plugins.MX, expectedMetadata: &plugins.Metadata{Name: string(plugins.MX), Command: "Built In", BuiltIn: true}},
 		{pluginType: plugins.NS, expectedMetadata: &plugins.Metadata{Name: string(plugins.NS), Command: "Built In", BuiltIn: true}},
 		{pluginType: plugins.PTR, expectedMetadata: &plugins.Metadata{Name: string(plugins.PTR), Command: "Built In", BuiltIn: true}},
 		{pluginType: plugins.SOA, expectedMetadata: &plugins.Metadata{Name: string(plugins.SOA), Command: "Built In", BuiltIn: true}},
+		{pluginType: plugins.SRV, expectedMetadata: &plugins.Metadata{Name: string(plugins.SRV), Command: "Built In", BuiltIn: true}},
 		{pluginType: plugins.TXT, expectedMetadata: &plugins.Metadata{Name: string(plugins.TXT), Command: "Built In", BuiltIn: true}},
 	}
 
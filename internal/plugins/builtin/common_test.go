@@ -60,3 +60,14 @@ func checkErr(t *testing.T, err error, wantErr string) {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+// Every problem ValidateZone finds with a record must carry the identifier of the record
+func checkRecordErrors(t *testing.T, err error) {
+	t.Helper()
+	for _, problem := range plugins.Problems(err) {
+		var recordErr *plugins.RecordError
+		if !errors.As(problem, &recordErr) || recordErr.Identifier == "" {
+			t.Errorf("expected a record error with an identifier, got %q", problem)
+		}
+	}
+}
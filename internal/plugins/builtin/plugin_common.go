@@ -18,6 +18,54 @@
  */
 package builtin
 
-import "github.com/bcurnow/zonemgr/plugins"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bcurnow/zonemgr/models"
+	"github.com/bcurnow/zonemgr/plugins"
+)
 
 var validations plugins.Validator = plugins.V()
+
+// Returns the RDATA fields of a record type with a fixed number of fields. These are either the entries in Values or
+// the single Value shortcut written the way it would be in a zone file (e.g. "10 mail.example.com.").
+func rdataFields(identifier string, rr *models.ResourceRecord, fieldNames ...string) ([]string, error) {
+	var fields []string
+	if len(rr.Values) > 0 {
+		for _, v := range rr.Values {
+			fields = append(fields, v.Value)
+		}
+	} else {
+		fields = strings.Fields(rr.Value)
+	}
+
+	if len(fields) != len(fieldNames) {
+		return nil, fmt.Errorf("invalid %s record, must have exactly %d values (%s), found %d, identifier: '%s'", rr.Type, len(fieldNames), strings.Join(fieldNames, ", "), len(fields), identifier)
+	}
+	return fields, nil
+}
+
+// When Values is used, each value can carry its own comment, these are combined as there's only one line to put them on
+func rdataComment(rr *models.ResourceRecord) string {
+	if len(rr.Values) == 0 {
+		return rr.Comment
+	}
+
+	var comments []string
+	for _, v := range rr.Values {
+		if v.Comment != "" {
+			comments = append(comments, v.Comment)
+		}
+	}
+	return strings.Join(comments, " ")
+}
+
+// Ensures the value is a 16-bit unsigned integer, the size used for priorities, weights and ports
+func ensureUint16(identifier string, value string, fieldName string, rrType models.ResourceRecordType) error {
+	if _, err := strconv.ParseUint(value, 10, 16); err != nil {
+		return fmt.Errorf("invalid %s record, %s must be a number between 0 and 65535: '%s', identifier: '%s'", rrType, fieldName, value, identifier)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+// Defines how the targets of the CNAME records of a zone are checked, a target is followed through every zone in the
+// input the way a resolver would
+type CNAMEPolicy string
+
+const (
+	// A target in one of the zones must exist and can't be part of a loop, a target outside of them isn't checked
+	CNAMEPolicyManaged CNAMEPolicy = "managed"
+	// Every target must be in one of the zones and must end at an A or AAAA record
+	CNAMEPolicyStrict CNAMEPolicy = "strict"
+	// The targets aren't checked
+	CNAMEPolicyNone CNAMEPolicy = "none"
+)
+
+func (cp CNAMEPolicy) IsValid() bool {
+	switch cp {
+	case CNAMEPolicyManaged, CNAMEPolicyStrict, CNAMEPolicyNone, "": // Empty will use the default policy (managed)
+		return true
+	default:
+		return false
+	}
+}
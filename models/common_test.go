@@ -26,3 +26,7 @@ func toInt32Ptr(i int32) *int32 {
 func toUint32Ptr(i uint32) *uint32 {
 	return &i
 }
+
+func toBoolPtr(b bool) *bool {
+	return &b
+}
@@ -16,18 +16,176 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 */
 package models
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
 
 type Config struct {
-	GenerateSerial bool `yaml:"generate_serial" validate:"boolean"`
-	// Not validated as a dirpath: FileSerialManager.Next() already creates this directory itself via
+	GenerateSerial bool `yaml:"generate_serial,omitempty" validate:"boolean"`
+	// Not validated as a dirpath: FileSerialManager.Commit() already creates this directory itself via
 	// MkdirAll, so requiring it to pre-exist is unnecessary and breaks first-time setup.
-	SerialChangeIndexDirectory string `yaml:"serial_change_index_directory" validate:"omitempty"`
-	GenerateReverseLookupZones bool   `yaml:"generate_reverse_lookup_zones" validate:"boolean"`
-	IsCatalog                  bool   `yaml:"is_catalog" validate:"boolean"`
-	CatalogIncludeReverseZones bool   `yaml:"catalog_include_reverse_zones" validate:"boolean"`
+	SerialChangeIndexDirectory string `yaml:"serial_change_index_directory,omitempty" validate:"omitempty"`
+	GenerateReverseLookupZones bool   `yaml:"generate_reverse_lookup_zones,omitempty" validate:"boolean"`
+	IsCatalog                  bool   `yaml:"is_catalog,omitempty" validate:"boolean"`
+	CatalogIncludeReverseZones bool   `yaml:"catalog_include_reverse_zones,omitempty" validate:"boolean"`
+	// The order of the resource records after the SOA and apex NS records, defaults to identifier
+	RecordOrder RecordOrder `yaml:"record_order,omitempty" validate:"omitempty,oneof=name type identifier"`
+	// Render TTLs and SOA time intervals the way they were written (e.g. 1h) instead of as a number of seconds
+	KeepTimeUnits bool `yaml:"keep_time_units,omitempty" validate:"boolean"`
+	// Which PTR records are generated for an address used by more than one A or AAAA record, defaults to error
+	PTRPolicy PTRPolicy `yaml:"ptr_policy,omitempty" validate:"omitempty,oneof=error first all"`
+	// The networks (e.g. 10.0.0.0/16) the reverse lookup zones are generated for, an address that isn't in any of them
+	// uses a /24 for IPv4 and a /64 for IPv6
+	ReverseZones []string `yaml:"reverse_zones,omitempty" validate:"omitempty,dive,cidr"`
+	// How the targets of the CNAME records are checked, defaults to managed
+	CNAMEPolicy CNAMEPolicy `yaml:"cname_policy,omitempty" validate:"omitempty,oneof=managed strict none"`
+	// What happens to the delegations of the child zones of this zone that are in the input, defaults to generate
+	DelegationPolicy DelegationPolicy `yaml:"delegation_policy,omitempty" validate:"omitempty,oneof=generate verify none"`
+	// The keys that were present in the YAML, only these override the defaults (see WithDefaults)
+	keys map[string]bool
+}
+
+// Records which keys were present so an explicit false (or empty) value can still override a default
+func (c *Config) UnmarshalYAML(node *yaml.Node) error {
+	type plain Config
+	if err := node.Decode((*plain)(c)); err != nil {
+		return err
+	}
+
+	c.keys = make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		c.keys[node.Content[i].Value] = true
+	}
+	return checkKnownKeys(node, reflect.TypeOf(plain{}), "models.Config")
+}
+
+// Decoding into a plain type loses the strict decoding of the caller, this reports the keys of the mapping node that
+// aren't fields of t the same way the strict decoding does
+func checkKnownKeys(node *yaml.Node, t reflect.Type, typeName string) error {
+	knownKeys := yamlKeys(t)
+	var unknownKeys []string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if key := node.Content[i]; !knownKeys[key.Value] {
+			unknownKeys = append(unknownKeys, fmt.Sprintf("line %d: field %s not found in type %s", key.Line, key.Value, typeName))
+		}
+	}
+	if len(unknownKeys) > 0 {
+		return &yaml.TypeError{Errors: unknownKeys}
+	}
+	return nil
+}
+
+func yamlKeys(t reflect.Type) map[string]bool {
+	keys := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ","); name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}
+
+// Returns a new config where each setting that isn't set on this config is taken from defaults. When the config
+// was read from YAML, a setting is set if its key was present, otherwise it's set if it isn't the zero value.
+// The returned config doesn't record any keys so defaults should only be applied once.
+// IsCatalog is never taken from defaults, being a catalog zone is a property of a specific zone.
+func (c *Config) WithDefaults(defaults *Config) *Config {
+	if nil == defaults {
+		defaults = &Config{}
+	}
+	if nil == c {
+		c = &Config{}
+	}
+
+	merged := &Config{IsCatalog: c.IsCatalog}
+	merged.GenerateSerial = pick(c.isSet("generate_serial", !c.GenerateSerial), c.GenerateSerial, defaults.GenerateSerial)
+	merged.SerialChangeIndexDirectory = pick(c.isSet("serial_change_index_directory", c.SerialChangeIndexDirectory == ""), c.SerialChangeIndexDirectory, defaults.SerialChangeIndexDirectory)
+	merged.GenerateReverseLookupZones = pick(c.isSet("generate_reverse_lookup_zones", !c.GenerateReverseLookupZones), c.GenerateReverseLookupZones, defaults.GenerateReverseLookupZones)
+	merged.CatalogIncludeReverseZones = pick(c.isSet("catalog_include_reverse_zones", !c.CatalogIncludeReverseZones), c.CatalogIncludeReverseZones, defaults.CatalogIncludeReverseZones)
+	merged.RecordOrder = pick(c.isSet("record_order", c.RecordOrder == ""), c.RecordOrder, defaults.RecordOrder)
+	merged.KeepTimeUnits = pick(c.isSet("keep_time_units", !c.KeepTimeUnits), c.KeepTimeUnits, defaults.KeepTimeUnits)
+	merged.PTRPolicy = pick(c.isSet("ptr_policy", c.PTRPolicy == ""), c.PTRPolicy, defaults.PTRPolicy)
+	merged.ReverseZones = pick(c.isSet("reverse_zones", len(c.ReverseZones) == 0), c.ReverseZones, defaults.ReverseZones)
+	merged.CNAMEPolicy = pick(c.isSet("cname_policy", c.CNAMEPolicy == ""), c.CNAMEPolicy, defaults.CNAMEPolicy)
+	merged.DelegationPolicy = pick(c.isSet("delegation_policy", c.DelegationPolicy == ""), c.DelegationPolicy, defaults.DelegationPolicy)
+	return merged
+}
+
+// Returns a new config where each setting that isn't set on this config is inherited from parent (see Zone.Extends).
+// Unlike WithDefaults, the returned config records the keys set on either config so the defaults can still be applied
+// afterwards. IsCatalog is never inherited for the same reason it's never taken from defaults.
+func (c *Config) Inherit(parent *Config) *Config {
+	if nil == parent {
+		return c
+	}
+	if nil == c {
+		c = &Config{}
+	}
+
+	merged := c.WithDefaults(parent)
+	merged.keys = make(map[string]bool)
+	parentKeys := parent.setKeys()
+	for key, set := range c.setKeys() {
+		if set || (parentKeys[key] && key != "is_catalog") {
+			merged.keys[key] = true
+		}
+	}
+	return merged
+}
+
+func (c *Config) setKeys() map[string]bool {
+	return map[string]bool{
+		"generate_serial":               c.isSet("generate_serial", !c.GenerateSerial),
+		"serial_change_index_directory": c.isSet("serial_change_index_directory", c.SerialChangeIndexDirectory == ""),
+		"generate_reverse_lookup_zones": c.isSet("generate_reverse_lookup_zones", !c.GenerateReverseLookupZones),
+		"is_catalog":                    c.isSet("is_catalog", !c.IsCatalog),
+		"catalog_include_reverse_zones": c.isSet("catalog_include_reverse_zones", !c.CatalogIncludeReverseZones),
+		"record_order":                  c.isSet("record_order", c.RecordOrder == ""),
+		"keep_time_units":               c.isSet("keep_time_units", !c.KeepTimeUnits),
+		"ptr_policy":                    c.isSet("ptr_policy", c.PTRPolicy == ""),
+		"reverse_zones":                 c.isSet("reverse_zones", len(c.ReverseZones) == 0),
+		"cname_policy":                  c.isSet("cname_policy", c.CNAMEPolicy == ""),
+		"delegation_policy":             c.isSet("delegation_policy", c.DelegationPolicy == ""),
+	}
+}
+
+// Configs are equal when their settings are, it doesn't matter where the settings came from
+func (c *Config) Equal(other *Config) bool {
+	if nil == c || nil == other {
+		return c == other
+	}
+	return c.GenerateSerial == other.GenerateSerial &&
+		c.SerialChangeIndexDirectory == other.SerialChangeIndexDirectory &&
+		c.GenerateReverseLookupZones == other.GenerateReverseLookupZones &&
+		c.IsCatalog == other.IsCatalog &&
+		c.CatalogIncludeReverseZones == other.CatalogIncludeReverseZones &&
+		c.RecordOrder == other.RecordOrder &&
+		c.KeepTimeUnits == other.KeepTimeUnits &&
+		c.PTRPolicy == other.PTRPolicy &&
+		slices.Equal(c.ReverseZones, other.ReverseZones) &&
+		c.CNAMEPolicy == other.CNAMEPolicy &&
+		c.DelegationPolicy == other.DelegationPolicy
+}
+
+func (c *Config) isSet(key string, isZero bool) bool {
+	if c.keys != nil {
+		return c.keys[key]
+	}
+	return !isZero
+}
+
+func pick[T any](useValue bool, value T, defaultValue T) T {
+	if useValue {
+		return value
+	}
+	return defaultValue
 }
 
 func (c *Config) String() string {
-	return fmt.Sprintf("Config{ GenerateSerial: %t, GenerateReverseLookupZones: %t, SerialChangeIndexDirectory: %s, IsCatalog: %t, CatalogIncludeReverseZones: %t }", c.GenerateSerial, c.GenerateReverseLookupZones, c.SerialChangeIndexDirectory, c.IsCatalog, c.CatalogIncludeReverseZones)
+	return fmt.Sprintf("Config{ GenerateSerial: %t, GenerateReverseLookupZones: %t, SerialChangeIndexDirectory: %s, IsCatalog: %t, CatalogIncludeReverseZones: %t, RecordOrder: %s, KeepTimeUnits: %t, PTRPolicy: %s, ReverseZones: %v, CNAMEPolicy: %s, DelegationPolicy: %s }", c.GenerateSerial, c.GenerateReverseLookupZones, c.SerialChangeIndexDirectory, c.IsCatalog, c.CatalogIncludeReverseZones, c.RecordOrder, c.KeepTimeUnits, c.PTRPolicy, c.ReverseZones, c.CNAMEPolicy, c.DelegationPolicy)
 }
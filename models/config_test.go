@@ -19,7 +19,11 @@
 
 package models
 
-import "testing"
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
 
 func TestString_Config(t *testing.T) {
 	c := &Config{
@@ -28,16 +32,153 @@ func TestString_Config(t *testing.T) {
 		SerialChangeIndexDirectory: "testing",
 		IsCatalog:                  true,
 		CatalogIncludeReverseZones: true,
+		RecordOrder:                RecordOrderName,
+		KeepTimeUnits:              true,
+		PTRPolicy:                  PTRPolicyFirst,
+		ReverseZones:               []string{"10.0.0.0/16", "192.0.2.0/26"},
+		CNAMEPolicy:                CNAMEPolicyStrict,
+		DelegationPolicy:           DelegationPolicyVerify,
 	}
 
-	want := "Config{ GenerateSerial: true, GenerateReverseLookupZones: true, SerialChangeIndexDirectory: testing, IsCatalog: true, CatalogIncludeReverseZones: true }"
+	want := "Config{ GenerateSerial: true, GenerateReverseLookupZones: true, SerialChangeIndexDirectory: testing, IsCatalog: true, CatalogIncludeReverseZones: true, RecordOrder: name, KeepTimeUnits: true, PTRPolicy: first, ReverseZones: [10.0.0.0/16 192.0.2.0/26], CNAMEPolicy: strict, DelegationPolicy: verify }"
 	if c.String() != want {
 		t.Errorf("incorrect string:\n%s\nwant:\n%s", c.String(), want)
 	}
 
 	c = &Config{}
-	want = "Config{ GenerateSerial: false, GenerateReverseLookupZones: false, SerialChangeIndexDirectory: , IsCatalog: false, CatalogIncludeReverseZones: false }"
+	want = "Config{ GenerateSerial: false, GenerateReverseLookupZones: false, SerialChangeIndexDirectory: , IsCatalog: false, CatalogIncludeReverseZones: false, RecordOrder: , KeepTimeUnits: false, PTRPolicy: , ReverseZones: [], CNAMEPolicy: , DelegationPolicy:  }"
 	if c.String() != want {
 		t.Errorf("incorrect string:\n%s\nwant:\n%s", c.String(), want)
 	}
 }
+
+func TestWithDefaults(t *testing.T) {
+	defaults := &Config{
+		GenerateSerial:             true,
+		SerialChangeIndexDirectory: "/defaults",
+		GenerateReverseLookupZones: true,
+		IsCatalog:                  true,
+		CatalogIncludeReverseZones: true,
+		RecordOrder:                RecordOrderType,
+	}
+
+	testCases := []struct {
+		name     string
+		yaml     string
+		config   *Config
+		defaults *Config
+		want     *Config
+	}{
+		{name: "nil-config", defaults: defaults, want: &Config{GenerateSerial: true, SerialChangeIndexDirectory: "/defaults", GenerateReverseLookupZones: true, CatalogIncludeReverseZones: true, RecordOrder: RecordOrderType}},
+		{name: "nil-defaults", config: &Config{GenerateSerial: true}, want: &Config{GenerateSerial: true}},
+		{name: "nil-both", want: &Config{}},
+		{name: "zero-values-use-defaults", config: &Config{IsCatalog: true}, defaults: defaults, want: &Config{GenerateSerial: true, SerialChangeIndexDirectory: "/defaults", GenerateReverseLookupZones: true, IsCatalog: true, CatalogIncludeReverseZones: true, RecordOrder: RecordOrderType}},
+		{name: "values-override-defaults", config: &Config{SerialChangeIndexDirectory: "/zone", RecordOrder: RecordOrderName}, defaults: defaults, want: &Config{GenerateSerial: true, SerialChangeIndexDirectory: "/zone", GenerateReverseLookupZones: true, CatalogIncludeReverseZones: true, RecordOrder: RecordOrderName}},
+		{name: "yaml-keys-override-defaults", yaml: "generate_serial: false\nrecord_order: identifier\n", defaults: defaults, want: &Config{SerialChangeIndexDirectory: "/defaults", GenerateReverseLookupZones: true, CatalogIncludeReverseZones: true, RecordOrder: RecordOrderIdentifier}},
+		{name: "keep-time-units-from-defaults", config: &Config{}, defaults: &Config{KeepTimeUnits: true}, want: &Config{KeepTimeUnits: true}},
+		{name: "yaml-keep-time-units-overrides-default", yaml: "keep_time_units: false\n", defaults: &Config{KeepTimeUnits: true}, want: &Config{}},
+		{name: "ptr-policy-from-defaults", config: &Config{}, defaults: &Config{PTRPolicy: PTRPolicyAll}, want: &Config{PTRPolicy: PTRPolicyAll}},
+		{name: "reverse-zones-from-defaults", config: &Config{}, defaults: &Config{ReverseZones: []string{"10.0.0.0/16"}}, want: &Config{ReverseZones: []string{"10.0.0.0/16"}}},
+		{name: "yaml-reverse-zones-override-default", yaml: "reverse_zones: [192.0.2.0/26]\n", defaults: &Config{ReverseZones: []string{"10.0.0.0/16"}}, want: &Config{ReverseZones: []string{"192.0.2.0/26"}}},
+		{name: "cname-policy-from-defaults", config: &Config{}, defaults: &Config{CNAMEPolicy: CNAMEPolicyStrict}, want: &Config{CNAMEPolicy: CNAMEPolicyStrict}},
+		{name: "delegation-policy-from-defaults", config: &Config{}, defaults: &Config{DelegationPolicy: DelegationPolicyNone}, want: &Config{DelegationPolicy: DelegationPolicyNone}},
+		{name: "yaml-empty-string-overrides-default", yaml: "serial_change_index_directory: \"\"\n", defaults: defaults, want: &Config{GenerateSerial: true, GenerateReverseLookupZones: true, CatalogIncludeReverseZones: true, RecordOrder: RecordOrderType}},
+	}
+
+	for _, tc := range testCases {
+		config := tc.config
+		if tc.yaml != "" {
+			config = &Config{}
+			if err := yaml.Unmarshal([]byte(tc.yaml), config); err != nil {
+				t.Fatalf("%s - unexpected error: %s", tc.name, err)
+			}
+		}
+
+		got := config.WithDefaults(tc.defaults)
+		if !got.Equal(tc.want) {
+			t.Errorf("%s - incorrect config: %s, want: %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestInherit_Config(t *testing.T) {
+	fromYaml := func(content string) *Config {
+		config := &Config{}
+		if err := yaml.Unmarshal([]byte(content), config); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return config
+	}
+	defaults := &Config{GenerateSerial: true, SerialChangeIndexDirectory: "/defaults", RecordOrder: RecordOrderType}
+
+	testCases := []struct {
+		name         string
+		config       *Config
+		parent       *Config
+		want         *Config
+		wantDefaults *Config
+	}{
+		{name: "nil-both"},
+		{name: "nil-parent", config: &Config{GenerateSerial: true}, want: &Config{GenerateSerial: true}, wantDefaults: &Config{GenerateSerial: true, SerialChangeIndexDirectory: "/defaults", RecordOrder: RecordOrderType}},
+		{name: "nil-config", parent: &Config{GenerateReverseLookupZones: true, IsCatalog: true}, want: &Config{GenerateReverseLookupZones: true}, wantDefaults: &Config{GenerateSerial: true, SerialChangeIndexDirectory: "/defaults", GenerateReverseLookupZones: true, RecordOrder: RecordOrderType}},
+		{
+			name:   "yaml-keys-override-parent",
+			config: fromYaml("generate_serial: false\n"),
+			parent: fromYaml("generate_serial: true\nrecord_order: name\n"),
+			want:   &Config{RecordOrder: RecordOrderName},
+			// The keys set on either config still override the defaults
+			wantDefaults: &Config{SerialChangeIndexDirectory: "/defaults", RecordOrder: RecordOrderName},
+		},
+	}
+
+	for _, tc := range testCases {
+		got := tc.config.Inherit(tc.parent)
+		if !got.Equal(tc.want) {
+			t.Errorf("%s - incorrect config: %s, want: %s", tc.name, got, tc.want)
+		}
+		if tc.wantDefaults != nil {
+			if withDefaults := got.WithDefaults(defaults); !withDefaults.Equal(tc.wantDefaults) {
+				t.Errorf("%s - incorrect config with defaults: %s, want: %s", tc.name, withDefaults, tc.wantDefaults)
+			}
+		}
+	}
+}
+
+func TestUnmarshalYAML_Config(t *testing.T) {
+	config := &Config{}
+	if err := yaml.Unmarshal([]byte("generate_serial: true\nrecord_order: name\n"), config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !config.Equal(&Config{GenerateSerial: true, RecordOrder: RecordOrderName}) {
+		t.Errorf("incorrect config: %s", config)
+	}
+
+	err := yaml.Unmarshal([]byte("generate_serial: true\nbogus: true\n"), &Config{})
+	want := "yaml: unmarshal errors:\n  line 2: field bogus not found in type models.Config"
+	if err == nil || err.Error() != want {
+		t.Errorf("incorrect error: '%v', want: '%s'", err, want)
+	}
+}
+
+func TestEqual_Config(t *testing.T) {
+	testCases := []struct {
+		a    *Config
+		b    *Config
+		want bool
+	}{
+		{want: true},
+		{a: &Config{}, want: false},
+		{b: &Config{}, want: false},
+		{a: &Config{RecordOrder: RecordOrderName}, b: &Config{RecordOrder: RecordOrderName}, want: true},
+		{a: &Config{RecordOrder: RecordOrderName, keys: map[string]bool{"record_order": true}}, b: &Config{RecordOrder: RecordOrderName}, want: true},
+		{a: &Config{IsCatalog: true}, b: &Config{}, want: false},
+		{a: &Config{ReverseZones: []string{"10.0.0.0/16"}}, b: &Config{ReverseZones: []string{"10.0.0.0/16"}}, want: true},
+		{a: &Config{ReverseZones: []string{"10.0.0.0/16"}}, b: &Config{ReverseZones: []string{"10.0.0.0/24"}}, want: false},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.a.Equal(tc.b); got != tc.want {
+			t.Errorf("incorrect result comparing %v and %v: %t, want: %t", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+// Defines what happens to the delegation of a child zone that is in the input with its parent, the delegation is the
+// NS records for the child in the parent and the glue A and AAAA records its name servers need
+type DelegationPolicy string
+
+const (
+	// The records missing from the parent are generated from the child, the ones that are there must match it
+	DelegationPolicyGenerate DelegationPolicy = "generate"
+	// The records must already be in the parent and must match the child
+	DelegationPolicyVerify DelegationPolicy = "verify"
+	// The delegation isn't generated or checked
+	DelegationPolicyNone DelegationPolicy = "none"
+)
+
+func (dp DelegationPolicy) IsValid() bool {
+	switch dp {
+	case DelegationPolicyGenerate, DelegationPolicyVerify, DelegationPolicyNone, "": // Empty will use the default policy (generate)
+		return true
+	default:
+		return false
+	}
+}
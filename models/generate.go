@@ -0,0 +1,103 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The most records a single generate can expand into, a typo in a range shouldn't produce millions of records
+const MaxGeneratedRecords = 65536
+
+// Expands a resource record into a record for each number of a range, the way the BIND $GENERATE directive does.
+// The name and values of the record are templates in which $ is replaced with the number.
+type Generate struct {
+	// The first and last numbers, e.g. 10-250
+	Range string `yaml:"range" validate:"required"`
+	// The difference between one number and the next, defaults to 1
+	Step int `yaml:"step,omitempty" validate:"omitempty,min=1"`
+	// Renders the records as a $GENERATE directive in the zone file instead of a record for each number
+	Directive bool `yaml:"directive,omitempty" validate:"omitempty"`
+}
+
+func (g *Generate) UnmarshalYAML(node *yaml.Node) error {
+	type plain Generate
+	if err := node.Decode((*plain)(g)); err != nil {
+		return err
+	}
+	return checkKnownKeys(node, reflect.TypeOf(plain{}), "models.Generate")
+}
+
+// Returns the numbers of the range in order
+func (g *Generate) Numbers() ([]int, error) {
+	start, stop, err := g.bounds()
+	if err != nil {
+		return nil, err
+	}
+
+	step := g.Step
+	if step == 0 {
+		step = 1
+	}
+	if step < 0 {
+		return nil, fmt.Errorf("invalid generate step %d, must be a positive number", g.Step)
+	}
+
+	if count := (stop-start)/step + 1; count > MaxGeneratedRecords {
+		return nil, fmt.Errorf("invalid generate range '%s', it would generate %d records, the most a range can generate is %d", g.Range, count, MaxGeneratedRecords)
+	}
+
+	var numbers []int
+	for n := start; n <= stop; n += step {
+		numbers = append(numbers, n)
+	}
+	return numbers, nil
+}
+
+// The range the way the $GENERATE directive writes it, e.g. 10-250/2
+func (g *Generate) String() string {
+	if g.Step > 1 {
+		return fmt.Sprintf("%s/%d", g.Range, g.Step)
+	}
+	return g.Range
+}
+
+func (g *Generate) Clone() *Generate {
+	if g == nil {
+		return nil
+	}
+	clone := *g
+	return &clone
+}
+
+func (g *Generate) bounds() (int, int, error) {
+	startText, stopText, ok := strings.Cut(g.Range, "-")
+	start, startErr := strconv.Atoi(startText)
+	stop, stopErr := strconv.Atoi(stopText)
+	if !ok || startErr != nil || stopErr != nil || start < 0 || stop < start {
+		return 0, 0, fmt.Errorf("invalid generate range '%s', must be <start>-<stop> where start and stop are numbers and start isn't more than stop", g.Range)
+	}
+	return start, stop, nil
+}
@@ -0,0 +1,134 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnmarshalYAML_Generate(t *testing.T) {
+	testCases := []struct {
+		name string
+		yaml string
+		want *Generate
+		err  string
+	}{
+		{name: "range", yaml: "range: 10-250\n", want: &Generate{Range: "10-250"}},
+		{name: "all", yaml: "range: 10-250\nstep: 2\ndirective: true\n", want: &Generate{Range: "10-250", Step: 2, Directive: true}},
+		{name: "unknown-key", yaml: "range: 10-250\nsetp: 2\n", err: "yaml: unmarshal errors:\n  line 2: field setp not found in type models.Generate"},
+	}
+
+	for _, tc := range testCases {
+		got := &Generate{}
+		err := yaml.Unmarshal([]byte(tc.yaml), got)
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("%s - incorrect error: '%v', want: '%s'", tc.name, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s - unexpected error: %s", tc.name, err)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s - incorrect generate:\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestNumbers_Generate(t *testing.T) {
+	testCases := []struct {
+		generate *Generate
+		want     []int
+		err      string
+	}{
+		{generate: &Generate{Range: "1-3"}, want: []int{1, 2, 3}},
+		{generate: &Generate{Range: "5-5"}, want: []int{5}},
+		{generate: &Generate{Range: "0-10", Step: 4}, want: []int{0, 4, 8}},
+		{generate: &Generate{Range: "0-65535"}, want: nil},
+		{generate: &Generate{Range: "0-65536"}, err: "invalid generate range '0-65536', it would generate 65537 records, the most a range can generate is 65536"},
+		{generate: &Generate{Range: "5-1"}, err: "invalid generate range '5-1', must be <start>-<stop> where start and stop are numbers and start isn't more than stop"},
+		{generate: &Generate{Range: "10"}, err: "invalid generate range '10', must be <start>-<stop> where start and stop are numbers and start isn't more than stop"},
+		{generate: &Generate{Range: "a-b"}, err: "invalid generate range 'a-b', must be <start>-<stop> where start and stop are numbers and start isn't more than stop"},
+		{generate: &Generate{Range: "1-10", Step: -1}, err: "invalid generate step -1, must be a positive number"},
+	}
+
+	for _, tc := range testCases {
+		got, err := tc.generate.Numbers()
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("%s - incorrect error: '%v', want: '%s'", tc.generate, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s - unexpected error: %s", tc.generate, err)
+			continue
+		}
+		// The full range is too long to write out
+		if tc.want == nil {
+			if len(got) != MaxGeneratedRecords {
+				t.Errorf("%s - incorrect number of numbers: %d, want: %d", tc.generate, len(got), MaxGeneratedRecords)
+			}
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s - incorrect numbers:\n%s", tc.generate, diff)
+		}
+	}
+}
+
+func TestString_Generate(t *testing.T) {
+	testCases := []struct {
+		generate *Generate
+		want     string
+	}{
+		{generate: &Generate{Range: "1-10"}, want: "1-10"},
+		{generate: &Generate{Range: "1-10", Step: 1}, want: "1-10"},
+		{generate: &Generate{Range: "1-10", Step: 3}, want: "1-10/3"},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.generate.String(); got != tc.want {
+			t.Errorf("incorrect string: '%s', want: '%s'", got, tc.want)
+		}
+	}
+}
+
+func TestClone_Generate(t *testing.T) {
+	generate := &Generate{Range: "1-10", Step: 2, Directive: true}
+	clone := generate.Clone()
+	if diff := cmp.Diff(generate, clone); diff != "" {
+		t.Errorf("incorrect clone:\n%s", diff)
+	}
+
+	clone.Range = "1-20"
+	if generate.Range != "1-10" {
+		t.Error("the clone shares data with the original")
+	}
+
+	if (*Generate)(nil).Clone() != nil {
+		t.Error("expected the clone of nil to be nil")
+	}
+}
@@ -22,3 +22,7 @@ package grpc
 func toInt32Ptr(i int32) *int32 {
 	return &i
 }
+
+func toBoolPtr(b bool) *bool {
+	return &b
+}
@@ -15,6 +15,12 @@ func ConfigFromProtoBuf(p *proto.Config, c *models.Config) {
 	c.SerialChangeIndexDirectory = p.SerialChangeIndexDirectory
 	c.IsCatalog = p.IsCatalog
 	c.CatalogIncludeReverseZones = p.CatalogIncludeReverseZones
+	c.RecordOrder = models.RecordOrder(p.RecordOrder)
+	c.KeepTimeUnits = p.KeepTimeUnits
+	c.PTRPolicy = models.PTRPolicy(p.PtrPolicy)
+	c.ReverseZones = p.ReverseZones
+	c.CNAMEPolicy = models.CNAMEPolicy(p.CnamePolicy)
+	c.DelegationPolicy = models.DelegationPolicy(p.DelegationPolicy)
 }
 
 func ConfigToProtoBuf(c *models.Config) *proto.Config {
@@ -28,5 +34,11 @@ func ConfigToProtoBuf(c *models.Config) *proto.Config {
 		SerialChangeIndexDirectory: c.SerialChangeIndexDirectory,
 		IsCatalog:                  c.IsCatalog,
 		CatalogIncludeReverseZones: c.CatalogIncludeReverseZones,
+		RecordOrder:                string(c.RecordOrder),
+		KeepTimeUnits:              c.KeepTimeUnits,
+		PtrPolicy:                  string(c.PTRPolicy),
+		ReverseZones:               c.ReverseZones,
+		CnamePolicy:                string(c.CNAMEPolicy),
+		DelegationPolicy:           string(c.DelegationPolicy),
 	}
 }
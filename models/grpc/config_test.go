@@ -40,6 +40,10 @@ func TestConfigFromProtoBuf(t *testing.T) {
 			config: &models.Config{GenerateSerial: true, GenerateReverseLookupZones: true, SerialChangeIndexDirectory: "testing", IsCatalog: true, CatalogIncludeReverseZones: true},
 			proto:  &proto.Config{GenerateSerial: true, GenerateReverseLookupZones: true, SerialChangeIndexDirectory: "testing", IsCatalog: true, CatalogIncludeReverseZones: true},
 		},
+		{
+			config: &models.Config{RecordOrder: models.RecordOrderType, KeepTimeUnits: true, PTRPolicy: models.PTRPolicyAll, ReverseZones: []string{"192.0.2.0/26"}, CNAMEPolicy: models.CNAMEPolicyStrict, DelegationPolicy: models.DelegationPolicyVerify},
+			proto:  &proto.Config{RecordOrder: "type", KeepTimeUnits: true, PtrPolicy: "all", ReverseZones: []string{"192.0.2.0/26"}, CnamePolicy: "strict", DelegationPolicy: "verify"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -68,6 +72,12 @@ func TestConfigToProtoBuf(t *testing.T) {
 				SerialChangeIndexDirectory: "testing",
 				IsCatalog:                  true,
 				CatalogIncludeReverseZones: true,
+				RecordOrder:                models.RecordOrderType,
+				KeepTimeUnits:              true,
+				PTRPolicy:                  models.PTRPolicyAll,
+				ReverseZones:               []string{"192.0.2.0/26"},
+				CNAMEPolicy:                models.CNAMEPolicyStrict,
+				DelegationPolicy:           models.DelegationPolicyVerify,
 			},
 			proto: &proto.Config{
 				GenerateSerial:             true,
@@ -75,6 +85,12 @@ func TestConfigToProtoBuf(t *testing.T) {
 				SerialChangeIndexDirectory: "testing",
 				IsCatalog:                  true,
 				CatalogIncludeReverseZones: true,
+				RecordOrder:                "type",
+				KeepTimeUnits:              true,
+				PtrPolicy:                  "all",
+				ReverseZones:               []string{"192.0.2.0/26"},
+				CnamePolicy:                "strict",
+				DelegationPolicy:           "verify",
 			},
 		},
 	}
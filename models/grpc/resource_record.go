@@ -16,6 +16,12 @@ func ResourceRecordFromProtoBuf(p *proto.ResourceRecord, rr *models.ResourceReco
 	resourceRecordValuesFromProtoBuf(p, rr)
 	rr.Value = p.Value
 	rr.Comment = p.Comment
+	rr.SOA = soaFieldsFromProtoBuf(p.Soa)
+	rr.MX = mxFieldsFromProtoBuf(p.Mx)
+	rr.Reverse = p.Reverse
+	rr.PTRName = p.PtrName
+	rr.Generate = generateFromProtoBuf(p.Generate)
+	rr.SetTTLText(p.TtlText)
 }
 
 func ResourceRecordToProtoBuf(rr *models.ResourceRecord) *proto.ResourceRecord {
@@ -23,13 +29,19 @@ func ResourceRecordToProtoBuf(rr *models.ResourceRecord) *proto.ResourceRecord {
 		return nil
 	}
 	ret := &proto.ResourceRecord{
-		Name:    rr.Name,
-		Type:    string(rr.Type),
-		Class:   string(rr.Class),
-		Ttl:     rr.TTL,
-		Value:   rr.Value,
-		Values:  resourceRecordValuesToProtoBuf(rr.Values),
-		Comment: rr.Comment,
+		Name:     rr.Name,
+		Type:     string(rr.Type),
+		Class:    string(rr.Class),
+		Ttl:      rr.TTL,
+		Value:    rr.Value,
+		Values:   resourceRecordValuesToProtoBuf(rr.Values),
+		Comment:  rr.Comment,
+		Soa:      soaFieldsToProtoBuf(rr.SOA),
+		Mx:       mxFieldsToProtoBuf(rr.MX),
+		Reverse:  rr.Reverse,
+		PtrName:  rr.PTRName,
+		Generate: generateToProtoBuf(rr.Generate),
+		TtlText:  rr.TTLText(),
 	}
 
 	return ret
@@ -56,3 +68,75 @@ func resourceRecordValuesToProtoBuf(rrvs []*models.ResourceRecordValue) []*proto
 	}
 	return protoRRVs
 }
+
+func soaFieldsFromProtoBuf(p *proto.SOAFields) *models.SOAFields {
+	if p == nil {
+		return nil
+	}
+	return &models.SOAFields{
+		MName:   soaFieldFromProtoBuf(p.Mname),
+		RName:   soaFieldFromProtoBuf(p.Rname),
+		Serial:  soaFieldFromProtoBuf(p.Serial),
+		Refresh: soaFieldFromProtoBuf(p.Refresh),
+		Retry:   soaFieldFromProtoBuf(p.Retry),
+		Expire:  soaFieldFromProtoBuf(p.Expire),
+		Minimum: soaFieldFromProtoBuf(p.Minimum),
+	}
+}
+
+func soaFieldsToProtoBuf(f *models.SOAFields) *proto.SOAFields {
+	if f == nil {
+		return nil
+	}
+	return &proto.SOAFields{
+		Mname:   soaFieldToProtoBuf(f.MName),
+		Rname:   soaFieldToProtoBuf(f.RName),
+		Serial:  soaFieldToProtoBuf(f.Serial),
+		Refresh: soaFieldToProtoBuf(f.Refresh),
+		Retry:   soaFieldToProtoBuf(f.Retry),
+		Expire:  soaFieldToProtoBuf(f.Expire),
+		Minimum: soaFieldToProtoBuf(f.Minimum),
+	}
+}
+
+func soaFieldFromProtoBuf(p *proto.SOAField) *models.SOAField {
+	if p == nil {
+		return nil
+	}
+	return &models.SOAField{Value: p.Value, Comment: p.Comment}
+}
+
+func soaFieldToProtoBuf(f *models.SOAField) *proto.SOAField {
+	if f == nil {
+		return nil
+	}
+	return &proto.SOAField{Value: f.Value, Comment: f.Comment}
+}
+
+func mxFieldsFromProtoBuf(p *proto.MXFields) *models.MXFields {
+	if p == nil {
+		return nil
+	}
+	return &models.MXFields{Preference: p.Preference, Exchange: p.Exchange}
+}
+
+func mxFieldsToProtoBuf(f *models.MXFields) *proto.MXFields {
+	if f == nil {
+		return nil
+	}
+	return &proto.MXFields{Preference: f.Preference, Exchange: f.Exchange}
+}
+
+func generateFromProtoBuf(p *proto.Generate) *models.Generate {
+	if p == nil {
+		return nil
+	}
+	return &models.Generate{Range: p.Range, Step: int(p.Step), Directive: p.Directive}
+}
+
+func generateToProtoBuf(g *models.Generate) *proto.Generate {
+	if g == nil {
+		return nil
+	}
+	return &proto.Generate{Range: g.Range, Step: int32(g.Step), Directive: g.Directive}
+}
@@ -26,12 +26,14 @@ import (
 	"github.com/bcurnow/zonemgr/plugins/proto"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 func TestResourceRecordFromProtoBuf(t *testing.T) {
 	testCases := []struct {
-		rr    *models.ResourceRecord
-		proto *proto.ResourceRecord
+		rr      *models.ResourceRecord
+		ttlText string
+		proto   *proto.ResourceRecord
 	}{
 		{rr: nil, proto: nil},
 		{rr: nil, proto: &proto.ResourceRecord{}},
@@ -57,6 +59,30 @@ func TestResourceRecordFromProtoBuf(t *testing.T) {
 				Comment: "test-comment",
 			},
 		},
+		{
+			rr: &models.ResourceRecord{
+				Type:     models.MX,
+				Name:     "testing",
+				TTL:      toInt32Ptr(3600),
+				MX:       &models.MXFields{Preference: "10", Exchange: "mail"},
+				Reverse:  toBoolPtr(false),
+				PTRName:  "ptr-testing",
+				SOA:      &models.SOAFields{MName: &models.SOAField{Value: "ns1", Comment: "mname-comment"}, Minimum: &models.SOAField{Value: "1h"}},
+				Generate: &models.Generate{Range: "1-10", Step: 2, Directive: true},
+			},
+			ttlText: "1h",
+			proto: &proto.ResourceRecord{
+				Type:     "MX",
+				Name:     "testing",
+				Ttl:      toInt32Ptr(3600),
+				Mx:       &proto.MXFields{Preference: "10", Exchange: "mail"},
+				Reverse:  toBoolPtr(false),
+				PtrName:  "ptr-testing",
+				Soa:      &proto.SOAFields{Mname: &proto.SOAField{Value: "ns1", Comment: "mname-comment"}, Minimum: &proto.SOAField{Value: "1h"}},
+				Generate: &proto.Generate{Range: "1-10", Step: 2, Directive: true},
+				TtlText:  "1h",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -67,16 +93,20 @@ func TestResourceRecordFromProtoBuf(t *testing.T) {
 
 		ResourceRecordFromProtoBuf(tc.proto, input)
 
-		if !cmp.Equal(input, tc.rr) {
+		if !cmp.Equal(input, tc.rr, cmpopts.IgnoreUnexported(models.ResourceRecord{})) {
 			t.Errorf("incorrect result: %s, want: %s", input, tc.rr)
 		}
+		if input != nil && input.TTLText() != tc.ttlText {
+			t.Errorf("incorrect TTL text: '%s', want: '%s'", input.TTLText(), tc.ttlText)
+		}
 	}
 }
 
 func TestUpdateResourceRecordToProtoBuf(t *testing.T) {
 	testCases := []struct {
-		rr    *models.ResourceRecord
-		proto *proto.ResourceRecord
+		rr      *models.ResourceRecord
+		ttlText string
+		proto   *proto.ResourceRecord
 	}{
 		{rr: nil, proto: nil},
 		{
@@ -118,12 +148,39 @@ func TestUpdateResourceRecordToProtoBuf(t *testing.T) {
 				Comment: "test-comment",
 			},
 		},
+		{
+			rr: &models.ResourceRecord{
+				Type:     models.MX,
+				Name:     "testing",
+				TTL:      toInt32Ptr(3600),
+				MX:       &models.MXFields{Preference: "10", Exchange: "mail"},
+				Reverse:  toBoolPtr(false),
+				PTRName:  "ptr-testing",
+				SOA:      &models.SOAFields{MName: &models.SOAField{Value: "ns1", Comment: "mname-comment"}, Minimum: &models.SOAField{Value: "1h"}},
+				Generate: &models.Generate{Range: "1-10", Step: 2, Directive: true},
+			},
+			ttlText: "1h",
+			proto: &proto.ResourceRecord{
+				Type:     "MX",
+				Name:     "testing",
+				Ttl:      toInt32Ptr(3600),
+				Mx:       &proto.MXFields{Preference: "10", Exchange: "mail"},
+				Reverse:  toBoolPtr(false),
+				PtrName:  "ptr-testing",
+				Soa:      &proto.SOAFields{Mname: &proto.SOAField{Value: "ns1", Comment: "mname-comment"}, Minimum: &proto.SOAField{Value: "1h"}},
+				Generate: &proto.Generate{Range: "1-10", Step: 2, Directive: true},
+				TtlText:  "1h",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
+		if tc.rr != nil {
+			tc.rr.SetTTLText(tc.ttlText)
+		}
 		result := ResourceRecordToProtoBuf(tc.rr)
 
-		if !cmp.Equal(result, tc.proto, cmpopts.IgnoreTypes(proto.ResourceRecord{}, proto.ResourceRecordValue{})) {
+		if !cmp.Equal(result, tc.proto, protocmp.Transform()) {
 			t.Errorf("incorrect result: %s, want: %s", result, tc.proto)
 		}
 	}
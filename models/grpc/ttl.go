@@ -11,6 +11,7 @@ func TTLFromProtoBuf(p *proto.TTL, ttl *models.TTL) {
 	}
 	ttl.Value = p.Ttl
 	ttl.Comment = p.Comment
+	ttl.SetText(p.Text)
 }
 
 func TTLToProtoBuf(ttl *models.TTL) *proto.TTL {
@@ -18,5 +19,5 @@ func TTLToProtoBuf(ttl *models.TTL) *proto.TTL {
 		return nil
 	}
 
-	return &proto.TTL{Ttl: ttl.Value, Comment: ttl.Comment}
+	return &proto.TTL{Ttl: ttl.Value, Comment: ttl.Comment, Text: ttl.Text()}
 }
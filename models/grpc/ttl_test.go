@@ -30,6 +30,7 @@ import (
 func TestTTLFromProtoBuf(t *testing.T) {
 	testCases := []struct {
 		ttl   *models.TTL
+		text  string
 		proto *proto.TTL
 	}{
 		{ttl: nil, proto: nil},
@@ -39,6 +40,11 @@ func TestTTLFromProtoBuf(t *testing.T) {
 			ttl:   &models.TTL{Value: toInt32Ptr(99), Comment: "testing-comment"},
 			proto: &proto.TTL{Ttl: toInt32Ptr(99), Comment: "testing-comment"},
 		},
+		{
+			ttl:   &models.TTL{Value: toInt32Ptr(3600)},
+			text:  "1h",
+			proto: &proto.TTL{Ttl: toInt32Ptr(3600), Text: "1h"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -50,15 +56,19 @@ func TestTTLFromProtoBuf(t *testing.T) {
 		}
 		TTLFromProtoBuf(tc.proto, input)
 
-		if !cmp.Equal(input, want) {
+		if !cmp.Equal(input, want, cmpopts.IgnoreUnexported(models.TTL{})) {
 			t.Errorf("incorrect result: %s, want: %s", input, want)
 		}
+		if input != nil && input.Text() != tc.text {
+			t.Errorf("incorrect text: '%s', want: '%s'", input.Text(), tc.text)
+		}
 	}
 }
 
 func TestTTLToProtoBuf(t *testing.T) {
 	testCases := []struct {
 		ttl   *models.TTL
+		text  string
 		proto *proto.TTL
 	}{
 		{ttl: nil, proto: nil},
@@ -66,9 +76,17 @@ func TestTTLToProtoBuf(t *testing.T) {
 			ttl:   &models.TTL{Value: toInt32Ptr(99), Comment: "testing-comment"},
 			proto: &proto.TTL{Ttl: toInt32Ptr(99), Comment: "testing-comment"},
 		},
+		{
+			ttl:   &models.TTL{Value: toInt32Ptr(3600)},
+			text:  "1h",
+			proto: &proto.TTL{Ttl: toInt32Ptr(3600), Text: "1h"},
+		},
 	}
 
 	for _, tc := range testCases {
+		if tc.ttl != nil {
+			tc.ttl.SetText(tc.text)
+		}
 		result := TTLToProtoBuf(tc.ttl)
 
 		if !cmp.Equal(result, tc.proto, cmpopts.IgnoreUnexported(proto.TTL{})) {
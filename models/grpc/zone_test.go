@@ -77,8 +77,8 @@ func TestZoneFromProtoBuf(t *testing.T) {
 
 		ZoneFromProtoBuf(tc.proto, input)
 
-		if !cmp.Equal(input, want, cmpopts.IgnoreUnexported(models.Zone{})) {
-			t.Errorf("incorrect result:\n%s", cmp.Diff(input, want, cmpopts.IgnoreUnexported(models.Zone{})))
+		if !cmp.Equal(input, want, cmpopts.IgnoreUnexported(models.Zone{}, models.ResourceRecord{}, models.TTL{})) {
+			t.Errorf("incorrect result:\n%s", cmp.Diff(input, want, cmpopts.IgnoreUnexported(models.Zone{}, models.ResourceRecord{}, models.TTL{})))
 		}
 	}
 }
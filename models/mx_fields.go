@@ -0,0 +1,53 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The keyed form of the values of an MX record, an alternative to the positional values
+type MXFields struct {
+	Preference string `yaml:"preference" validate:"required"`
+	Exchange   string `yaml:"exchange" validate:"required"`
+}
+
+func (f *MXFields) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return &yaml.TypeError{Errors: []string{fmt.Sprintf("line %d: cannot unmarshal %s into models.MXFields, expected a mapping of preference and exchange", node.Line, node.ShortTag())}}
+	}
+	type plain MXFields
+	if err := node.Decode((*plain)(f)); err != nil {
+		return err
+	}
+	return checkKnownKeys(node, reflect.TypeOf(plain{}), "models.MXFields")
+}
+
+// Returns a copy of the fields which doesn't share anything with the original
+func (f *MXFields) Clone() *MXFields {
+	if nil == f {
+		return nil
+	}
+	clone := *f
+	return &clone
+}
@@ -0,0 +1,74 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnmarshalYAML_MXFields(t *testing.T) {
+	testCases := []struct {
+		name string
+		yaml string
+		want *MXFields
+		err  string
+	}{
+		{name: "fields", yaml: "preference: 10\nexchange: mail.example.com.\n", want: &MXFields{Preference: "10", Exchange: "mail.example.com."}},
+		{name: "unknown-field", yaml: "preference: 10\nexchnage: mail.example.com.\n", err: "yaml: unmarshal errors:\n  line 2: field exchnage not found in type models.MXFields"},
+		{name: "scalar", yaml: "10 mail.example.com.\n", err: "yaml: unmarshal errors:\n  line 1: cannot unmarshal !!str into models.MXFields, expected a mapping of preference and exchange"},
+	}
+
+	for _, tc := range testCases {
+		got := &MXFields{}
+		err := yaml.Unmarshal([]byte(tc.yaml), got)
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("%s - incorrect error: '%v', want: '%s'", tc.name, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s - unexpected error: %s", tc.name, err)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s - incorrect fields:\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestClone_MXFields(t *testing.T) {
+	if (*MXFields)(nil).Clone() != nil {
+		t.Error("expected the clone of nil to be nil")
+	}
+
+	fields := &MXFields{Preference: "10", Exchange: "mail.example.com."}
+	clone := fields.Clone()
+	if diff := cmp.Diff(fields, clone); diff != "" {
+		t.Errorf("incorrect clone:\n%s", diff)
+	}
+	clone.Exchange = "mail.example.net."
+	if fields.Exchange != "mail.example.com." {
+		t.Error("the clone shares data with the original")
+	}
+}
@@ -0,0 +1,119 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"slices"
+	"strings"
+)
+
+// Resolves a record name against the zone so names from different records and zones can be compared, the result is
+// lowercase and always has a trailing dot ('@' is the zone itself, a name with a trailing dot is already absolute)
+func AbsoluteName(name string, zoneName string) string {
+	if !strings.HasSuffix(zoneName, ".") {
+		zoneName += "."
+	}
+
+	switch {
+	case name == "@" || name == "":
+		name = zoneName
+	case !strings.HasSuffix(name, "."):
+		name = name + "." + zoneName
+	}
+	return strings.ToLower(name)
+}
+
+// Removes the leftmost label from an absolute name, the parent of a top level name is the root
+func ParentName(name string) string {
+	if _, parent, ok := strings.Cut(name, "."); ok && parent != "" {
+		return parent
+	}
+	return "."
+}
+
+// Compares two absolute names in DNS canonical order (RFC4034 6.1), label by label starting with the rightmost label
+// so every name is immediately followed by the names below it
+func CompareNames(a string, b string) int {
+	labelsA := strings.Split(strings.TrimSuffix(a, "."), ".")
+	labelsB := strings.Split(strings.TrimSuffix(b, "."), ".")
+	slices.Reverse(labelsA)
+	slices.Reverse(labelsB)
+	return slices.Compare(labelsA, labelsB)
+}
+
+// The names that exist in a zone with the types of the records they own, used to find the owner whose records answer
+// a query for a name, including through a wildcard (RFC4592)
+type ZoneNames struct {
+	apex string
+	// A name between an owner and the apex exists even if it doesn't own any records (an empty non-terminal)
+	names map[string]map[ResourceRecordType]bool
+}
+
+// Collects the names of the records of the zone, records whose name isn't in the zone are left out
+func NewZoneNames(zoneName string, zone *Zone) *ZoneNames {
+	z := &ZoneNames{apex: AbsoluteName("@", zoneName), names: make(map[string]map[ResourceRecordType]bool)}
+	for _, rr := range zone.ResourceRecords {
+		owner := AbsoluteName(rr.Name, zoneName)
+		if !z.Contains(owner) {
+			continue
+		}
+		for name := owner; z.Contains(name) && z.names[name] == nil; name = ParentName(name) {
+			z.names[name] = make(map[ResourceRecordType]bool)
+		}
+		z.names[owner][rr.Type] = true
+	}
+	return z
+}
+
+// The absolute name of the zone
+func (z *ZoneNames) Apex() string {
+	return z.apex
+}
+
+// Checks if the absolute name owns a record of the type
+func (z *ZoneNames) Owns(name string, rrType ResourceRecordType) bool {
+	return z.names[strings.ToLower(name)][rrType]
+}
+
+// Checks if the absolute name is the zone or below it
+func (z *ZoneNames) Contains(name string) bool {
+	name = strings.ToLower(name)
+	return name == z.apex || strings.HasSuffix(name, "."+z.apex)
+}
+
+// Returns the owner name whose records answer a query for the absolute name: the name itself if it exists, otherwise
+// the wildcard at the closest encloser (RFC4592 3.3.1) if there is one. Returns "" when nothing in the zone answers.
+func (z *ZoneNames) Resolve(name string) string {
+	name = strings.ToLower(name)
+	if z.names[name] != nil {
+		return name
+	}
+
+	// The closest encloser is the nearest existing ancestor, only a wildcard directly below it can match
+	for encloser := ParentName(name); z.Contains(encloser); encloser = ParentName(encloser) {
+		if z.names[encloser] != nil || encloser == z.apex {
+			if wildcard := "*." + encloser; z.names[wildcard] != nil {
+				return wildcard
+			}
+			return ""
+		}
+	}
+	return ""
+}
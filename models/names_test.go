@@ -0,0 +1,145 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"testing"
+)
+
+func TestAbsoluteName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		zoneName string
+		want     string
+	}{
+		{name: "@", zoneName: "example.com.", want: "example.com."},
+		{name: "", zoneName: "example.com", want: "example.com."},
+		{name: "www", zoneName: "example.com.", want: "www.example.com."},
+		{name: "WWW", zoneName: "Example.com", want: "www.example.com."},
+		{name: "www.example.net.", zoneName: "example.com.", want: "www.example.net."},
+	}
+
+	for _, tc := range testCases {
+		if got := AbsoluteName(tc.name, tc.zoneName); got != tc.want {
+			t.Errorf("AbsoluteName(%q, %q) = %q, want %q", tc.name, tc.zoneName, got, tc.want)
+		}
+	}
+}
+
+func TestParentName(t *testing.T) {
+	testCases := []struct {
+		name string
+		want string
+	}{
+		{name: "www.example.com.", want: "example.com."},
+		{name: "com.", want: "."},
+		{name: ".", want: "."},
+	}
+
+	for _, tc := range testCases {
+		if got := ParentName(tc.name); got != tc.want {
+			t.Errorf("ParentName(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestCompareNames(t *testing.T) {
+	testCases := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{a: "example.com.", b: "example.com.", want: 0},
+		{a: "example.com.", b: "a.example.com.", want: -1},
+		{a: "z.example.com.", b: "a.b.example.com.", want: 1},
+		{a: "b.example.com.", b: "a.example.net.", want: -1},
+	}
+
+	for _, tc := range testCases {
+		if got := CompareNames(tc.a, tc.b); got != tc.want {
+			t.Errorf("CompareNames(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestZoneNames(t *testing.T) {
+	zone := &Zone{ResourceRecords: map[string]*ResourceRecord{
+		"soa":      {Name: "example.com.", Type: SOA},
+		"www":      {Name: "www", Type: A},
+		"wildcard": {Name: "*", Type: A},
+		"apps":     {Name: "*.apps", Type: CNAME},
+		"deep":     {Name: "host.lab.dev", Type: A},
+		"other":    {Name: "other.example.net.", Type: A},
+	}}
+	names := NewZoneNames("Example.com", zone)
+
+	if names.Apex() != "example.com." {
+		t.Errorf("incorrect apex: '%s', want: 'example.com.'", names.Apex())
+	}
+
+	testCases := []struct {
+		name     string
+		want     string
+		contains bool
+	}{
+		{name: "www.example.com.", want: "www.example.com.", contains: true},
+		{name: "WWW.example.com.", want: "www.example.com.", contains: true},
+		{name: "missing.example.com.", want: "*.example.com.", contains: true},
+		{name: "a.b.missing.example.com.", want: "*.example.com.", contains: true},
+		{name: "console.apps.example.com.", want: "*.apps.example.com.", contains: true},
+		{name: "a.console.apps.example.com.", want: "*.apps.example.com.", contains: true},
+		{name: "apps.example.com.", want: "apps.example.com.", contains: true},
+		// lab.dev and dev exist as empty non-terminals, there's no wildcard below them
+		{name: "lab.dev.example.com.", want: "lab.dev.example.com.", contains: true},
+		{name: "other.lab.dev.example.com.", contains: true},
+		{name: "other.dev.example.com.", contains: true},
+		{name: "www.example.net."},
+		{name: "other.example.net."},
+		{name: "com."},
+	}
+
+	for _, tc := range testCases {
+		if got := names.Resolve(tc.name); got != tc.want {
+			t.Errorf("Resolve(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+		if got := names.Contains(tc.name); got != tc.contains {
+			t.Errorf("Contains(%q) = %t, want %t", tc.name, got, tc.contains)
+		}
+	}
+
+	ownsCases := []struct {
+		name   string
+		rrType ResourceRecordType
+		want   bool
+	}{
+		{name: "www.example.com.", rrType: A, want: true},
+		{name: "WWW.example.com.", rrType: A, want: true},
+		{name: "www.example.com.", rrType: AAAA},
+		{name: "*.apps.example.com.", rrType: CNAME, want: true},
+		{name: "dev.example.com.", rrType: A},
+		{name: "other.example.net.", rrType: A},
+	}
+
+	for _, tc := range ownsCases {
+		if got := names.Owns(tc.name, tc.rrType); got != tc.want {
+			t.Errorf("Owns(%q, %s) = %t, want %t", tc.name, tc.rrType, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+// Defines which PTR records are generated when more than one A or AAAA record of a zone has the same address
+type PTRPolicy string
+
+const (
+	// An error unless the records have the same PTR name or exactly one of them sets reverse to true
+	PTRPolicyError PTRPolicy = "error"
+	// Only the record with the first identifier gets a PTR record
+	PTRPolicyFirst PTRPolicy = "first"
+	// Every record gets a PTR record
+	PTRPolicyAll PTRPolicy = "all"
+)
+
+func (pp PTRPolicy) IsValid() bool {
+	switch pp {
+	case PTRPolicyError, PTRPolicyFirst, PTRPolicyAll, "": // Empty will use the default policy (error)
+		return true
+	default:
+		return false
+	}
+}
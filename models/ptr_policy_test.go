@@ -0,0 +1,42 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import "testing"
+
+func TestIsValid_PTRPolicy(t *testing.T) {
+	testCases := []struct {
+		policy PTRPolicy
+		want   bool
+	}{
+		{policy: PTRPolicyError, want: true},
+		{policy: PTRPolicyFirst, want: true},
+		{policy: PTRPolicyAll, want: true},
+		{policy: "", want: true},
+		{policy: "bogus", want: false},
+		{policy: "First", want: false},
+	}
+
+	for _, tc := range testCases {
+		if tc.policy.IsValid() != tc.want {
+			t.Errorf("incorrect result for '%s': %t, want %t", tc.policy, tc.policy.IsValid(), tc.want)
+		}
+	}
+}
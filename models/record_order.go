@@ -0,0 +1,40 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package models
+
+// Defines how the resource records that follow the SOA and apex NS records are ordered in a zone file
+type RecordOrder string
+
+const (
+	// Sorted by owner name (in DNS canonical order so a name is followed by the names below it), then type
+	RecordOrderName RecordOrder = "name"
+	// Sorted by type, then owner name
+	RecordOrderType RecordOrder = "type"
+	// Sorted by the identifier of the resource record in the YAML
+	RecordOrderIdentifier RecordOrder = "identifier"
+)
+
+func (ro RecordOrder) IsValid() bool {
+	switch ro {
+	case RecordOrderName, RecordOrderType, RecordOrderIdentifier, "": // Empty will use the default ordering
+		return true
+	default:
+		return false
+	}
+}
@@ -18,8 +18,11 @@ package models
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -33,13 +36,55 @@ type ResourceRecord struct {
 	// Not validated here: go-playground's "fqdn" tag requires a real multi-label dotted name and rejects
 	// short, relative names (e.g. "www"), which are normal and valid in a zone file. The builtin plugins'
 	// own Normalize() methods (EnsureValidNameOrWildcard et al.) do the real, format-appropriate validation.
-	Name    string                 `yaml:"name" validate:"omitempty"`
-	Type    ResourceRecordType     `yaml:"type" validate:"required"`   //TODO see if we can use something similar to ResourceRecordClass instead, this would simplify validations
-	Class   ResourceRecordClass    `yaml:"class" validate:"omitempty"` //TODO See if we can use ResourceRecordClass instead, this would simplify validations
-	TTL     *int32                 `yaml:"ttl" validate:"omitempty,min=0,max=2147483647"`
-	Values  []*ResourceRecordValue `yaml:"values" validate:"omitempty,dive"`
-	Value   string                 `yaml:"value" validate:"omitempty"`
-	Comment string                 `yaml:"comment" validate:"omitempty"`
+	Name    string                 `yaml:"name,omitempty" validate:"omitempty"`
+	Type    ResourceRecordType     `yaml:"type" validate:"required"`             //TODO see if we can use something similar to ResourceRecordClass instead, this would simplify validations
+	Class   ResourceRecordClass    `yaml:"class,omitempty" validate:"omitempty"` //TODO See if we can use ResourceRecordClass instead, this would simplify validations
+	TTL     *int32                 `yaml:"ttl,omitempty" validate:"omitempty,min=0,max=2147483647"`
+	Values  []*ResourceRecordValue `yaml:"values,omitempty" validate:"omitempty,dive"`
+	Value   string                 `yaml:"value,omitempty" validate:"omitempty"`
+	Comment string                 `yaml:"comment,omitempty" validate:"omitempty"`
+	// The keyed form of the values of an SOA record, it's turned into Values before the zone is normalized
+	SOA *SOAFields `yaml:"soa,omitempty" validate:"omitempty"`
+	// The keyed form of the values of an MX record, the MX plugin turns it into Values when the record is normalized
+	MX *MXFields `yaml:"mx,omitempty" validate:"omitempty"`
+	// Only for A and AAAA records, false leaves the record out of the generated reverse lookup zones and true makes it
+	// the record the PTR record points at when other records have the same address
+	Reverse *bool `yaml:"reverse,omitempty" validate:"omitempty"`
+	// Only for A and AAAA records, the name the generated PTR record points at instead of the name of the record
+	PTRName string `yaml:"ptr_name,omitempty" validate:"omitempty"`
+	// Expands the record into a record for each number of a range, it's expanded before the zone is normalized
+	Generate *Generate `yaml:"generate,omitempty" validate:"omitempty"`
+	// The TTL as it was written when it used units (e.g. 1h), it's rendered that way when keep_time_units is set
+	ttlText string
+}
+
+// The TTL can be a number of seconds or use units (e.g. 1h), either way it's stored as a number of seconds
+func (rr *ResourceRecord) UnmarshalYAML(node *yaml.Node) error {
+	type plain ResourceRecord
+	decoded, texts, err := withTimeIntervals(node, "ttl")
+	if err != nil {
+		return err
+	}
+	if err := decoded.Decode((*plain)(rr)); err != nil {
+		return err
+	}
+	rr.ttlText = texts["ttl"]
+	return checkKnownKeys(node, reflect.TypeOf(plain{}), "models.ResourceRecord")
+}
+
+// Forgets how the TTL was written so it's rendered as a number of seconds
+func (rr *ResourceRecord) DiscardTimeUnits() {
+	rr.ttlText = ""
+}
+
+// The TTL as it was written when it used units (e.g. 1h), empty when it was a number of seconds
+func (rr *ResourceRecord) TTLText() string {
+	return rr.ttlText
+}
+
+// Sets how the TTL was written, e.g. when the resource record is received from a plugin
+func (rr *ResourceRecord) SetTTLText(text string) {
+	rr.ttlText = text
 }
 
 func (rr *ResourceRecord) String() string {
@@ -54,6 +99,73 @@ func (rr *ResourceRecord) String() string {
 		"     }"
 }
 
+// Returns a copy of the resource record which doesn't share anything with the original, e.g. so the normalizer
+// can change a record included from a template in one zone without changing it in every other zone
+func (rr *ResourceRecord) Clone() *ResourceRecord {
+	if rr == nil {
+		return nil
+	}
+	clone := *rr
+	if rr.TTL != nil {
+		ttl := *rr.TTL
+		clone.TTL = &ttl
+	}
+	if rr.Values != nil {
+		clone.Values = make([]*ResourceRecordValue, len(rr.Values))
+		for i, value := range rr.Values {
+			if value != nil {
+				v := *value
+				clone.Values[i] = &v
+			}
+		}
+	}
+	clone.SOA = rr.SOA.Clone()
+	clone.MX = rr.MX.Clone()
+	clone.Generate = rr.Generate.Clone()
+	if rr.Reverse != nil {
+		reverse := *rr.Reverse
+		clone.Reverse = &reverse
+	}
+	return &clone
+}
+
+// Returns a new resource record where each field that isn't set on this resource record is inherited from parent (see
+// Zone.Extends). The data (value, values, soa, mx and generate) and the comment are inherited as a whole when none of
+// the data is set, except that the fields of two keyed SOA records are merged. A resource record of a different type doesn't inherit anything.
+func (rr *ResourceRecord) Inherit(parent *ResourceRecord) *ResourceRecord {
+	if nil == parent || rr.Type != parent.Type {
+		return rr
+	}
+
+	merged := rr.Clone()
+	parent = parent.Clone()
+	merged.Name = pick(rr.Name != "", merged.Name, parent.Name)
+	merged.Class = pick(rr.Class != "", merged.Class, parent.Class)
+	if nil == rr.TTL {
+		merged.TTL, merged.ttlText = parent.TTL, parent.ttlText
+	}
+	merged.Reverse = pick(rr.Reverse != nil, merged.Reverse, parent.Reverse)
+	merged.PTRName = pick(rr.PTRName != "", merged.PTRName, parent.PTRName)
+
+	switch {
+	case rr.SOA != nil && parent.SOA != nil:
+		merged.SOA = rr.SOA.WithDefaults(parent.SOA)
+	case !rr.hasData():
+		merged.Value, merged.Values, merged.SOA, merged.MX, merged.Generate = parent.Value, parent.Values, parent.SOA, parent.MX, parent.Generate
+		merged.Comment = pick(rr.Comment != "", merged.Comment, parent.Comment)
+	}
+	return merged
+}
+
+func (rr *ResourceRecord) hasData() bool {
+	return rr.Value != "" || len(rr.Values) > 0 || rr.SOA != nil || rr.MX != nil || rr.Generate != nil
+}
+
+// A wildcard owns every name below its parent that doesn't otherwise exist (RFC4592), e.g. * or *.apps
+func (rr *ResourceRecord) IsWildcard() bool {
+	return rr.Name == "*" || strings.HasPrefix(rr.Name, "*.")
+}
+
 // There are two possible places to get a value from: Value or Values[0].Value
 // This method will validate that only Value or Values is populated, that, if Values is populated, there's only a single item.
 // Will return either Value or the Values[0].Value
@@ -120,23 +232,28 @@ func (rr *ResourceRecord) IsCommentSetInOnePlace() bool {
 	return false
 }
 
+// Renders everything before the value in the order RFC1035 defines (<name> [<TTL>] [<class>] <type>), every record in a
+// generated zone file and every $GENERATE directive starts with this. The type used to come before the TTL and class,
+// which a name server reads as part of the value.
 func (rr *ResourceRecord) RenderResourceWithoutValue() string {
 	var record strings.Builder
 
 	fmt.Fprintf(&record, ResourceRecordNameFormatString, rr.Name)
 	record.WriteString(" ")
-	fmt.Fprintf(&record, ResourceRecordTypeFormatString, rr.Type)
-	record.WriteString(" ")
-	if rr.Class != "" {
-		record.WriteString(string(rr.Class))
+	// RFC1035 only allows the TTL and class before the type, anything after the type is RDATA
+	if rr.TTL != nil {
+		record.WriteString(renderTimeInterval(*rr.TTL, rr.ttlText))
 		record.WriteString(" ")
 	}
 
-	if rr.TTL != nil {
-		record.WriteString(strconv.Itoa(int(*rr.TTL)))
+	if rr.Class != "" {
+		record.WriteString(string(rr.Class))
 		record.WriteString(" ")
 	}
 
+	fmt.Fprintf(&record, ResourceRecordTypeFormatString, rr.Type)
+	record.WriteString(" ")
+
 	return record.String()
 }
 
@@ -21,9 +21,11 @@ package models
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
 )
 
 func TestString_ResourceRecord(t *testing.T) {
@@ -66,6 +68,175 @@ func TestString_ResourceRecord(t *testing.T) {
 	}
 }
 
+func TestClone_ResourceRecord(t *testing.T) {
+	rr := &ResourceRecord{
+		Name:     "www",
+		Type:     A,
+		Class:    INTERNET,
+		TTL:      toInt32Ptr(300),
+		Values:   []*ResourceRecordValue{{Value: "192.0.2.1", Comment: "first"}, nil},
+		Comment:  "testing",
+		SOA:      &SOAFields{MName: &SOAField{Value: "ns1.example.com."}},
+		MX:       &MXFields{Preference: "10", Exchange: "mail.example.com."},
+		Reverse:  toBoolPtr(true),
+		PTRName:  "web.example.com.",
+		Generate: &Generate{Range: "1-10"},
+	}
+
+	clone := rr.Clone()
+	if diff := cmp.Diff(rr, clone, cmp.AllowUnexported(ResourceRecord{})); diff != "" {
+		t.Errorf("incorrect clone:\n%s", diff)
+	}
+
+	// Changing the clone must not change the original
+	*clone.TTL = 600
+	clone.Values[0].Value = "192.0.2.2"
+	clone.SOA.MName.Value = "ns2.example.com."
+	clone.MX.Exchange = "mail.example.net."
+	*clone.Reverse = false
+	clone.Generate.Range = "1-20"
+	if *rr.TTL != 300 || rr.Values[0].Value != "192.0.2.1" || rr.SOA.MName.Value != "ns1.example.com." || rr.MX.Exchange != "mail.example.com." || !*rr.Reverse || rr.Generate.Range != "1-10" {
+		t.Errorf("the clone shares data with the original: %s", rr)
+	}
+
+	if clone := (&ResourceRecord{Type: A}).Clone(); clone.TTL != nil || clone.Values != nil {
+		t.Errorf("incorrect clone of an empty record: %s", clone)
+	}
+
+	if (*ResourceRecord)(nil).Clone() != nil {
+		t.Error("expected the clone of nil to be nil")
+	}
+}
+
+func TestInherit_ResourceRecord(t *testing.T) {
+	parent := &ResourceRecord{Name: "www", Type: A, Class: INTERNET, TTL: toInt32Ptr(300), Value: "192.0.2.1", Comment: "web server", Reverse: toBoolPtr(false)}
+	soaParent := &ResourceRecord{Name: "@", Type: SOA, SOA: &SOAFields{MName: &SOAField{Value: "ns1.example.com."}, RName: &SOAField{Value: "hostmaster.example.com."}}}
+
+	testCases := []struct {
+		name   string
+		rr     *ResourceRecord
+		parent *ResourceRecord
+		want   *ResourceRecord
+	}{
+		{
+			name:   "ttl-only",
+			rr:     &ResourceRecord{Type: A, TTL: toInt32Ptr(60)},
+			parent: parent,
+			want:   &ResourceRecord{Name: "www", Type: A, Class: INTERNET, TTL: toInt32Ptr(60), Value: "192.0.2.1", Comment: "web server", Reverse: toBoolPtr(false)},
+		},
+		{
+			name:   "data-with-comments",
+			rr:     &ResourceRecord{Type: A, Values: []*ResourceRecordValue{{Value: "192.0.2.10", Comment: "new web server"}}},
+			parent: parent,
+			want:   &ResourceRecord{Name: "www", Type: A, Class: INTERNET, TTL: toInt32Ptr(300), Values: []*ResourceRecordValue{{Value: "192.0.2.10", Comment: "new web server"}}, Reverse: toBoolPtr(false)},
+		},
+		{
+			name:   "comment-only",
+			rr:     &ResourceRecord{Type: A, Comment: "still the web server", Reverse: toBoolPtr(true)},
+			parent: parent,
+			want:   &ResourceRecord{Name: "www", Type: A, Class: INTERNET, TTL: toInt32Ptr(300), Value: "192.0.2.1", Comment: "still the web server", Reverse: toBoolPtr(true)},
+		},
+		{
+			name:   "keyed-soa",
+			rr:     &ResourceRecord{Type: SOA, SOA: &SOAFields{RName: &SOAField{Value: "admin.example.net."}}},
+			parent: soaParent,
+			want:   &ResourceRecord{Name: "@", Type: SOA, SOA: &SOAFields{MName: &SOAField{Value: "ns1.example.com."}, RName: &SOAField{Value: "admin.example.net."}}},
+		},
+		{
+			name:   "different-type",
+			rr:     &ResourceRecord{Type: CNAME, Value: "web"},
+			parent: parent,
+			want:   &ResourceRecord{Type: CNAME, Value: "web"},
+		},
+		{
+			name: "no-parent",
+			rr:   &ResourceRecord{Type: A, Value: "192.0.2.1"},
+			want: &ResourceRecord{Type: A, Value: "192.0.2.1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		got := tc.rr.Inherit(tc.parent)
+		if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(ResourceRecord{})); diff != "" {
+			t.Errorf("%s - incorrect record:\n%s", tc.name, diff)
+		}
+	}
+
+	// The inherited fields are copies
+	got := (&ResourceRecord{Type: A}).Inherit(parent)
+	*got.TTL = 60
+	*got.Reverse = true
+	if *parent.TTL != 300 || *parent.Reverse {
+		t.Errorf("the record shares data with the parent: %s", parent)
+	}
+}
+
+func TestUnmarshalYAML_ResourceRecord(t *testing.T) {
+	testCases := []struct {
+		name string
+		yaml string
+		want *ResourceRecord
+		err  string
+	}{
+		{name: "seconds", yaml: "type: A\nttl: 300\nvalue: 192.0.2.1\n", want: &ResourceRecord{Type: A, TTL: toInt32Ptr(300), Value: "192.0.2.1"}},
+		{name: "units", yaml: "type: A\nttl: 2h30m\nvalue: 192.0.2.1\n", want: &ResourceRecord{Type: A, TTL: toInt32Ptr(9000), ttlText: "2h30m", Value: "192.0.2.1"}},
+		{name: "invalid-units", yaml: "type: A\nttl: 2y\n", err: "yaml: unmarshal errors:\n  line 2: invalid time interval '2y', expected a number of seconds or numbers with a unit (w, d, h, m or s) e.g. 1h30m"},
+		{name: "unknown-key", yaml: "type: A\nvalu: 192.0.2.1\n", err: "yaml: unmarshal errors:\n  line 2: field valu not found in type models.ResourceRecord"},
+		{name: "unknown-value-key", yaml: "type: A\nvalues:\n  - value: 192.0.2.1\n    coment: typo\n", err: "yaml: unmarshal errors:\n  line 4: field coment not found in type models.ResourceRecordValue"},
+	}
+
+	for _, tc := range testCases {
+		got := &ResourceRecord{}
+		err := yaml.Unmarshal([]byte(tc.yaml), got)
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("%s - incorrect error: '%v', want: '%s'", tc.name, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s - unexpected error: %s", tc.name, err)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(ResourceRecord{})); diff != "" {
+			t.Errorf("%s - incorrect resource record:\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestTTLText(t *testing.T) {
+	rr := &ResourceRecord{Type: A, TTL: toInt32Ptr(7200)}
+	rr.SetTTLText("2h")
+	if rr.TTLText() != "2h" {
+		t.Errorf("incorrect TTL text: '%s', want: '2h'", rr.TTLText())
+	}
+	rr.DiscardTimeUnits()
+	if rr.TTLText() != "" {
+		t.Errorf("incorrect TTL text: '%s', want: ''", rr.TTLText())
+	}
+}
+
+func TestIsWildcard(t *testing.T) {
+	testCases := []struct {
+		name string
+		want bool
+	}{
+		{name: "*", want: true},
+		{name: "*.apps", want: true},
+		{name: "*.apps.example.com.", want: true},
+		{name: "@"},
+		{name: "www"},
+		{name: "*apps"},
+		{name: "www.*.example.com."},
+	}
+
+	for _, tc := range testCases {
+		if got := (&ResourceRecord{Name: tc.name}).IsWildcard(); got != tc.want {
+			t.Errorf("%s - incorrect result: %t, want %t", tc.name, got, tc.want)
+		}
+	}
+}
+
 func TestRetrieveSingleValue(t *testing.T) {
 	testCases := []struct {
 		rr   *ResourceRecord
@@ -150,7 +321,8 @@ func TestRenderResourceWithoutValue(t *testing.T) {
 		// I don't like the way the wants are put together but haven't come up with a better idea
 		{rr: &ResourceRecord{}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" ", "", "")},
 		{rr: &ResourceRecord{Name: "name", Type: A, Value: "1.2.3.4"}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" ", "name", "A")},
-		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "1.2.3.4"}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" %s %s ", "name", "A", "IN", "30")},
+		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "1.2.3.4"}, want: fmt.Sprintf(ResourceRecordNameFormatString+" %s %s "+ResourceRecordTypeFormatString+" ", "name", "30", "IN", "A")},
+		{rr: &ResourceRecord{Name: "name", Type: A, TTL: toInt32Ptr(7200), ttlText: "2h", Value: "1.2.3.4"}, want: fmt.Sprintf(ResourceRecordNameFormatString+" %s "+ResourceRecordTypeFormatString+" ", "name", "2h", "A")},
 	}
 
 	for _, tc := range testCases {
@@ -160,6 +332,14 @@ func TestRenderResourceWithoutValue(t *testing.T) {
 	}
 }
 
+// RFC1035 only allows the TTL and class before the type, generated zone files and $GENERATE directives rely on it
+func TestRenderResourceWithoutValue_Order(t *testing.T) {
+	rr := &ResourceRecord{Name: "www", Type: A, Class: INTERNET, TTL: toInt32Ptr(300), Value: "192.0.2.1"}
+	if got := strings.Join(strings.Fields(rr.RenderSingleValueResource()), " "); got != "www 300 IN A 192.0.2.1" {
+		t.Errorf("incorrect order: '%s', want: 'www 300 IN A 192.0.2.1'", got)
+	}
+}
+
 func TestRenderSingleValueResource(t *testing.T) {
 	testCases := []struct {
 		rr   *ResourceRecord
@@ -168,12 +348,12 @@ func TestRenderSingleValueResource(t *testing.T) {
 		// I don't like the way the wants are put together but haven't come up with a better idea
 		{rr: &ResourceRecord{}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" ", "", "")},
 		{rr: &ResourceRecord{Name: "name", Type: A, Value: "1.2.3.4"}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" %s", "name", "A", "1.2.3.4")},
-		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "1.2.3.4"}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" %s %s %s", "name", "A", "IN", "30", "1.2.3.4")},
-		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "1.2.3.4", Comment: "testing"}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" %s %s %s ;%s", "name", "A", "IN", "30", "1.2.3.4", "testing")},
-		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Values: []*ResourceRecordValue{{Value: "1.2.3.4"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" %s %s %s", "name", "A", "IN", "30", "1.2.3.4")},
-		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Values: []*ResourceRecordValue{{Value: "1.2.3.4", Comment: "testing"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" %s %s %s ;%s", "name", "A", "IN", "30", "1.2.3.4", "testing")},
-		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "main value", Values: []*ResourceRecordValue{{Value: "1.2.3.4"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" %s %s %s", "name", "A", "IN", "30", "1.2.3.4")},
-		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "main value", Comment: "main comment", Values: []*ResourceRecordValue{{Value: "1.2.3.4", Comment: "testing"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" %s %s %s ;%s", "name", "A", "IN", "30", "1.2.3.4", "testing")},
+		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "1.2.3.4"}, want: fmt.Sprintf(ResourceRecordNameFormatString+" %s %s "+ResourceRecordTypeFormatString+" %s", "name", "30", "IN", "A", "1.2.3.4")},
+		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "1.2.3.4", Comment: "testing"}, want: fmt.Sprintf(ResourceRecordNameFormatString+" %s %s "+ResourceRecordTypeFormatString+" %s ;%s", "name", "30", "IN", "A", "1.2.3.4", "testing")},
+		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Values: []*ResourceRecordValue{{Value: "1.2.3.4"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" %s %s "+ResourceRecordTypeFormatString+" %s", "name", "30", "IN", "A", "1.2.3.4")},
+		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Values: []*ResourceRecordValue{{Value: "1.2.3.4", Comment: "testing"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" %s %s "+ResourceRecordTypeFormatString+" %s ;%s", "name", "30", "IN", "A", "1.2.3.4", "testing")},
+		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "main value", Values: []*ResourceRecordValue{{Value: "1.2.3.4"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" %s %s "+ResourceRecordTypeFormatString+" %s", "name", "30", "IN", "A", "1.2.3.4")},
+		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "main value", Comment: "main comment", Values: []*ResourceRecordValue{{Value: "1.2.3.4", Comment: "testing"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" %s %s "+ResourceRecordTypeFormatString+" %s ;%s", "name", "30", "IN", "A", "1.2.3.4", "testing")},
 	}
 
 	for _, tc := range testCases {
@@ -191,12 +371,12 @@ func TestRenderMultiValueResource(t *testing.T) {
 		// I don't like the way the wants are put together but haven't come up with a better idea
 		{rr: &ResourceRecord{}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" (\n%48s)", "", "", "")},
 		{rr: &ResourceRecord{Name: "name", Type: A, Value: "1.2.3.4"}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" (\n%48s)", "name", "A", "")},
-		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "1.2.3.4"}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" %s %s (\n%54s)", "name", "A", "IN", "30", "")},
-		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "1.2.3.4", Comment: "testing"}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" %s %s (\n%54s)", "name", "A", "IN", "30", "")},
-		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Values: []*ResourceRecordValue{{Value: "1.2.3.4"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" %s %s (\n%54s"+ResourceRecordMultivalueIndentFormatString+ResourceRecordNameFormatString+"\n%54s)", "name", "A", "IN", "30", "", "", "1.2.3.4", "")},
-		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Values: []*ResourceRecordValue{{Value: "1.2.3.4", Comment: "testing"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" %s %s (\n%54s"+ResourceRecordMultivalueIndentFormatString+ResourceRecordNameFormatString+" ;%s\n%54s)", "name", "A", "IN", "30", "", "", "1.2.3.4", "testing", "")},
-		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "main value", Values: []*ResourceRecordValue{{Value: "1.2.3.4"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" %s %s (\n%54s"+ResourceRecordMultivalueIndentFormatString+ResourceRecordNameFormatString+"\n%54s)", "name", "A", "IN", "30", "", "", "1.2.3.4", "")},
-		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "main value", Comment: "main comment", Values: []*ResourceRecordValue{{Value: "1.2.3.4", Comment: "testing"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" "+ResourceRecordTypeFormatString+" %s %s (\n%54s"+ResourceRecordMultivalueIndentFormatString+ResourceRecordNameFormatString+" ;%s\n%54s)", "name", "A", "IN", "30", "", "", "1.2.3.4", "testing", "")},
+		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "1.2.3.4"}, want: fmt.Sprintf(ResourceRecordNameFormatString+" %s %s "+ResourceRecordTypeFormatString+" (\n%54s)", "name", "30", "IN", "A", "")},
+		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "1.2.3.4", Comment: "testing"}, want: fmt.Sprintf(ResourceRecordNameFormatString+" %s %s "+ResourceRecordTypeFormatString+" (\n%54s)", "name", "30", "IN", "A", "")},
+		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Values: []*ResourceRecordValue{{Value: "1.2.3.4"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" %s %s "+ResourceRecordTypeFormatString+" (\n%54s"+ResourceRecordMultivalueIndentFormatString+ResourceRecordNameFormatString+"\n%54s)", "name", "30", "IN", "A", "", "", "1.2.3.4", "")},
+		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Values: []*ResourceRecordValue{{Value: "1.2.3.4", Comment: "testing"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" %s %s "+ResourceRecordTypeFormatString+" (\n%54s"+ResourceRecordMultivalueIndentFormatString+ResourceRecordNameFormatString+" ;%s\n%54s)", "name", "30", "IN", "A", "", "", "1.2.3.4", "testing", "")},
+		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "main value", Values: []*ResourceRecordValue{{Value: "1.2.3.4"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" %s %s "+ResourceRecordTypeFormatString+" (\n%54s"+ResourceRecordMultivalueIndentFormatString+ResourceRecordNameFormatString+"\n%54s)", "name", "30", "IN", "A", "", "", "1.2.3.4", "")},
+		{rr: &ResourceRecord{Name: "name", Type: A, Class: INTERNET, TTL: toInt32Ptr(30), Value: "main value", Comment: "main comment", Values: []*ResourceRecordValue{{Value: "1.2.3.4", Comment: "testing"}}}, want: fmt.Sprintf(ResourceRecordNameFormatString+" %s %s "+ResourceRecordTypeFormatString+" (\n%54s"+ResourceRecordMultivalueIndentFormatString+ResourceRecordNameFormatString+" ;%s\n%54s)", "name", "30", "IN", "A", "", "", "1.2.3.4", "testing", "")},
 		{rr: &ResourceRecord{
 			Name: "example.com.",
 			Type: SOA,
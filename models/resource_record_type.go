@@ -115,3 +115,20 @@ const (
 	X25        ResourceRecordType = "X25"
 	ZONEMD     ResourceRecordType = "ZONEMD"
 )
+
+// The RDATA fields, by position, that hold a domain name for the record types we know about
+var domainNameFields = map[ResourceRecordType][]int{
+	CNAME: {0},
+	DNAME: {0},
+	MX:    {1},
+	NS:    {0},
+	PTR:   {0},
+	SOA:   {0, 1},
+	SRV:   {3},
+}
+
+// Returns the positions of the RDATA fields that hold a domain name, e.g. the exchange of an MX record, these are
+// compared and resolved the same way as owner names. Returns nil when the type has none or isn't known.
+func (t ResourceRecordType) DomainNameFields() []int {
+	return domainNameFields[t]
+}
@@ -0,0 +1,47 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDomainNameFields_ResourceRecordType(t *testing.T) {
+	testCases := []struct {
+		rrType ResourceRecordType
+		want   []int
+	}{
+		{rrType: CNAME, want: []int{0}},
+		{rrType: MX, want: []int{1}},
+		{rrType: SOA, want: []int{0, 1}},
+		{rrType: SRV, want: []int{3}},
+		{rrType: A},
+		{rrType: TXT},
+		{rrType: "BOGUS"},
+	}
+
+	for _, tc := range testCases {
+		if diff := cmp.Diff(tc.want, tc.rrType.DomainNameFields()); diff != "" {
+			t.Errorf("%s - incorrect fields:\n%s", tc.rrType, diff)
+		}
+	}
+}
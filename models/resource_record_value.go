@@ -19,13 +19,26 @@
 
 package models
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
 
 type ResourceRecordValue struct {
 	Value   string `yaml:"value" validate:"required"`
-	Comment string `yaml:"comment" validate:"omitempty"`
+	Comment string `yaml:"comment,omitempty" validate:"omitempty"`
 }
 
 func (rrv *ResourceRecordValue) String() string {
 	return fmt.Sprintf("ResourceRecordValue{ Value: %s, Comment: %s }", rrv.Value, rrv.Comment)
 }
+
+func (rrv *ResourceRecordValue) UnmarshalYAML(node *yaml.Node) error {
+	type plain ResourceRecordValue
+	if err := node.Decode((*plain)(rrv)); err != nil {
+		return err
+	}
+	return checkKnownKeys(node, reflect.TypeOf(plain{}), "models.ResourceRecordValue")
+}
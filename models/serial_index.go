@@ -24,8 +24,10 @@ import "fmt"
 type SerialIndex struct {
 	Base        *uint32 `yaml:"base_serial_number"`
 	ChangeIndex *uint32 `yaml:"change_index"`
+	// The hash of the zone content (excluding the serial number) the serial number was committed for
+	ContentHash string `yaml:"content_hash,omitempty"`
 }
 
 func (si *SerialIndex) String() string {
-	return fmt.Sprintf("SerialIndex{ Base: %s, ChangeIndex: %s}", uint32ToString(si.Base), uint32ToString(si.ChangeIndex))
+	return fmt.Sprintf("SerialIndex{ Base: %s, ChangeIndex: %s, ContentHash: %s}", uint32ToString(si.Base), uint32ToString(si.ChangeIndex), si.ContentHash)
 }
@@ -22,5 +22,19 @@ package models
 import "testing"
 
 func TestString_SerialIndex(t *testing.T) {
+	base := uint32(2025080300)
+	changeIndex := uint32(2)
+	testCases := []struct {
+		si   *SerialIndex
+		want string
+	}{
+		{&SerialIndex{}, "SerialIndex{ Base: <nil>, ChangeIndex: <nil>, ContentHash: }"},
+		{&SerialIndex{Base: &base, ChangeIndex: &changeIndex, ContentHash: "abc"}, "SerialIndex{ Base: 2025080300, ChangeIndex: 2, ContentHash: abc}"},
+	}
 
+	for _, tc := range testCases {
+		if got := tc.si.String(); got != tc.want {
+			t.Errorf("incorrect string: '%s', want: '%s'", got, tc.want)
+		}
+	}
 }
@@ -0,0 +1,149 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A single value of the keyed form of an SOA record, either a scalar or a value with a comment
+type SOAField struct {
+	Value   string `yaml:"value" validate:"required"`
+	Comment string `yaml:"comment,omitempty" validate:"omitempty"`
+}
+
+// The keyed form of the values of an SOA record, an alternative to the positional values. Serial is optional, it's
+// generated when generate_serial is true. Any field that isn't set is taken from the SOA defaults of the zone.
+type SOAFields struct {
+	MName   *SOAField `yaml:"mname,omitempty" validate:"omitempty"`
+	RName   *SOAField `yaml:"rname,omitempty" validate:"omitempty"`
+	Serial  *SOAField `yaml:"serial,omitempty" validate:"omitempty"`
+	Refresh *SOAField `yaml:"refresh,omitempty" validate:"omitempty"`
+	Retry   *SOAField `yaml:"retry,omitempty" validate:"omitempty"`
+	Expire  *SOAField `yaml:"expire,omitempty" validate:"omitempty"`
+	Minimum *SOAField `yaml:"minimum,omitempty" validate:"omitempty"`
+}
+
+func (f *SOAField) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		f.Value = node.Value
+		return nil
+	case yaml.MappingNode:
+		type plain SOAField
+		if err := node.Decode((*plain)(f)); err != nil {
+			return err
+		}
+		return checkKnownKeys(node, reflect.TypeOf(plain{}), "models.SOAField")
+	default:
+		return &yaml.TypeError{Errors: []string{fmt.Sprintf("line %d: cannot unmarshal %s into models.SOAField, expected a value or a mapping of value and comment", node.Line, node.ShortTag())}}
+	}
+}
+
+func (f *SOAFields) UnmarshalYAML(node *yaml.Node) error {
+	type plain SOAFields
+	if err := node.Decode((*plain)(f)); err != nil {
+		return err
+	}
+	return checkKnownKeys(node, reflect.TypeOf(plain{}), "models.SOAFields")
+}
+
+// Returns new fields where each field that isn't set is taken from defaults
+func (f *SOAFields) WithDefaults(defaults *SOAFields) *SOAFields {
+	if nil == defaults {
+		return f
+	}
+	if nil == f {
+		f = &SOAFields{}
+	}
+
+	return &SOAFields{
+		MName:   pick(f.MName != nil, f.MName, defaults.MName),
+		RName:   pick(f.RName != nil, f.RName, defaults.RName),
+		Serial:  pick(f.Serial != nil, f.Serial, defaults.Serial),
+		Refresh: pick(f.Refresh != nil, f.Refresh, defaults.Refresh),
+		Retry:   pick(f.Retry != nil, f.Retry, defaults.Retry),
+		Expire:  pick(f.Expire != nil, f.Expire, defaults.Expire),
+		Minimum: pick(f.Minimum != nil, f.Minimum, defaults.Minimum),
+	}
+}
+
+// Returns the positional values of the SOA record, the serial is left out when it isn't set
+func (f *SOAFields) Values() ([]*ResourceRecordValue, error) {
+	if nil == f {
+		f = &SOAFields{}
+	}
+
+	fields := []struct {
+		name     string
+		field    *SOAField
+		optional bool
+	}{
+		{name: "mname", field: f.MName},
+		{name: "rname", field: f.RName},
+		{name: "serial", field: f.Serial, optional: true},
+		{name: "refresh", field: f.Refresh},
+		{name: "retry", field: f.Retry},
+		{name: "expire", field: f.Expire},
+		{name: "minimum", field: f.Minimum},
+	}
+
+	var values []*ResourceRecordValue
+	var missing []string
+	for _, field := range fields {
+		switch {
+		case field.field != nil:
+			values = append(values, &ResourceRecordValue{Value: field.field.Value, Comment: field.field.Comment})
+		case !field.optional:
+			missing = append(missing, field.name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing %s, set them on the SOA record or in the soa_defaults of the zone", strings.Join(missing, ", "))
+	}
+	return values, nil
+}
+
+// Returns a copy of the fields which doesn't share anything with the original
+func (f *SOAFields) Clone() *SOAFields {
+	if nil == f {
+		return nil
+	}
+	clone := func(field *SOAField) *SOAField {
+		if nil == field {
+			return nil
+		}
+		c := *field
+		return &c
+	}
+	return &SOAFields{
+		MName:   clone(f.MName),
+		RName:   clone(f.RName),
+		Serial:  clone(f.Serial),
+		Refresh: clone(f.Refresh),
+		Retry:   clone(f.Retry),
+		Expire:  clone(f.Expire),
+		Minimum: clone(f.Minimum),
+	}
+}
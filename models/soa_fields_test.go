@@ -0,0 +1,130 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnmarshalYAML_SOAFields(t *testing.T) {
+	testCases := []struct {
+		name string
+		yaml string
+		want *SOAFields
+		err  string
+	}{
+		{
+			name: "scalars-and-mappings",
+			yaml: "mname: ns1.example.com.\nrefresh:\n  value: 7200\n  comment: refresh interval\n",
+			want: &SOAFields{MName: &SOAField{Value: "ns1.example.com."}, Refresh: &SOAField{Value: "7200", Comment: "refresh interval"}},
+		},
+		{name: "unknown-key", yaml: "retry:\n  value: 600\n  coment: typo\n", err: "yaml: unmarshal errors:\n  line 3: field coment not found in type models.SOAField"},
+		{name: "unknown-field", yaml: "mnmae: ns1.example.com.\n", err: "yaml: unmarshal errors:\n  line 1: field mnmae not found in type models.SOAFields"},
+		{name: "sequence", yaml: "expire: [1, 2]\n", err: "yaml: unmarshal errors:\n  line 1: cannot unmarshal !!seq into models.SOAField, expected a value or a mapping of value and comment"},
+	}
+
+	for _, tc := range testCases {
+		got := &SOAFields{}
+		err := yaml.Unmarshal([]byte(tc.yaml), got)
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("%s - incorrect error: '%v', want: '%s'", tc.name, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s - unexpected error: %s", tc.name, err)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s - incorrect fields:\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestWithDefaults_SOAFields(t *testing.T) {
+	defaults := &SOAFields{MName: &SOAField{Value: "ns1.example.com."}, RName: &SOAField{Value: "hostmaster.example.com."}, Refresh: &SOAField{Value: "7200"}}
+	fields := &SOAFields{RName: &SOAField{Value: "admin.example.com.", Comment: "zone admin"}}
+
+	want := &SOAFields{MName: &SOAField{Value: "ns1.example.com."}, RName: &SOAField{Value: "admin.example.com.", Comment: "zone admin"}, Refresh: &SOAField{Value: "7200"}}
+	if diff := cmp.Diff(want, fields.WithDefaults(defaults)); diff != "" {
+		t.Errorf("incorrect fields:\n%s", diff)
+	}
+	if diff := cmp.Diff(defaults, (*SOAFields)(nil).WithDefaults(defaults)); diff != "" {
+		t.Errorf("incorrect fields for nil:\n%s", diff)
+	}
+	if fields.WithDefaults(nil) != fields {
+		t.Error("expected the fields to be returned as is without defaults")
+	}
+}
+
+func TestValues_SOAFields(t *testing.T) {
+	fields := &SOAFields{
+		MName:   &SOAField{Value: "ns1.example.com."},
+		RName:   &SOAField{Value: "hostmaster.example.com.", Comment: "contact"},
+		Refresh: &SOAField{Value: "7200"},
+		Retry:   &SOAField{Value: "600"},
+		Expire:  &SOAField{Value: "3600000"},
+		Minimum: &SOAField{Value: "172800"},
+	}
+
+	values, err := fields.Values()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []*ResourceRecordValue{{Value: "ns1.example.com."}, {Value: "hostmaster.example.com.", Comment: "contact"}, {Value: "7200"}, {Value: "600"}, {Value: "3600000"}, {Value: "172800"}}
+	if diff := cmp.Diff(want, values); diff != "" {
+		t.Errorf("incorrect values without a serial:\n%s", diff)
+	}
+
+	fields.Serial = &SOAField{Value: "2025010101"}
+	values, err = fields.Values()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(values) != 7 || values[2].Value != "2025010101" {
+		t.Errorf("incorrect values with a serial: %v", values)
+	}
+
+	_, err = (&SOAFields{MName: &SOAField{Value: "ns1.example.com."}}).Values()
+	wantErr := "missing rname, refresh, retry, expire, minimum, set them on the SOA record or in the soa_defaults of the zone"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("incorrect error: '%v', want: '%s'", err, wantErr)
+	}
+}
+
+func TestClone_SOAFields(t *testing.T) {
+	if (*SOAFields)(nil).Clone() != nil {
+		t.Error("expected the clone of nil to be nil")
+	}
+
+	fields := &SOAFields{MName: &SOAField{Value: "ns1.example.com.", Comment: "primary"}}
+	clone := fields.Clone()
+	if diff := cmp.Diff(fields, clone); diff != "" {
+		t.Errorf("incorrect clone:\n%s", diff)
+	}
+	clone.MName.Value = "ns2.example.com."
+	if fields.MName.Value != "ns1.example.com." {
+		t.Error("the clone shares data with the original")
+	}
+}
@@ -0,0 +1,32 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+// A named set of resource records that zones can include, see Zone.Include
+type Template struct {
+	ResourceRecords map[string]*ResourceRecord `yaml:"resource_records" validate:"omitempty,dive"`
+	sourcePositions `yaml:"-"`
+}
+
+// The contents of a YAML input file, the top level keys are the zones except for templates
+type ZoneFile struct {
+	Templates map[string]*Template `yaml:"templates,omitempty"`
+	Zones     map[string]*Zone     `yaml:",inline"`
+}
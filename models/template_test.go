@@ -0,0 +1,45 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import "testing"
+
+func TestPositions_Template(t *testing.T) {
+	template := &Template{}
+	if template.Position() != nil || template.ResourceRecordPosition("ns1") != nil {
+		t.Error("expected no positions for a template that wasn't read from a file")
+	}
+
+	templatePosition := &SourcePosition{File: "zones.yaml", Line: 2, Column: 3}
+	recordPosition := &SourcePosition{File: "zones.yaml", Line: 4, Column: 7}
+	template.SetPosition(templatePosition)
+	template.SetResourceRecordPosition("ns1", recordPosition)
+
+	if template.Position() != templatePosition {
+		t.Errorf("incorrect template position: %s, want: %s", template.Position(), templatePosition)
+	}
+	if template.ResourceRecordPosition("ns1") != recordPosition {
+		t.Errorf("incorrect resource record position: %s, want: %s", template.ResourceRecordPosition("ns1"), recordPosition)
+	}
+	// Records without a position of their own fall back to the template's
+	if template.ResourceRecordPosition("mx") != templatePosition {
+		t.Errorf("incorrect fallback position: %s, want: %s", template.ResourceRecordPosition("mx"), templatePosition)
+	}
+}
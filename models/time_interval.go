@@ -0,0 +1,119 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Parses a time interval (e.g. a TTL or the refresh of an SOA record) as BIND accepts it, either a number of seconds
+// or a sequence of numbers with a unit (w, d, h, m or s), e.g. 1w3d or 2h30m.
+func ParseTimeInterval(s string) (int32, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty time interval")
+	}
+	if seconds, err := strconv.ParseInt(s, 10, 32); err == nil {
+		if seconds < 0 {
+			return 0, fmt.Errorf("invalid time interval '%s'", s)
+		}
+		return int32(seconds), nil
+	}
+
+	units := map[byte]int64{'w': 604800, 'd': 86400, 'h': 3600, 'm': 60, 's': 1}
+	var total, current int64
+	digits := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			current = current*10 + int64(c-'0')
+			digits++
+		case units[toLowerASCII(c)] != 0 && digits > 0:
+			total += current * units[toLowerASCII(c)]
+			current, digits = 0, 0
+		default:
+			return 0, fmt.Errorf("invalid time interval '%s', expected a number of seconds or numbers with a unit (w, d, h, m or s) e.g. 1h30m", s)
+		}
+		if total+current > 2147483647 {
+			return 0, fmt.Errorf("time interval '%s' is larger than a 32 bit integer", s)
+		}
+	}
+	// A trailing number without a unit is a number of seconds
+	return int32(total + current), nil
+}
+
+// Returns the time interval in seconds unless it uses units, this is a nop for anything that isn't a time interval
+func TimeIntervalInSeconds(s string) string {
+	if seconds, err := ParseTimeInterval(s); err == nil {
+		return strconv.Itoa(int(seconds))
+	}
+	return s
+}
+
+// Renders the time interval the way it was written when text still has the same value, otherwise in seconds
+func renderTimeInterval(value int32, text string) string {
+	if seconds, err := ParseTimeInterval(text); err == nil && seconds == value {
+		return text
+	}
+	return strconv.Itoa(int(value))
+}
+
+// Returns a copy of the mapping node where the values of keys that are time intervals with units are replaced by the
+// number of seconds so they decode into an int32. The text of each replaced value is returned by key.
+func withTimeIntervals(node *yaml.Node, keys ...string) (*yaml.Node, map[string]string, error) {
+	if node.Kind != yaml.MappingNode {
+		return node, nil, nil
+	}
+
+	texts := make(map[string]string)
+	decoded := *node
+	decoded.Content = make([]*yaml.Node, len(node.Content))
+	copy(decoded.Content, node.Content)
+	var errs []string
+	for i := 0; i+1 < len(decoded.Content); i += 2 {
+		value := decoded.Content[i+1]
+		if !slices.Contains(keys, decoded.Content[i].Value) || value.Kind != yaml.ScalarNode || value.ShortTag() != "!!str" {
+			continue
+		}
+
+		seconds, err := ParseTimeInterval(value.Value)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: %s", value.Line, err))
+			continue
+		}
+		texts[decoded.Content[i].Value] = value.Value
+		decoded.Content[i+1] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(int(seconds)), Line: value.Line, Column: value.Column}
+	}
+	if len(errs) > 0 {
+		return nil, nil, &yaml.TypeError{Errors: errs}
+	}
+	return &decoded, texts, nil
+}
+
+func toLowerASCII(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + ('a' - 'A')
+	}
+	return c
+}
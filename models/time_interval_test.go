@@ -0,0 +1,133 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseTimeInterval(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want int32
+		err  string
+	}{
+		{s: "3600", want: 3600},
+		{s: "1h", want: 3600},
+		{s: "1W3D", want: 864000},
+		{s: "2h30m", want: 9000},
+		{s: "1h30", want: 3630},
+		{s: "", err: "empty time interval"},
+		{s: "-1", err: "invalid time interval '-1'"},
+		{s: "h", err: "invalid time interval 'h', expected a number of seconds or numbers with a unit (w, d, h, m or s) e.g. 1h30m"},
+		{s: "1y", err: "invalid time interval '1y', expected a number of seconds or numbers with a unit (w, d, h, m or s) e.g. 1h30m"},
+		{s: "9999999999", err: "time interval '9999999999' is larger than a 32 bit integer"},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParseTimeInterval(tc.s)
+		if err != nil {
+			if err.Error() != tc.err {
+				t.Errorf("%s: incorrect error: '%s', want: '%s'", tc.s, err, tc.err)
+			}
+			continue
+		}
+		if tc.err != "" {
+			t.Errorf("%s: expected an error, found none", tc.s)
+		}
+		if got != tc.want {
+			t.Errorf("%s: incorrect time interval: %d, want: %d", tc.s, got, tc.want)
+		}
+	}
+}
+
+func TestTimeIntervalInSeconds(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want string
+	}{
+		{s: "300", want: "300"},
+		{s: "1d", want: "86400"},
+		{s: "testing", want: "testing"},
+	}
+
+	for _, tc := range testCases {
+		if got := TimeIntervalInSeconds(tc.s); got != tc.want {
+			t.Errorf("%s: incorrect result: '%s', want: '%s'", tc.s, got, tc.want)
+		}
+	}
+}
+
+func TestRenderTimeInterval(t *testing.T) {
+	testCases := []struct {
+		value int32
+		text  string
+		want  string
+	}{
+		{value: 3600, want: "3600"},
+		{value: 3600, text: "1h", want: "1h"},
+		{value: 3600, text: "60m", want: "60m"},
+		// The value was changed after it was read, the text is out of date
+		{value: 300, text: "1h", want: "300"},
+	}
+
+	for _, tc := range testCases {
+		if got := renderTimeInterval(tc.value, tc.text); got != tc.want {
+			t.Errorf("%d/%s: incorrect render: '%s', want: '%s'", tc.value, tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestWithTimeIntervals(t *testing.T) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte("ttl: 1h\nvalue: 1d\nother: 300\n"), &node); err != nil {
+		t.Fatal(err)
+	}
+	mapping := node.Content[0]
+
+	decoded, texts, err := withTimeIntervals(mapping, "ttl", "other")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if decoded.Content[1].Value != "3600" || decoded.Content[1].ShortTag() != "!!int" {
+		t.Errorf("incorrect ttl node: %s %s", decoded.Content[1].ShortTag(), decoded.Content[1].Value)
+	}
+	if decoded.Content[3].Value != "1d" {
+		t.Errorf("incorrect value node, it isn't a time interval: %s", decoded.Content[3].Value)
+	}
+	if len(texts) != 1 || texts["ttl"] != "1h" {
+		t.Errorf("incorrect texts: %v", texts)
+	}
+	// The original node must not be changed
+	if mapping.Content[1].Value != "1h" {
+		t.Errorf("the original node was changed: %s", mapping.Content[1].Value)
+	}
+
+	if err := yaml.Unmarshal([]byte("ttl: soon\n"), &node); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = withTimeIntervals(node.Content[0], "ttl")
+	want := "yaml: unmarshal errors:\n  line 1: invalid time interval 'soon', expected a number of seconds or numbers with a unit (w, d, h, m or s) e.g. 1h30m"
+	if err == nil || err.Error() != want {
+		t.Errorf("incorrect error: '%v', want: '%s'", err, want)
+	}
+}
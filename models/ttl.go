@@ -21,11 +21,47 @@ package models
 
 import (
 	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
 )
 
 type TTL struct {
 	Value   *int32 `yaml:"value" validate:"omitempty,min=0,max=2147483647"` // The use of a pointer to an int32 allows us to handle missing (nil) values more easily
-	Comment string `yaml:"comment" validate:"omitempty"`
+	Comment string `yaml:"comment,omitempty" validate:"omitempty"`
+	// The value as it was written when it used units (e.g. 1h), it's rendered that way when keep_time_units is set
+	text string
+}
+
+// The value can be a number of seconds or use units (e.g. 1h), either way it's stored as a number of seconds
+func (t *TTL) UnmarshalYAML(node *yaml.Node) error {
+	type plain TTL
+	decoded, texts, err := withTimeIntervals(node, "value")
+	if err != nil {
+		return err
+	}
+	if err := decoded.Decode((*plain)(t)); err != nil {
+		return err
+	}
+	t.text = texts["value"]
+	return checkKnownKeys(node, reflect.TypeOf(plain{}), "models.TTL")
+}
+
+// Forgets how the value was written so it's rendered as a number of seconds
+func (t *TTL) DiscardTimeUnits() {
+	if nil != t {
+		t.text = ""
+	}
+}
+
+// The value as it was written when it used units (e.g. 1h), empty when it was a number of seconds
+func (t *TTL) Text() string {
+	return t.text
+}
+
+// Sets how the value was written, e.g. when the TTL is received from a plugin
+func (t *TTL) SetText(text string) {
+	t.text = text
 }
 
 func (ttl *TTL) String() string {
@@ -38,7 +74,7 @@ func (t *TTL) Render() string {
 		if comment != "" {
 			comment = " ;" + comment
 		}
-		return fmt.Sprintf("$TTL %d%s", *t.Value, comment)
+		return fmt.Sprintf("$TTL %s%s", renderTimeInterval(*t.Value, t.text), comment)
 	}
 	return ""
 }
@@ -19,7 +19,11 @@
 
 package models
 
-import "testing"
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
 
 func TestString_TTL(t *testing.T) {
 	ttl := &TTL{Value: toInt32Ptr(99), Comment: "comment"}
@@ -46,6 +50,7 @@ func TestRender_TTL(t *testing.T) {
 		{ttl: &TTL{Value: toInt32Ptr(999999)}, want: "$TTL 999999"},
 		{ttl: &TTL{Value: nil}, want: ""},
 		{ttl: &TTL{Value: nil, Comment: "doesn't matter if this is here"}, want: ""},
+		{ttl: &TTL{Value: toInt32Ptr(86400), text: "1d"}, want: "$TTL 1d"},
 	}
 
 	for _, tc := range testCases {
@@ -54,3 +59,54 @@ func TestRender_TTL(t *testing.T) {
 		}
 	}
 }
+
+func TestUnmarshalYAML_TTL(t *testing.T) {
+	testCases := []struct {
+		yaml     string
+		want     int32
+		wantText string
+		err      string
+	}{
+		{yaml: "value: 300\n", want: 300},
+		{yaml: "value: 1w3d\ncomment: ten days\n", want: 864000, wantText: "1w3d"},
+		{yaml: "value: soon\n", err: "yaml: unmarshal errors:\n  line 1: invalid time interval 'soon', expected a number of seconds or numbers with a unit (w, d, h, m or s) e.g. 1h30m"},
+		{yaml: "value: 300\ncoment: typo\n", err: "yaml: unmarshal errors:\n  line 2: field coment not found in type models.TTL"},
+	}
+
+	for _, tc := range testCases {
+		ttl := &TTL{}
+		err := yaml.Unmarshal([]byte(tc.yaml), ttl)
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("incorrect error: '%v', want: '%s'", err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+			continue
+		}
+		if *ttl.Value != tc.want || ttl.text != tc.wantText {
+			t.Errorf("incorrect TTL: %d (%s), want: %d (%s)", *ttl.Value, ttl.text, tc.want, tc.wantText)
+		}
+	}
+}
+
+func TestDiscardTimeUnits_TTL(t *testing.T) {
+	ttl := &TTL{Value: toInt32Ptr(3600), text: "1h"}
+	ttl.DiscardTimeUnits()
+	if ttl.Render() != "$TTL 3600" {
+		t.Errorf("incorrect render: '%s', want: '$TTL 3600'", ttl.Render())
+	}
+
+	// A zone doesn't have to have a TTL
+	(*TTL)(nil).DiscardTimeUnits()
+}
+
+func TestText_TTL(t *testing.T) {
+	ttl := &TTL{Value: toInt32Ptr(3600)}
+	ttl.SetText("1h")
+	if ttl.Text() != "1h" || ttl.Render() != "$TTL 1h" {
+		t.Errorf("incorrect text: '%s' (%s), want: '1h'", ttl.Text(), ttl.Render())
+	}
+}
@@ -0,0 +1,206 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Where something was defined in an input file, Line and Column start at 1, zero means unknown
+type SourcePosition struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (p *SourcePosition) String() string {
+	switch {
+	case p.Line == 0:
+		return p.File
+	case p.Column == 0:
+		return fmt.Sprintf("%s:%d", p.File, p.Line)
+	default:
+		return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+	}
+}
+
+// Where a zone or template and each of its resource records were defined, only set when read from a file
+type sourcePositions struct {
+	position        *SourcePosition
+	recordPositions map[string]*SourcePosition
+}
+
+func (p *sourcePositions) Position() *SourcePosition {
+	return p.position
+}
+
+func (p *sourcePositions) SetPosition(position *SourcePosition) {
+	p.position = position
+}
+
+// Returns the position of the resource record, falling back to the position of the zone or template when it isn't known
+func (p *sourcePositions) ResourceRecordPosition(identifier string) *SourcePosition {
+	if position, ok := p.recordPositions[identifier]; ok {
+		return position
+	}
+	return p.position
+}
+
+func (p *sourcePositions) SetResourceRecordPosition(identifier string, position *SourcePosition) {
+	if nil == p.recordPositions {
+		p.recordPositions = make(map[string]*SourcePosition)
+	}
+	p.recordPositions[identifier] = position
+}
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Identifies the kind of problem, plugin rules are prefixed with the resource record type (e.g. CNAME/validate-zone)
+const (
+	RuleYAMLSyntax      = "yaml-syntax"
+	RuleYAMLType        = "yaml-type"
+	RuleSchema          = "schema"
+	RuleMissingZone     = "missing-zone"
+	RuleConfig          = "config"
+	RuleMissingPlugin   = "missing-plugin"
+	RuleDuplicate       = "duplicate"
+	RuleTemplate        = "template"
+	RuleExtends         = "extends"
+	RuleSOA             = "soa"
+	RuleGenerate        = "generate"
+	RuleInput           = "input"
+	RuleCNAMETarget     = "cname-target"
+	RuleCNAMEApex       = "cname-apex"
+	RuleCNAMEConflict   = "cname-conflict"
+	RuleDuplicateRecord = "duplicate-record"
+	RuleTTLMismatch     = "ttl-mismatch"
+	RuleDelegation      = "delegation"
+)
+
+// A single problem found while reading or normalizing the zones, Position, Zone, Template and Identifier are only set when known
+type ValidationError struct {
+	Position   *SourcePosition
+	Zone       string
+	Template   string
+	Identifier string
+	Rule       string
+	// Empty is treated as SeverityError
+	Severity Severity
+	Err      error
+}
+
+func (e *ValidationError) Level() Severity {
+	if e.Severity == "" {
+		return SeverityError
+	}
+	return e.Severity
+}
+
+func (e *ValidationError) Error() string {
+	var context []string
+	if e.Zone != "" {
+		context = append(context, fmt.Sprintf("zone '%s'", e.Zone))
+	}
+	if e.Template != "" {
+		context = append(context, fmt.Sprintf("template '%s'", e.Template))
+	}
+	if e.Identifier != "" {
+		context = append(context, fmt.Sprintf("identifier '%s'", e.Identifier))
+	}
+
+	var message strings.Builder
+	if e.Level() == SeverityWarning {
+		message.WriteString("warning: ")
+	}
+	if e.Position != nil {
+		message.WriteString(e.Position.String())
+		message.WriteString(": ")
+	}
+	if len(context) > 0 {
+		message.WriteString(strings.Join(context, ", "))
+		message.WriteString(": ")
+	}
+	message.WriteString(e.Err.Error())
+	return message.String()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Every problem found, in the order they were found, so they can all be fixed at once
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	var errs strings.Builder
+	fmt.Fprintf(&errs, "found %d errors:", len(e))
+	for _, err := range e {
+		errs.WriteString("\n  ")
+		errs.WriteString(err.Error())
+	}
+	return errs.String()
+}
+
+// Sorts the errors into the order they appear in the input files, errors without a position keep their order
+// and are moved to the end
+func (e ValidationErrors) SortByPosition() {
+	sort.SliceStable(e, func(i, j int) bool {
+		a, b := e[i].Position, e[j].Position
+		switch {
+		case a == nil || b == nil:
+			return a != nil && b == nil
+		case a.File != b.File:
+			return a.File < b.File
+		case a.Line != b.Line:
+			return a.Line < b.Line
+		default:
+			return a.Column < b.Column
+		}
+	})
+}
+
+// Checks if any of the problems is an error, warnings alone don't make the input invalid
+func (e ValidationErrors) HasErrors() bool {
+	for _, err := range e {
+		if err.Level() == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns nil when there are no errors so the result can be returned as an error directly
+func (e ValidationErrors) OrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
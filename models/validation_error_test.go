@@ -0,0 +1,140 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestString_SourcePosition(t *testing.T) {
+	testCases := []struct {
+		position *SourcePosition
+		want     string
+	}{
+		{position: &SourcePosition{File: "zones.yaml"}, want: "zones.yaml"},
+		{position: &SourcePosition{File: "zones.yaml", Line: 3}, want: "zones.yaml:3"},
+		{position: &SourcePosition{File: "zones.yaml", Line: 3, Column: 5}, want: "zones.yaml:3:5"},
+	}
+
+	for _, tc := range testCases {
+		if tc.position.String() != tc.want {
+			t.Errorf("incorrect string: '%s', want: '%s'", tc.position.String(), tc.want)
+		}
+	}
+}
+
+func TestError_ValidationError(t *testing.T) {
+	position := &SourcePosition{File: "zones.yaml", Line: 3, Column: 5}
+	testCases := []struct {
+		err  *ValidationError
+		want string
+	}{
+		{err: &ValidationError{Err: errors.New("testing")}, want: "testing"},
+		{err: &ValidationError{Position: position, Err: errors.New("testing")}, want: "zones.yaml:3:5: testing"},
+		{err: &ValidationError{Zone: "example.com.", Err: errors.New("testing")}, want: "zone 'example.com.': testing"},
+		{err: &ValidationError{Position: position, Zone: "example.com.", Identifier: "www", Err: errors.New("testing")}, want: "zones.yaml:3:5: zone 'example.com.', identifier 'www': testing"},
+		{err: &ValidationError{Template: "common", Identifier: "ns1", Err: errors.New("testing")}, want: "template 'common', identifier 'ns1': testing"},
+		{err: &ValidationError{Position: position, Zone: "example.com.", Severity: SeverityWarning, Err: errors.New("testing")}, want: "warning: zones.yaml:3:5: zone 'example.com.': testing"},
+	}
+
+	for _, tc := range testCases {
+		if tc.err.Error() != tc.want {
+			t.Errorf("incorrect error: '%s', want: '%s'", tc.err, tc.want)
+		}
+	}
+
+	wrapped := errors.New("wrapped")
+	if !errors.Is(&ValidationError{Err: wrapped}, wrapped) {
+		t.Error("expected the validation error to wrap the error")
+	}
+}
+
+func TestError_ValidationErrors(t *testing.T) {
+	errs := ValidationErrors{{Zone: "one.", Err: errors.New("first")}}
+	want := "zone 'one.': first"
+	if errs.Error() != want {
+		t.Errorf("incorrect error: '%s', want: '%s'", errs, want)
+	}
+
+	errs = append(errs, &ValidationError{Zone: "two.", Identifier: "www", Err: errors.New("second")})
+	want = "found 2 errors:\n  zone 'one.': first\n  zone 'two.', identifier 'www': second"
+	if errs.Error() != want {
+		t.Errorf("incorrect error: '%s', want: '%s'", errs, want)
+	}
+}
+
+func TestOrNil_ValidationErrors(t *testing.T) {
+	var errs ValidationErrors
+	if errs.OrNil() != nil {
+		t.Error("expected nil for no errors")
+	}
+
+	errs = append(errs, &ValidationError{Err: errors.New("testing")})
+	if errs.OrNil() == nil {
+		t.Error("expected an error, found nil")
+	}
+}
+
+func TestHasErrors_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		errs ValidationErrors
+		want bool
+	}{
+		{},
+		{errs: ValidationErrors{{Severity: SeverityWarning, Err: errors.New("warning")}}},
+		{errs: ValidationErrors{{Severity: SeverityWarning, Err: errors.New("warning")}, {Err: errors.New("error")}}, want: true},
+		{errs: ValidationErrors{{Severity: SeverityError, Err: errors.New("error")}}, want: true},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.errs.HasErrors(); got != tc.want {
+			t.Errorf("incorrect result for %v: %t, want: %t", tc.errs, got, tc.want)
+		}
+	}
+}
+
+func TestSortByPosition_ValidationErrors(t *testing.T) {
+	errs := ValidationErrors{
+		{Err: errors.New("no-position")},
+		{Position: &SourcePosition{File: "b.yaml", Line: 1}, Err: errors.New("b1")},
+		{Position: &SourcePosition{File: "a.yaml", Line: 7, Column: 9}, Err: errors.New("a7-9")},
+		{Position: &SourcePosition{File: "a.yaml", Line: 7, Column: 3}, Err: errors.New("a7-3")},
+		{Position: &SourcePosition{File: "a.yaml", Line: 2}, Err: errors.New("a2")},
+	}
+
+	errs.SortByPosition()
+
+	want := []string{"a.yaml:2: a2", "a.yaml:7:3: a7-3", "a.yaml:7:9: a7-9", "b.yaml:1: b1", "no-position"}
+	for i, w := range want {
+		if errs[i].Error() != w {
+			t.Errorf("incorrect error at %d: '%s', want: '%s'", i, errs[i], w)
+		}
+	}
+}
+
+func TestLevel_ValidationError(t *testing.T) {
+	if level := (&ValidationError{}).Level(); level != SeverityError {
+		t.Errorf("incorrect default level: '%s', want: '%s'", level, SeverityError)
+	}
+	if level := (&ValidationError{Severity: SeverityWarning}).Level(); level != SeverityWarning {
+		t.Errorf("incorrect level: '%s', want: '%s'", level, SeverityWarning)
+	}
+}
@@ -18,16 +18,44 @@ package models
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 )
 
 // Represents the overall Zone file structure, the YAML file is an array of these
 type Zone struct {
-	Config                *Config                    `yaml:"config" validate:"omitempty"`
+	Config                *Config                    `yaml:"config,omitempty" validate:"omitempty"`
 	ResourceRecords       map[string]*ResourceRecord `yaml:"resource_records" validate:"omitempty,dive"`
-	TTL                   *TTL                       `yaml:"ttl" validate:"omitempty"`
+	TTL                   *TTL                       `yaml:"ttl,omitempty" validate:"omitempty"`
+	Include               []string                   `yaml:"include,omitempty" validate:"omitempty"`      // The names of the templates whose resource records are added to the zone
+	Extends               string                     `yaml:"extends,omitempty" validate:"omitempty"`      // The name of the zone or template the zone inherits from
+	Remove                []string                   `yaml:"remove,omitempty" validate:"omitempty"`       // The identifiers of the inherited resource records to leave out
+	SOADefaults           *SOAFields                 `yaml:"soa_defaults,omitempty" validate:"omitempty"` // The fields of the keyed SOA record that it doesn't set
 	resourceRecordsByType map[ResourceRecordType]map[string]*ResourceRecord
+	sourcePositions       `yaml:"-"`
+	// The resource records with a generate that each of the resource records was expanded from
+	generatedFrom map[string]*ResourceRecord
+}
+
+// Returns the resource record with a generate that the resource record was expanded from, nil when it wasn't
+func (z *Zone) GeneratedFrom(identifier string) *ResourceRecord {
+	return z.generatedFrom[identifier]
+}
+
+func (z *Zone) SetGeneratedFrom(identifier string, rr *ResourceRecord) {
+	if nil == z.generatedFrom {
+		z.generatedFrom = make(map[string]*ResourceRecord)
+	}
+	z.generatedFrom[identifier] = rr
+}
+
+// Forgets how the TTLs of the zone and its resource records were written so they're rendered as a number of seconds
+func (z *Zone) DiscardTimeUnits() {
+	z.TTL.DiscardTimeUnits()
+	for _, rr := range z.ResourceRecords {
+		rr.DiscardTimeUnits()
+	}
 }
 
 func (z *Zone) String() string {
@@ -102,3 +130,53 @@ func (z *Zone) sortedResourceRecordKeys() []string {
 	sort.Strings(keys)
 	return keys
 }
+
+// Calls fn for each resource record in the order they belong in a zone file: the SOA record first, then the NS records
+// of the zone itself (sorted by identifier) followed by the rest of the resource records in the specified order
+func (z *Zone) WithRenderOrderedResourceRecords(zoneName string, order RecordOrder, fn func(identifier string, rr *ResourceRecord) error) error {
+	for _, identifier := range z.renderOrderedResourceRecordKeys(zoneName, order) {
+		if err := fn(identifier, z.ResourceRecords[identifier]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (z *Zone) renderOrderedResourceRecordKeys(zoneName string, order RecordOrder) []string {
+	// SOA, then the apex NS records, then everything else
+	group := func(rr *ResourceRecord) int {
+		switch {
+		case rr.Type == SOA:
+			return 0
+		case rr.Type == NS && AbsoluteName(rr.Name, zoneName) == AbsoluteName("@", zoneName):
+			return 1
+		default:
+			return 2
+		}
+	}
+
+	keys := z.sortedResourceRecordKeys()
+	slices.SortStableFunc(keys, func(a string, b string) int {
+		rrA, rrB := z.ResourceRecords[a], z.ResourceRecords[b]
+		if c := group(rrA) - group(rrB); c != 0 || group(rrA) < 2 {
+			// The keys are already sorted by identifier so there's nothing more to compare
+			return c
+		}
+
+		switch order {
+		case RecordOrderName:
+			if c := CompareNames(AbsoluteName(rrA.Name, zoneName), AbsoluteName(rrB.Name, zoneName)); c != 0 {
+				return c
+			}
+			return strings.Compare(string(rrA.Type), string(rrB.Type))
+		case RecordOrderType:
+			if c := strings.Compare(string(rrA.Type), string(rrB.Type)); c != 0 {
+				return c
+			}
+			return CompareNames(AbsoluteName(rrA.Name, zoneName), AbsoluteName(rrB.Name, zoneName))
+		default:
+			return 0
+		}
+	})
+	return keys
+}
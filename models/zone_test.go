@@ -20,6 +20,7 @@
 package models
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -35,7 +36,7 @@ func TestString_Zone(t *testing.T) {
 		TTL: &TTL{Value: toInt32Ptr(33), Comment: "ttl comment"},
 	}
 	want := "Zone{\n" +
-		"   Config: Config{ GenerateSerial: false, GenerateReverseLookupZones: false, SerialChangeIndexDirectory: , IsCatalog: false, CatalogIncludeReverseZones: false }\n" +
+		"   Config: Config{ GenerateSerial: false, GenerateReverseLookupZones: false, SerialChangeIndexDirectory: , IsCatalog: false, CatalogIncludeReverseZones: false, RecordOrder: , KeepTimeUnits: false, PTRPolicy: , ReverseZones: [], CNAMEPolicy: , DelegationPolicy:  }\n" +
 		"   ResourceRecords:\n" +
 		"     example.com. -> ResourceRecord{\n" +
 		"       Name: \n" +
@@ -90,7 +91,7 @@ func TestSOARecord(t *testing.T) {
 			delete(z.ResourceRecords, "one")
 		}
 		actual := z.SOARecord()
-		if !cmp.Equal(actual, tc.want) {
+		if !cmp.Equal(actual, tc.want, cmp.AllowUnexported(ResourceRecord{})) {
 			t.Errorf("incorrect record: '%s', want: '%s'", actual, tc.want)
 		}
 	}
@@ -124,8 +125,8 @@ func TestResourceRecordsByType(t *testing.T) {
 	for _, tc := range testCases {
 		actual := tc.zone.ResourceRecordsByType()
 
-		if cmp.Diff(actual, tc.want) != "" {
-			t.Errorf("incorrect results:\n%s", cmp.Diff(actual, tc.want))
+		if cmp.Diff(actual, tc.want, cmp.AllowUnexported(ResourceRecord{})) != "" {
+			t.Errorf("incorrect results:\n%s", cmp.Diff(actual, tc.want, cmp.AllowUnexported(ResourceRecord{})))
 		}
 	}
 }
@@ -155,8 +156,111 @@ func TestSortedResourceRecordKeys(t *testing.T) {
 	for _, tc := range testCases {
 		actual := tc.zone.sortedResourceRecordKeys()
 
-		if cmp.Diff(actual, tc.want) != "" {
-			t.Errorf("incorrect results:\n%s", cmp.Diff(actual, tc.want))
+		if cmp.Diff(actual, tc.want, cmp.AllowUnexported(ResourceRecord{})) != "" {
+			t.Errorf("incorrect results:\n%s", cmp.Diff(actual, tc.want, cmp.AllowUnexported(ResourceRecord{})))
 		}
 	}
 }
+
+func TestWithRenderOrderedResourceRecords(t *testing.T) {
+	zone := &Zone{
+		ResourceRecords: map[string]*ResourceRecord{
+			"a-host":     {Name: "a-host", Type: A},
+			"b-txt":      {Name: "@", Type: TXT},
+			"c-mx":       {Name: "example.com.", Type: MX},
+			"d-sub-ns":   {Name: "sub", Type: NS},
+			"e-sub-host": {Name: "host.sub", Type: A},
+			"f-wildcard": {Name: "*", Type: A},
+			"ns2":        {Name: "example.com.", Type: NS},
+			"ns1":        {Name: "@", Type: NS},
+			"soa":        {Name: "example.com.", Type: SOA},
+			"z-host":     {Name: "Z-HOST", Type: AAAA},
+			"z-host-a":   {Name: "z-host.example.com.", Type: A},
+		},
+	}
+
+	testCases := []struct {
+		order RecordOrder
+		want  []string
+	}{
+		{order: "", want: []string{"soa", "ns1", "ns2", "a-host", "b-txt", "c-mx", "d-sub-ns", "e-sub-host", "f-wildcard", "z-host", "z-host-a"}},
+		{order: RecordOrderIdentifier, want: []string{"soa", "ns1", "ns2", "a-host", "b-txt", "c-mx", "d-sub-ns", "e-sub-host", "f-wildcard", "z-host", "z-host-a"}},
+		{order: RecordOrderName, want: []string{"soa", "ns1", "ns2", "c-mx", "b-txt", "f-wildcard", "a-host", "d-sub-ns", "e-sub-host", "z-host-a", "z-host"}},
+		{order: RecordOrderType, want: []string{"soa", "ns1", "ns2", "f-wildcard", "a-host", "e-sub-host", "z-host-a", "z-host", "c-mx", "d-sub-ns", "b-txt"}},
+	}
+
+	for _, tc := range testCases {
+		var got []string
+		if err := zone.WithRenderOrderedResourceRecords("example.com", tc.order, func(identifier string, rr *ResourceRecord) error {
+			got = append(got, identifier)
+			return nil
+		}); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+
+		if !cmp.Equal(got, tc.want) {
+			t.Errorf("incorrect order for '%s':\n%s", tc.order, cmp.Diff(tc.want, got))
+		}
+	}
+
+	// Errors from fn stop the iteration
+	count := 0
+	err := zone.WithRenderOrderedResourceRecords("example.com", RecordOrderName, func(identifier string, rr *ResourceRecord) error {
+		count++
+		return errors.New("testing")
+	})
+	if err == nil || err.Error() != "testing" || count != 1 {
+		t.Errorf("incorrect error: %v, after %d calls", err, count)
+	}
+}
+
+func TestPositions_Zone(t *testing.T) {
+	zone := &Zone{}
+	if zone.Position() != nil || zone.ResourceRecordPosition("www") != nil {
+		t.Error("expected no positions for a zone that wasn't read from a file")
+	}
+
+	zonePosition := &SourcePosition{File: "zones.yaml", Line: 1, Column: 1}
+	recordPosition := &SourcePosition{File: "zones.yaml", Line: 4, Column: 5}
+	zone.SetPosition(zonePosition)
+	zone.SetResourceRecordPosition("www", recordPosition)
+
+	if zone.Position() != zonePosition {
+		t.Errorf("incorrect zone position: %s, want: %s", zone.Position(), zonePosition)
+	}
+	if zone.ResourceRecordPosition("www") != recordPosition {
+		t.Errorf("incorrect resource record position: %s, want: %s", zone.ResourceRecordPosition("www"), recordPosition)
+	}
+	// Records without a position of their own fall back to the zone's
+	if zone.ResourceRecordPosition("mail") != zonePosition {
+		t.Errorf("incorrect fallback position: %s, want: %s", zone.ResourceRecordPosition("mail"), zonePosition)
+	}
+}
+
+func TestGeneratedFrom_Zone(t *testing.T) {
+	zone := &Zone{}
+	if zone.GeneratedFrom("hosts[1]") != nil {
+		t.Error("expected no record for a zone without generated records")
+	}
+
+	rr := &ResourceRecord{Type: A, Name: "host-$", Value: "192.0.2.$", Generate: &Generate{Range: "1-2"}}
+	zone.SetGeneratedFrom("hosts[1]", rr)
+	if zone.GeneratedFrom("hosts[1]") != rr {
+		t.Errorf("incorrect record: %s, want: %s", zone.GeneratedFrom("hosts[1]"), rr)
+	}
+	if zone.GeneratedFrom("www") != nil {
+		t.Errorf("incorrect record for a record that wasn't generated: %s", zone.GeneratedFrom("www"))
+	}
+}
+
+func TestDiscardTimeUnits_Zone(t *testing.T) {
+	zone := &Zone{
+		TTL:             &TTL{Value: toInt32Ptr(86400), text: "1d"},
+		ResourceRecords: map[string]*ResourceRecord{"www": {Name: "www", Type: A, TTL: toInt32Ptr(300), ttlText: "5m"}},
+	}
+
+	zone.DiscardTimeUnits()
+	if zone.TTL.text != "" || zone.ResourceRecords["www"].ttlText != "" {
+		t.Errorf("expected the time units to be discarded, found: '%s' and '%s'", zone.TTL.text, zone.ResourceRecords["www"].ttlText)
+	}
+}
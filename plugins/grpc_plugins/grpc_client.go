@@ -21,6 +21,7 @@ package grpc_plugins
 
 import (
 	"context"
+	"errors"
 
 	"github.com/bcurnow/zonemgr/models"
 	"github.com/bcurnow/zonemgr/models/grpc"
@@ -75,11 +76,24 @@ func (c *GRPCClient) Normalize(identifier string, rr *models.ResourceRecord) err
 }
 
 func (c *GRPCClient) ValidateZone(name string, zone *models.Zone) error {
-	_, err := c.client.ValidateZone(context.Background(), &proto.ValidateZoneRequest{
+	resp, err := c.client.ValidateZone(context.Background(), &proto.ValidateZoneRequest{
 		Name: name,
 		Zone: grpc.ZoneToProtoBuf(zone),
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	// Each problem is turned back into the error the plugin returned, a problem with an identifier is a RecordError
+	problems := make([]error, 0, len(resp.Problems))
+	for _, problem := range resp.Problems {
+		var err error = errors.New(problem.Message)
+		if problem.Identifier != "" || problem.Severity != "" {
+			err = &plugins.RecordError{Identifier: problem.Identifier, Severity: models.Severity(problem.Severity), Err: err}
+		}
+		problems = append(problems, err)
+	}
+	return errors.Join(problems...)
 }
 
 func (c *GRPCClient) Render(identifier string, rr *models.ResourceRecord) (string, error) {
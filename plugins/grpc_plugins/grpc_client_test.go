@@ -30,6 +30,7 @@ import (
 	"github.com/bcurnow/zonemgr/plugins"
 	"github.com/bcurnow/zonemgr/plugins/proto"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 func TestPluginVersion_Client(t *testing.T) {
@@ -140,7 +141,7 @@ func TestNormalize_Client(t *testing.T) {
 		handleError(t, err, tc.err)
 
 		if tc.err == nil {
-			if !cmp.Equal(rr, tc.wantedRR) {
+			if !cmp.Equal(rr, tc.wantedRR, cmpopts.IgnoreUnexported(models.ResourceRecord{})) {
 				t.Errorf("incorrect result: '%s', wanted: '%s'", rr, tc.wantedRR)
 			}
 		}
@@ -151,11 +152,18 @@ func TestValidateZone_Client(t *testing.T) {
 	setup_grpc(t)
 	defer teardown_grpc(t)
 	testCases := []struct {
-		err        error
-		wantedZone *models.Zone
+		err            error
+		problems       []*proto.ValidateZoneProblem
+		wantErr        error
+		wantIdentifier string
+		wantSeverity   models.Severity
 	}{
-		{wantedZone: &models.Zone{ResourceRecords: map[string]*models.ResourceRecord{"one": {Type: models.A}}}},
-		{err: errors.New("testing-err")},
+		{},
+		{err: errors.New("testing-err"), wantErr: errors.New("testing-err")},
+		{problems: []*proto.ValidateZoneProblem{{Message: "zone-err"}}, wantErr: errors.New("zone-err")},
+		{problems: []*proto.ValidateZoneProblem{{Identifier: "www", Message: "record-err"}}, wantErr: errors.New("record-err"), wantIdentifier: "www"},
+		{problems: []*proto.ValidateZoneProblem{{Message: "zone-err"}, {Identifier: "www", Message: "record-err"}}, wantErr: errors.New("zone-err\nrecord-err"), wantIdentifier: "www"},
+		{problems: []*proto.ValidateZoneProblem{{Identifier: "www", Message: "record-warning", Severity: "warning"}}, wantErr: errors.New("record-warning"), wantIdentifier: "www", wantSeverity: models.SeverityWarning},
 	}
 
 	for _, tc := range testCases {
@@ -165,11 +173,16 @@ func TestValidateZone_Client(t *testing.T) {
 		if tc.err != nil {
 			call.Return(nil, tc.err)
 		} else {
-			call.Return(emptyMessage, nil)
+			call.Return(&proto.ValidateZoneResponse{Problems: tc.problems}, nil)
 		}
 
 		err := grpcClient.ValidateZone(name, z)
-		handleError(t, err, tc.err)
+		handleError(t, err, tc.wantErr)
+
+		var recordErr *plugins.RecordError
+		if errors.As(err, &recordErr) != (tc.wantIdentifier != "") || (recordErr != nil && (recordErr.Identifier != tc.wantIdentifier || recordErr.Severity != tc.wantSeverity)) {
+			t.Errorf("incorrect record error: %+v, want identifier: '%s', severity: '%s'", recordErr, tc.wantIdentifier, tc.wantSeverity)
+		}
 	}
 }
 
@@ -21,6 +21,7 @@ package grpc_plugins
 
 import (
 	"context"
+	"errors"
 
 	"github.com/bcurnow/zonemgr/models"
 	"github.com/bcurnow/zonemgr/models/grpc"
@@ -66,11 +67,22 @@ func (s *GRPCServer) Normalize(ctx context.Context, req *proto.NormalizeRequest)
 	return &proto.NormalizeResponse{ResourceRecord: grpc.ResourceRecordToProtoBuf(rr)}, err
 }
 
-func (s *GRPCServer) ValidateZone(ctx context.Context, req *proto.ValidateZoneRequest) (*proto.Empty, error) {
+// The problems are returned in the response rather than as an error so the identifier of each one isn't lost
+func (s *GRPCServer) ValidateZone(ctx context.Context, req *proto.ValidateZoneRequest) (*proto.ValidateZoneResponse, error) {
 	zone := &models.Zone{}
 	grpc.ZoneFromProtoBuf(req.Zone, zone)
-	err := s.Impl.ValidateZone(req.Name, zone)
-	return &proto.Empty{}, err
+
+	resp := &proto.ValidateZoneResponse{}
+	for _, problem := range plugins.Problems(s.Impl.ValidateZone(req.Name, zone)) {
+		validateZoneProblem := &proto.ValidateZoneProblem{Message: problem.Error()}
+		var recordErr *plugins.RecordError
+		if errors.As(problem, &recordErr) {
+			validateZoneProblem.Identifier = recordErr.Identifier
+			validateZoneProblem.Severity = string(recordErr.Severity)
+		}
+		resp.Problems = append(resp.Problems, validateZoneProblem)
+	}
+	return resp, nil
 }
 
 func (s *GRPCServer) Render(ctx context.Context, req *proto.RenderRequest) (*proto.RenderResponse, error) {
@@ -165,10 +165,19 @@ func TestValidateZone_Server(t *testing.T) {
 	setup_grpc(t)
 	defer teardown_grpc(t)
 	testCases := []struct {
-		err error
+		err  error
+		want []*proto.ValidateZoneProblem
 	}{
 		{},
-		{err: errors.New("testing-err")},
+		{err: errors.New("testing-err"), want: []*proto.ValidateZoneProblem{{Message: "testing-err"}}},
+		{
+			err:  errors.Join(errors.New("zone-err"), plugins.NewRecordError("www", errors.New("record-err"))),
+			want: []*proto.ValidateZoneProblem{{Message: "zone-err"}, {Identifier: "www", Message: "record-err"}},
+		},
+		{
+			err:  plugins.NewRecordWarning("www", errors.New("record-warning")),
+			want: []*proto.ValidateZoneProblem{{Identifier: "www", Message: "record-warning", Severity: "warning"}},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -183,14 +192,13 @@ func TestValidateZone_Server(t *testing.T) {
 
 		req := &proto.ValidateZoneRequest{Name: name, Zone: grpc.ZoneToProtoBuf(zone)}
 		resp, err := grpcServer.ValidateZone(context.Background(), req)
-		handleError(t, err, tc.err)
-		if tc.err == nil {
-			if !cmp.Equal(resp, emptyMessage, cmpopts.IgnoreUnexported(proto.Empty{})) {
-				t.Errorf("incorrect response: '%v', want: '%v'", resp, emptyMessage)
-			}
+		handleError(t, err, nil)
+		if !cmp.Equal(resp.Problems, tc.want, cmpopts.IgnoreUnexported(proto.ValidateZoneProblem{})) {
+			t.Errorf("incorrect problems: '%v', want: '%v'", resp.Problems, tc.want)
 		}
 	}
 }
+
 func TestRender_Server(t *testing.T) {
 	setup_grpc(t)
 	defer teardown_grpc(t)
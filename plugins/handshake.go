@@ -25,7 +25,7 @@ import (
 
 // This is the go-plugin handshake information that needs to be used for all plugins
 var HandshakeConfig = goplugin.HandshakeConfig{
-	ProtocolVersion:  1,
+	ProtocolVersion:  2,
 	MagicCookieKey:   "ZONEMGR_PLUGIN",
 	MagicCookieValue: "BEA0CA21-AAC6-4EA8-BB29-4B6B2E39B1AE",
 }
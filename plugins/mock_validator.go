@@ -1,5 +1,10 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: plugins/validator.go
+//
+// Generated by this command:
+//
+//	mockgen -source=plugins/validator.go -package plugins -self_package github.com/bcurnow/zonemgr/plugins
+//
 
 // Package plugins is a generated GoMock package.
 package plugins
@@ -15,6 +20,7 @@ import (
 type MockValidator struct {
 	ctrl     *gomock.Controller
 	recorder *MockValidatorMockRecorder
+	isgomock struct{}
 }
 
 // MockValidatorMockRecorder is the mock recorder for MockValidator.
@@ -37,7 +43,7 @@ func (m *MockValidator) EXPECT() *MockValidatorMockRecorder {
 // CommonValidations mocks base method.
 func (m *MockValidator) CommonValidations(identifier string, rr *models.ResourceRecord, supportedTypes ...Type) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{identifier, rr}
+	varargs := []any{identifier, rr}
 	for _, a := range supportedTypes {
 		varargs = append(varargs, a)
 	}
@@ -47,9 +53,9 @@ func (m *MockValidator) CommonValidations(identifier string, rr *models.Resource
 }
 
 // CommonValidations indicates an expected call of CommonValidations.
-func (mr *MockValidatorMockRecorder) CommonValidations(identifier, rr interface{}, supportedTypes ...interface{}) *gomock.Call {
+func (mr *MockValidatorMockRecorder) CommonValidations(identifier, rr any, supportedTypes ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{identifier, rr}, supportedTypes...)
+	varargs := append([]any{identifier, rr}, supportedTypes...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommonValidations", reflect.TypeOf((*MockValidator)(nil).CommonValidations), varargs...)
 }
 
@@ -62,7 +68,7 @@ func (m *MockValidator) EnsureFullyQualified(identifier, name string, rrType mod
 }
 
 // EnsureFullyQualified indicates an expected call of EnsureFullyQualified.
-func (mr *MockValidatorMockRecorder) EnsureFullyQualified(identifier, name, rrType interface{}) *gomock.Call {
+func (mr *MockValidatorMockRecorder) EnsureFullyQualified(identifier, name, rrType any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureFullyQualified", reflect.TypeOf((*MockValidator)(nil).EnsureFullyQualified), identifier, name, rrType)
 }
@@ -76,7 +82,7 @@ func (m *MockValidator) EnsureIP(identifier, s string, rrType models.ResourceRec
 }
 
 // EnsureIP indicates an expected call of EnsureIP.
-func (mr *MockValidatorMockRecorder) EnsureIP(identifier, s, rrType interface{}) *gomock.Call {
+func (mr *MockValidatorMockRecorder) EnsureIP(identifier, s, rrType any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureIP", reflect.TypeOf((*MockValidator)(nil).EnsureIP), identifier, s, rrType)
 }
@@ -90,7 +96,7 @@ func (m *MockValidator) EnsureNotIP(identifier, s string, rrType models.Resource
 }
 
 // EnsureNotIP indicates an expected call of EnsureNotIP.
-func (mr *MockValidatorMockRecorder) EnsureNotIP(identifier, s, rrType interface{}) *gomock.Call {
+func (mr *MockValidatorMockRecorder) EnsureNotIP(identifier, s, rrType any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureNotIP", reflect.TypeOf((*MockValidator)(nil).EnsureNotIP), identifier, s, rrType)
 }
@@ -104,7 +110,7 @@ func (m *MockValidator) EnsurePositive(identifier, s, fieldName string, rrType m
 }
 
 // EnsurePositive indicates an expected call of EnsurePositive.
-func (mr *MockValidatorMockRecorder) EnsurePositive(identifier, s, fieldName, rrType interface{}) *gomock.Call {
+func (mr *MockValidatorMockRecorder) EnsurePositive(identifier, s, fieldName, rrType any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsurePositive", reflect.TypeOf((*MockValidator)(nil).EnsurePositive), identifier, s, fieldName, rrType)
 }
@@ -112,7 +118,7 @@ func (mr *MockValidatorMockRecorder) EnsurePositive(identifier, s, fieldName, rr
 // EnsureSupportedPluginType mocks base method.
 func (m *MockValidator) EnsureSupportedPluginType(identifier string, rrType models.ResourceRecordType, supportedTypes ...Type) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{identifier, rrType}
+	varargs := []any{identifier, rrType}
 	for _, a := range supportedTypes {
 		varargs = append(varargs, a)
 	}
@@ -122,9 +128,9 @@ func (m *MockValidator) EnsureSupportedPluginType(identifier string, rrType mode
 }
 
 // EnsureSupportedPluginType indicates an expected call of EnsureSupportedPluginType.
-func (mr *MockValidatorMockRecorder) EnsureSupportedPluginType(identifier, rrType interface{}, supportedTypes ...interface{}) *gomock.Call {
+func (mr *MockValidatorMockRecorder) EnsureSupportedPluginType(identifier, rrType any, supportedTypes ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{identifier, rrType}, supportedTypes...)
+	varargs := append([]any{identifier, rrType}, supportedTypes...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureSupportedPluginType", reflect.TypeOf((*MockValidator)(nil).EnsureSupportedPluginType), varargs...)
 }
 
@@ -137,7 +143,7 @@ func (m *MockValidator) EnsureTrailingDot(name string) string {
 }
 
 // EnsureTrailingDot indicates an expected call of EnsureTrailingDot.
-func (mr *MockValidatorMockRecorder) EnsureTrailingDot(name interface{}) *gomock.Call {
+func (mr *MockValidatorMockRecorder) EnsureTrailingDot(name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureTrailingDot", reflect.TypeOf((*MockValidator)(nil).EnsureTrailingDot), name)
 }
@@ -151,7 +157,7 @@ func (m *MockValidator) EnsureValidNameOrWildcard(identifier, name string, rrTyp
 }
 
 // EnsureValidNameOrWildcard indicates an expected call of EnsureValidNameOrWildcard.
-func (mr *MockValidatorMockRecorder) EnsureValidNameOrWildcard(identifier, name, rrType interface{}) *gomock.Call {
+func (mr *MockValidatorMockRecorder) EnsureValidNameOrWildcard(identifier, name, rrType any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureValidNameOrWildcard", reflect.TypeOf((*MockValidator)(nil).EnsureValidNameOrWildcard), identifier, name, rrType)
 }
@@ -165,11 +171,25 @@ func (m *MockValidator) EnsureValidRFC1035Name(identifier, name string, rrType m
 }
 
 // EnsureValidRFC1035Name indicates an expected call of EnsureValidRFC1035Name.
-func (mr *MockValidatorMockRecorder) EnsureValidRFC1035Name(identifier, name, rrType interface{}) *gomock.Call {
+func (mr *MockValidatorMockRecorder) EnsureValidRFC1035Name(identifier, name, rrType any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureValidRFC1035Name", reflect.TypeOf((*MockValidator)(nil).EnsureValidRFC1035Name), identifier, name, rrType)
 }
 
+// EnsureValidUnderscoreName mocks base method.
+func (m *MockValidator) EnsureValidUnderscoreName(identifier, name string, rrType models.ResourceRecordType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureValidUnderscoreName", identifier, name, rrType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureValidUnderscoreName indicates an expected call of EnsureValidUnderscoreName.
+func (mr *MockValidatorMockRecorder) EnsureValidUnderscoreName(identifier, name, rrType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureValidUnderscoreName", reflect.TypeOf((*MockValidator)(nil).EnsureValidUnderscoreName), identifier, name, rrType)
+}
+
 // FormatEmail mocks base method.
 func (m *MockValidator) FormatEmail(identifier, email string, rrType models.ResourceRecordType) (string, error) {
 	m.ctrl.T.Helper()
@@ -180,7 +200,7 @@ func (m *MockValidator) FormatEmail(identifier, email string, rrType models.Reso
 }
 
 // FormatEmail indicates an expected call of FormatEmail.
-func (mr *MockValidatorMockRecorder) FormatEmail(identifier, email, rrType interface{}) *gomock.Call {
+func (mr *MockValidatorMockRecorder) FormatEmail(identifier, email, rrType any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormatEmail", reflect.TypeOf((*MockValidator)(nil).FormatEmail), identifier, email, rrType)
 }
@@ -136,14 +136,14 @@ func (mr *MockZonemgrPluginClientMockRecorder) Render(ctx, in interface{}, opts
 }
 
 // ValidateZone mocks base method.
-func (m *MockZonemgrPluginClient) ValidateZone(ctx context.Context, in *ValidateZoneRequest, opts ...grpc.CallOption) (*Empty, error) {
+func (m *MockZonemgrPluginClient) ValidateZone(ctx context.Context, in *ValidateZoneRequest, opts ...grpc.CallOption) (*ValidateZoneResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "ValidateZone", varargs...)
-	ret0, _ := ret[0].(*Empty)
+	ret0, _ := ret[0].(*ValidateZoneResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -254,10 +254,10 @@ func (mr *MockZonemgrPluginServerMockRecorder) Render(arg0, arg1 interface{}) *g
 }
 
 // ValidateZone mocks base method.
-func (m *MockZonemgrPluginServer) ValidateZone(arg0 context.Context, arg1 *ValidateZoneRequest) (*Empty, error) {
+func (m *MockZonemgrPluginServer) ValidateZone(arg0 context.Context, arg1 *ValidateZoneRequest) (*ValidateZoneResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ValidateZone", arg0, arg1)
-	ret0, _ := ret[0].(*Empty)
+	ret0, _ := ret[0].(*ValidateZoneResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -46,6 +46,12 @@ type Config struct {
 	SerialChangeIndexDirectory string                 `protobuf:"bytes,3,opt,name=serial_change_index_directory,json=serialChangeIndexDirectory,proto3" json:"serial_change_index_directory,omitempty"`
 	IsCatalog                  bool                   `protobuf:"varint,4,opt,name=is_catalog,json=isCatalog,proto3" json:"is_catalog,omitempty"`
 	CatalogIncludeReverseZones bool                   `protobuf:"varint,5,opt,name=catalog_include_reverse_zones,json=catalogIncludeReverseZones,proto3" json:"catalog_include_reverse_zones,omitempty"`
+	RecordOrder                string                 `protobuf:"bytes,6,opt,name=record_order,json=recordOrder,proto3" json:"record_order,omitempty"`
+	KeepTimeUnits              bool                   `protobuf:"varint,7,opt,name=keep_time_units,json=keepTimeUnits,proto3" json:"keep_time_units,omitempty"`
+	PtrPolicy                  string                 `protobuf:"bytes,8,opt,name=ptr_policy,json=ptrPolicy,proto3" json:"ptr_policy,omitempty"`
+	ReverseZones               []string               `protobuf:"bytes,9,rep,name=reverse_zones,json=reverseZones,proto3" json:"reverse_zones,omitempty"`
+	CnamePolicy                string                 `protobuf:"bytes,10,opt,name=cname_policy,json=cnamePolicy,proto3" json:"cname_policy,omitempty"`
+	DelegationPolicy           string                 `protobuf:"bytes,11,opt,name=delegation_policy,json=delegationPolicy,proto3" json:"delegation_policy,omitempty"`
 	unknownFields              protoimpl.UnknownFields
 	sizeCache                  protoimpl.SizeCache
 }
@@ -115,6 +121,48 @@ func (x *Config) GetCatalogIncludeReverseZones() bool {
 	return false
 }
 
+func (x *Config) GetRecordOrder() string {
+	if x != nil {
+		return x.RecordOrder
+	}
+	return ""
+}
+
+func (x *Config) GetKeepTimeUnits() bool {
+	if x != nil {
+		return x.KeepTimeUnits
+	}
+	return false
+}
+
+func (x *Config) GetPtrPolicy() string {
+	if x != nil {
+		return x.PtrPolicy
+	}
+	return ""
+}
+
+func (x *Config) GetReverseZones() []string {
+	if x != nil {
+		return x.ReverseZones
+	}
+	return nil
+}
+
+func (x *Config) GetCnamePolicy() string {
+	if x != nil {
+		return x.CnamePolicy
+	}
+	return ""
+}
+
+func (x *Config) GetDelegationPolicy() string {
+	if x != nil {
+		return x.DelegationPolicy
+	}
+	return ""
+}
+
 type ResourceRecordValue struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
@@ -167,22 +215,285 @@ func (x *ResourceRecordValue) GetComment() string {
 	return ""
 }
 
-type ResourceRecord struct {
+type SOAField struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Class         string                 `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
-	Ttl           *int32                 `protobuf:"varint,4,opt,name=ttl,proto3,oneof" json:"ttl,omitempty"`
-	Values        []*ResourceRecordValue `protobuf:"bytes,5,rep,name=values,proto3" json:"values,omitempty"`
-	Value         string                 `protobuf:"bytes,6,opt,name=value,proto3" json:"value,omitempty"`
-	Comment       string                 `protobuf:"bytes,7,opt,name=comment,proto3" json:"comment,omitempty"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Comment       string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SOAField) Reset() {
+	*x = SOAField{}
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SOAField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SOAField) ProtoMessage() {}
+
+func (x *SOAField) ProtoReflect() protoreflect.Message {
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SOAField.ProtoReflect.Descriptor instead.
+func (*SOAField) Descriptor() ([]byte, []int) {
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SOAField) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SOAField) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type SOAFields struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mname         *SOAField              `protobuf:"bytes,1,opt,name=mname,proto3,oneof" json:"mname,omitempty"`
+	Rname         *SOAField              `protobuf:"bytes,2,opt,name=rname,proto3,oneof" json:"rname,omitempty"`
+	Serial        *SOAField              `protobuf:"bytes,3,opt,name=serial,proto3,oneof" json:"serial,omitempty"`
+	Refresh       *SOAField              `protobuf:"bytes,4,opt,name=refresh,proto3,oneof" json:"refresh,omitempty"`
+	Retry         *SOAField              `protobuf:"bytes,5,opt,name=retry,proto3,oneof" json:"retry,omitempty"`
+	Expire        *SOAField              `protobuf:"bytes,6,opt,name=expire,proto3,oneof" json:"expire,omitempty"`
+	Minimum       *SOAField              `protobuf:"bytes,7,opt,name=minimum,proto3,oneof" json:"minimum,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SOAFields) Reset() {
+	*x = SOAFields{}
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SOAFields) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SOAFields) ProtoMessage() {}
+
+func (x *SOAFields) ProtoReflect() protoreflect.Message {
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SOAFields.ProtoReflect.Descriptor instead.
+func (*SOAFields) Descriptor() ([]byte, []int) {
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SOAFields) GetMname() *SOAField {
+	if x != nil {
+		return x.Mname
+	}
+	return nil
+}
+
+func (x *SOAFields) GetRname() *SOAField {
+	if x != nil {
+		return x.Rname
+	}
+	return nil
+}
+
+func (x *SOAFields) GetSerial() *SOAField {
+	if x != nil {
+		return x.Serial
+	}
+	return nil
+}
+
+func (x *SOAFields) GetRefresh() *SOAField {
+	if x != nil {
+		return x.Refresh
+	}
+	return nil
+}
+
+func (x *SOAFields) GetRetry() *SOAField {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
+func (x *SOAFields) GetExpire() *SOAField {
+	if x != nil {
+		return x.Expire
+	}
+	return nil
+}
+
+func (x *SOAFields) GetMinimum() *SOAField {
+	if x != nil {
+		return x.Minimum
+	}
+	return nil
+}
+
+type MXFields struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Preference    string                 `protobuf:"bytes,1,opt,name=preference,proto3" json:"preference,omitempty"`
+	Exchange      string                 `protobuf:"bytes,2,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MXFields) Reset() {
+	*x = MXFields{}
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MXFields) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MXFields) ProtoMessage() {}
+
+func (x *MXFields) ProtoReflect() protoreflect.Message {
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MXFields.ProtoReflect.Descriptor instead.
+func (*MXFields) Descriptor() ([]byte, []int) {
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MXFields) GetPreference() string {
+	if x != nil {
+		return x.Preference
+	}
+	return ""
+}
+
+func (x *MXFields) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+type Generate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Range         string                 `protobuf:"bytes,1,opt,name=range,proto3" json:"range,omitempty"`
+	Step          int32                  `protobuf:"varint,2,opt,name=step,proto3" json:"step,omitempty"`
+	Directive     bool                   `protobuf:"varint,3,opt,name=directive,proto3" json:"directive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Generate) Reset() {
+	*x = Generate{}
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Generate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Generate) ProtoMessage() {}
+
+func (x *Generate) ProtoReflect() protoreflect.Message {
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Generate.ProtoReflect.Descriptor instead.
+func (*Generate) Descriptor() ([]byte, []int) {
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Generate) GetRange() string {
+	if x != nil {
+		return x.Range
+	}
+	return ""
+}
+
+func (x *Generate) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *Generate) GetDirective() bool {
+	if x != nil {
+		return x.Directive
+	}
+	return false
+}
+
+type ResourceRecord struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type     string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Class    string                 `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
+	Ttl      *int32                 `protobuf:"varint,4,opt,name=ttl,proto3,oneof" json:"ttl,omitempty"`
+	Values   []*ResourceRecordValue `protobuf:"bytes,5,rep,name=values,proto3" json:"values,omitempty"`
+	Value    string                 `protobuf:"bytes,6,opt,name=value,proto3" json:"value,omitempty"`
+	Comment  string                 `protobuf:"bytes,7,opt,name=comment,proto3" json:"comment,omitempty"`
+	Soa      *SOAFields             `protobuf:"bytes,8,opt,name=soa,proto3,oneof" json:"soa,omitempty"`
+	Mx       *MXFields              `protobuf:"bytes,9,opt,name=mx,proto3,oneof" json:"mx,omitempty"`
+	Reverse  *bool                  `protobuf:"varint,10,opt,name=reverse,proto3,oneof" json:"reverse,omitempty"`
+	PtrName  string                 `protobuf:"bytes,11,opt,name=ptr_name,json=ptrName,proto3" json:"ptr_name,omitempty"`
+	Generate *Generate              `protobuf:"bytes,12,opt,name=generate,proto3,oneof" json:"generate,omitempty"`
+	// The TTL as it was written when it used units (e.g. 1h)
+	TtlText       string `protobuf:"bytes,13,opt,name=ttl_text,json=ttlText,proto3" json:"ttl_text,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ResourceRecord) Reset() {
 	*x = ResourceRecord{}
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[2]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -194,7 +505,7 @@ func (x *ResourceRecord) String() string {
 func (*ResourceRecord) ProtoMessage() {}
 
 func (x *ResourceRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[2]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -207,7 +518,7 @@ func (x *ResourceRecord) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceRecord.ProtoReflect.Descriptor instead.
 func (*ResourceRecord) Descriptor() ([]byte, []int) {
-	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{2}
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ResourceRecord) GetName() string {
@@ -259,18 +570,62 @@ func (x *ResourceRecord) GetComment() string {
 	return ""
 }
 
+func (x *ResourceRecord) GetSoa() *SOAFields {
+	if x != nil {
+		return x.Soa
+	}
+	return nil
+}
+
+func (x *ResourceRecord) GetMx() *MXFields {
+	if x != nil {
+		return x.Mx
+	}
+	return nil
+}
+
+func (x *ResourceRecord) GetReverse() bool {
+	if x != nil && x.Reverse != nil {
+		return *x.Reverse
+	}
+	return false
+}
+
+func (x *ResourceRecord) GetPtrName() string {
+	if x != nil {
+		return x.PtrName
+	}
+	return ""
+}
+
+func (x *ResourceRecord) GetGenerate() *Generate {
+	if x != nil {
+		return x.Generate
+	}
+	return nil
+}
+
+func (x *ResourceRecord) GetTtlText() string {
+	if x != nil {
+		return x.TtlText
+	}
+	return ""
+}
+
 type TTL struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Todo fix this!
-	Ttl           *int32 `protobuf:"varint,1,opt,name=ttl,proto3,oneof" json:"ttl,omitempty"`
-	Comment       string `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	Ttl     *int32 `protobuf:"varint,1,opt,name=ttl,proto3,oneof" json:"ttl,omitempty"`
+	Comment string `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	// The TTL as it was written when it used units (e.g. 1h)
+	Text          string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TTL) Reset() {
 	*x = TTL{}
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[3]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -282,7 +637,7 @@ func (x *TTL) String() string {
 func (*TTL) ProtoMessage() {}
 
 func (x *TTL) ProtoReflect() protoreflect.Message {
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[3]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -295,7 +650,7 @@ func (x *TTL) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TTL.ProtoReflect.Descriptor instead.
 func (*TTL) Descriptor() ([]byte, []int) {
-	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{3}
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *TTL) GetTtl() int32 {
@@ -312,6 +667,13 @@ func (x *TTL) GetComment() string {
 	return ""
 }
 
+func (x *TTL) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
 type Zone struct {
 	state           protoimpl.MessageState     `protogen:"open.v1"`
 	Config          *Config                    `protobuf:"bytes,1,opt,name=config,proto3,oneof" json:"config,omitempty"`
@@ -323,7 +685,7 @@ type Zone struct {
 
 func (x *Zone) Reset() {
 	*x = Zone{}
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[4]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -335,7 +697,7 @@ func (x *Zone) String() string {
 func (*Zone) ProtoMessage() {}
 
 func (x *Zone) ProtoReflect() protoreflect.Message {
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[4]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,7 +710,7 @@ func (x *Zone) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Zone.ProtoReflect.Descriptor instead.
 func (*Zone) Descriptor() ([]byte, []int) {
-	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{4}
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Zone) GetConfig() *Config {
@@ -380,7 +742,7 @@ type Empty struct {
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[5]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -392,7 +754,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[5]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -405,7 +767,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{5}
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{9}
 }
 
 type PluginVersionResponse struct {
@@ -417,7 +779,7 @@ type PluginVersionResponse struct {
 
 func (x *PluginVersionResponse) Reset() {
 	*x = PluginVersionResponse{}
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[6]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -429,7 +791,7 @@ func (x *PluginVersionResponse) String() string {
 func (*PluginVersionResponse) ProtoMessage() {}
 
 func (x *PluginVersionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[6]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -442,7 +804,7 @@ func (x *PluginVersionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PluginVersionResponse.ProtoReflect.Descriptor instead.
 func (*PluginVersionResponse) Descriptor() ([]byte, []int) {
-	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{6}
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *PluginVersionResponse) GetVersion() string {
@@ -461,7 +823,7 @@ type PluginTypesResponse struct {
 
 func (x *PluginTypesResponse) Reset() {
 	*x = PluginTypesResponse{}
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[7]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -473,7 +835,7 @@ func (x *PluginTypesResponse) String() string {
 func (*PluginTypesResponse) ProtoMessage() {}
 
 func (x *PluginTypesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[7]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -486,7 +848,7 @@ func (x *PluginTypesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PluginTypesResponse.ProtoReflect.Descriptor instead.
 func (*PluginTypesResponse) Descriptor() ([]byte, []int) {
-	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{7}
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *PluginTypesResponse) GetSupportedTypes() []string {
@@ -505,7 +867,7 @@ type ConfigureRequest struct {
 
 func (x *ConfigureRequest) Reset() {
 	*x = ConfigureRequest{}
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[8]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -517,7 +879,7 @@ func (x *ConfigureRequest) String() string {
 func (*ConfigureRequest) ProtoMessage() {}
 
 func (x *ConfigureRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[8]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -530,7 +892,7 @@ func (x *ConfigureRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigureRequest.ProtoReflect.Descriptor instead.
 func (*ConfigureRequest) Descriptor() ([]byte, []int) {
-	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{8}
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ConfigureRequest) GetConfig() *Config {
@@ -550,7 +912,7 @@ type NormalizeRequest struct {
 
 func (x *NormalizeRequest) Reset() {
 	*x = NormalizeRequest{}
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[9]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -562,7 +924,7 @@ func (x *NormalizeRequest) String() string {
 func (*NormalizeRequest) ProtoMessage() {}
 
 func (x *NormalizeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[9]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -575,7 +937,7 @@ func (x *NormalizeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NormalizeRequest.ProtoReflect.Descriptor instead.
 func (*NormalizeRequest) Descriptor() ([]byte, []int) {
-	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{9}
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *NormalizeRequest) GetIdentifier() string {
@@ -601,7 +963,7 @@ type NormalizeResponse struct {
 
 func (x *NormalizeResponse) Reset() {
 	*x = NormalizeResponse{}
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[10]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -613,7 +975,7 @@ func (x *NormalizeResponse) String() string {
 func (*NormalizeResponse) ProtoMessage() {}
 
 func (x *NormalizeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[10]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -626,7 +988,7 @@ func (x *NormalizeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NormalizeResponse.ProtoReflect.Descriptor instead.
 func (*NormalizeResponse) Descriptor() ([]byte, []int) {
-	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{10}
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *NormalizeResponse) GetResourceRecord() *ResourceRecord {
@@ -646,7 +1008,7 @@ type ValidateZoneRequest struct {
 
 func (x *ValidateZoneRequest) Reset() {
 	*x = ValidateZoneRequest{}
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[11]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -658,7 +1020,7 @@ func (x *ValidateZoneRequest) String() string {
 func (*ValidateZoneRequest) ProtoMessage() {}
 
 func (x *ValidateZoneRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[11]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -671,7 +1033,7 @@ func (x *ValidateZoneRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidateZoneRequest.ProtoReflect.Descriptor instead.
 func (*ValidateZoneRequest) Descriptor() ([]byte, []int) {
-	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{11}
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ValidateZoneRequest) GetName() string {
@@ -688,6 +1050,110 @@ func (x *ValidateZoneRequest) GetZone() *Zone {
 	return nil
 }
 
+type ValidateZoneProblem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    string                 `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Severity      string                 `protobuf:"bytes,3,opt,name=severity,proto3" json:"severity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateZoneProblem) Reset() {
+	*x = ValidateZoneProblem{}
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateZoneProblem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateZoneProblem) ProtoMessage() {}
+
+func (x *ValidateZoneProblem) ProtoReflect() protoreflect.Message {
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateZoneProblem.ProtoReflect.Descriptor instead.
+func (*ValidateZoneProblem) Descriptor() ([]byte, []int) {
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ValidateZoneProblem) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
+func (x *ValidateZoneProblem) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ValidateZoneProblem) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+type ValidateZoneResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Problems      []*ValidateZoneProblem `protobuf:"bytes,1,rep,name=problems,proto3" json:"problems,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateZoneResponse) Reset() {
+	*x = ValidateZoneResponse{}
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateZoneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateZoneResponse) ProtoMessage() {}
+
+func (x *ValidateZoneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateZoneResponse.ProtoReflect.Descriptor instead.
+func (*ValidateZoneResponse) Descriptor() ([]byte, []int) {
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ValidateZoneResponse) GetProblems() []*ValidateZoneProblem {
+	if x != nil {
+		return x.Problems
+	}
+	return nil
+}
+
 type RenderRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Identifier     string                 `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
@@ -698,7 +1164,7 @@ type RenderRequest struct {
 
 func (x *RenderRequest) Reset() {
 	*x = RenderRequest{}
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[12]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -710,7 +1176,7 @@ func (x *RenderRequest) String() string {
 func (*RenderRequest) ProtoMessage() {}
 
 func (x *RenderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[12]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -723,7 +1189,7 @@ func (x *RenderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RenderRequest.ProtoReflect.Descriptor instead.
 func (*RenderRequest) Descriptor() ([]byte, []int) {
-	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{12}
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *RenderRequest) GetIdentifier() string {
@@ -749,7 +1215,7 @@ type RenderResponse struct {
 
 func (x *RenderResponse) Reset() {
 	*x = RenderResponse{}
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[13]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -761,7 +1227,7 @@ func (x *RenderResponse) String() string {
 func (*RenderResponse) ProtoMessage() {}
 
 func (x *RenderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[13]
+	mi := &file_plugins_proto_zonemgrplugin_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -774,7 +1240,7 @@ func (x *RenderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RenderResponse.ProtoReflect.Descriptor instead.
 func (*RenderResponse) Descriptor() ([]byte, []int) {
-	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{13}
+	return file_plugins_proto_zonemgrplugin_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *RenderResponse) GetContent() string {
@@ -788,17 +1254,54 @@ var File_plugins_proto_zonemgrplugin_proto protoreflect.FileDescriptor
 
 const file_plugins_proto_zonemgrplugin_proto_rawDesc = "" +
 	"\n" +
-	"!plugins/proto/zonemgrplugin.proto\"\x99\x02\n" +
+	"!plugins/proto/zonemgrplugin.proto\"\xf8\x03\n" +
 	"\x06Config\x12'\n" +
 	"\x0fgenerate_serial\x18\x01 \x01(\bR\x0egenerateSerial\x12A\n" +
 	"\x1dgenerate_reverse_lookup_zones\x18\x02 \x01(\bR\x1agenerateReverseLookupZones\x12A\n" +
 	"\x1dserial_change_index_directory\x18\x03 \x01(\tR\x1aserialChangeIndexDirectory\x12\x1d\n" +
 	"\n" +
 	"is_catalog\x18\x04 \x01(\bR\tisCatalog\x12A\n" +
-	"\x1dcatalog_include_reverse_zones\x18\x05 \x01(\bR\x1acatalogIncludeReverseZones\"E\n" +
+	"\x1dcatalog_include_reverse_zones\x18\x05 \x01(\bR\x1acatalogIncludeReverseZones\x12!\n" +
+	"\frecord_order\x18\x06 \x01(\tR\vrecordOrder\x12&\n" +
+	"\x0fkeep_time_units\x18\a \x01(\bR\rkeepTimeUnits\x12\x1d\n" +
+	"\n" +
+	"ptr_policy\x18\b \x01(\tR\tptrPolicy\x12#\n" +
+	"\rreverse_zones\x18\t \x03(\tR\freverseZones\x12!\n" +
+	"\fcname_policy\x18\n" +
+	" \x01(\tR\vcnamePolicy\x12+\n" +
+	"\x11delegation_policy\x18\v \x01(\tR\x10delegationPolicy\"E\n" +
 	"\x13ResourceRecordValue\x12\x14\n" +
 	"\x05value\x18\x01 \x01(\tR\x05value\x12\x18\n" +
-	"\acomment\x18\x02 \x01(\tR\acomment\"\xcb\x01\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\":\n" +
+	"\bSOAField\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\"\xed\x02\n" +
+	"\tSOAFields\x12$\n" +
+	"\x05mname\x18\x01 \x01(\v2\t.SOAFieldH\x00R\x05mname\x88\x01\x01\x12$\n" +
+	"\x05rname\x18\x02 \x01(\v2\t.SOAFieldH\x01R\x05rname\x88\x01\x01\x12&\n" +
+	"\x06serial\x18\x03 \x01(\v2\t.SOAFieldH\x02R\x06serial\x88\x01\x01\x12(\n" +
+	"\arefresh\x18\x04 \x01(\v2\t.SOAFieldH\x03R\arefresh\x88\x01\x01\x12$\n" +
+	"\x05retry\x18\x05 \x01(\v2\t.SOAFieldH\x04R\x05retry\x88\x01\x01\x12&\n" +
+	"\x06expire\x18\x06 \x01(\v2\t.SOAFieldH\x05R\x06expire\x88\x01\x01\x12(\n" +
+	"\aminimum\x18\a \x01(\v2\t.SOAFieldH\x06R\aminimum\x88\x01\x01B\b\n" +
+	"\x06_mnameB\b\n" +
+	"\x06_rnameB\t\n" +
+	"\a_serialB\n" +
+	"\n" +
+	"\b_refreshB\b\n" +
+	"\x06_retryB\t\n" +
+	"\a_expireB\n" +
+	"\n" +
+	"\b_minimum\"F\n" +
+	"\bMXFields\x12\x1e\n" +
+	"\n" +
+	"preference\x18\x01 \x01(\tR\n" +
+	"preference\x12\x1a\n" +
+	"\bexchange\x18\x02 \x01(\tR\bexchange\"R\n" +
+	"\bGenerate\x12\x14\n" +
+	"\x05range\x18\x01 \x01(\tR\x05range\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\x05R\x04step\x12\x1c\n" +
+	"\tdirective\x18\x03 \x01(\bR\tdirective\"\xb7\x03\n" +
 	"\x0eResourceRecord\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
@@ -806,11 +1309,25 @@ const file_plugins_proto_zonemgrplugin_proto_rawDesc = "" +
 	"\x03ttl\x18\x04 \x01(\x05H\x00R\x03ttl\x88\x01\x01\x12,\n" +
 	"\x06values\x18\x05 \x03(\v2\x14.ResourceRecordValueR\x06values\x12\x14\n" +
 	"\x05value\x18\x06 \x01(\tR\x05value\x12\x18\n" +
-	"\acomment\x18\a \x01(\tR\acommentB\x06\n" +
-	"\x04_ttl\">\n" +
+	"\acomment\x18\a \x01(\tR\acomment\x12!\n" +
+	"\x03soa\x18\b \x01(\v2\n" +
+	".SOAFieldsH\x01R\x03soa\x88\x01\x01\x12\x1e\n" +
+	"\x02mx\x18\t \x01(\v2\t.MXFieldsH\x02R\x02mx\x88\x01\x01\x12\x1d\n" +
+	"\areverse\x18\n" +
+	" \x01(\bH\x03R\areverse\x88\x01\x01\x12\x19\n" +
+	"\bptr_name\x18\v \x01(\tR\aptrName\x12*\n" +
+	"\bgenerate\x18\f \x01(\v2\t.GenerateH\x04R\bgenerate\x88\x01\x01\x12\x19\n" +
+	"\bttl_text\x18\r \x01(\tR\attlTextB\x06\n" +
+	"\x04_ttlB\x06\n" +
+	"\x04_soaB\x05\n" +
+	"\x03_mxB\n" +
+	"\n" +
+	"\b_reverseB\v\n" +
+	"\t_generate\"R\n" +
 	"\x03TTL\x12\x15\n" +
 	"\x03ttl\x18\x01 \x01(\x05H\x00R\x03ttl\x88\x01\x01\x12\x18\n" +
-	"\acomment\x18\x02 \x01(\tR\acommentB\x06\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04textB\x06\n" +
 	"\x04_ttl\"\xf8\x01\n" +
 	"\x04Zone\x12$\n" +
 	"\x06config\x18\x01 \x01(\v2\a.ConfigH\x00R\x06config\x88\x01\x01\x12E\n" +
@@ -837,20 +1354,28 @@ const file_plugins_proto_zonemgrplugin_proto_rawDesc = "" +
 	"\x0fresource_record\x18\x01 \x01(\v2\x0f.ResourceRecordR\x0eresourceRecord\"D\n" +
 	"\x13ValidateZoneRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
-	"\x04zone\x18\x02 \x01(\v2\x05.ZoneR\x04zone\"i\n" +
+	"\x04zone\x18\x02 \x01(\v2\x05.ZoneR\x04zone\"k\n" +
+	"\x13ValidateZoneProblem\x12\x1e\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\tR\n" +
+	"identifier\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
+	"\bseverity\x18\x03 \x01(\tR\bseverity\"H\n" +
+	"\x14ValidateZoneResponse\x120\n" +
+	"\bproblems\x18\x01 \x03(\v2\x14.ValidateZoneProblemR\bproblems\"i\n" +
 	"\rRenderRequest\x12\x1e\n" +
 	"\n" +
 	"identifier\x18\x01 \x01(\tR\n" +
 	"identifier\x128\n" +
 	"\x0fresource_record\x18\x02 \x01(\v2\x0f.ResourceRecordR\x0eresourceRecord\"*\n" +
 	"\x0eRenderResponse\x12\x18\n" +
-	"\acontent\x18\x01 \x01(\tR\acontent2\xa2\x02\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent2\xb1\x02\n" +
 	"\rZonemgrPlugin\x12/\n" +
 	"\rPluginVersion\x12\x06.Empty\x1a\x16.PluginVersionResponse\x12+\n" +
 	"\vPluginTypes\x12\x06.Empty\x1a\x14.PluginTypesResponse\x12&\n" +
 	"\tConfigure\x12\x11.ConfigureRequest\x1a\x06.Empty\x122\n" +
-	"\tNormalize\x12\x11.NormalizeRequest\x1a\x12.NormalizeResponse\x12,\n" +
-	"\fValidateZone\x12\x14.ValidateZoneRequest\x1a\x06.Empty\x12)\n" +
+	"\tNormalize\x12\x11.NormalizeRequest\x1a\x12.NormalizeResponse\x12;\n" +
+	"\fValidateZone\x12\x14.ValidateZoneRequest\x1a\x15.ValidateZoneResponse\x12)\n" +
 	"\x06Render\x12\x0e.RenderRequest\x1a\x0f.RenderResponseB\x11Z\x0f./plugins/protob\x06proto3"
 
 var (
@@ -865,52 +1390,69 @@ func file_plugins_proto_zonemgrplugin_proto_rawDescGZIP() []byte {
 	return file_plugins_proto_zonemgrplugin_proto_rawDescData
 }
 
-var file_plugins_proto_zonemgrplugin_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_plugins_proto_zonemgrplugin_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_plugins_proto_zonemgrplugin_proto_goTypes = []any{
 	(*Config)(nil),                // 0: Config
 	(*ResourceRecordValue)(nil),   // 1: ResourceRecordValue
-	(*ResourceRecord)(nil),        // 2: ResourceRecord
-	(*TTL)(nil),                   // 3: TTL
-	(*Zone)(nil),                  // 4: Zone
-	(*Empty)(nil),                 // 5: Empty
-	(*PluginVersionResponse)(nil), // 6: PluginVersionResponse
-	(*PluginTypesResponse)(nil),   // 7: PluginTypesResponse
-	(*ConfigureRequest)(nil),      // 8: ConfigureRequest
-	(*NormalizeRequest)(nil),      // 9: NormalizeRequest
-	(*NormalizeResponse)(nil),     // 10: NormalizeResponse
-	(*ValidateZoneRequest)(nil),   // 11: ValidateZoneRequest
-	(*RenderRequest)(nil),         // 12: RenderRequest
-	(*RenderResponse)(nil),        // 13: RenderResponse
-	nil,                           // 14: Zone.ResourceRecordsEntry
+	(*SOAField)(nil),              // 2: SOAField
+	(*SOAFields)(nil),             // 3: SOAFields
+	(*MXFields)(nil),              // 4: MXFields
+	(*Generate)(nil),              // 5: Generate
+	(*ResourceRecord)(nil),        // 6: ResourceRecord
+	(*TTL)(nil),                   // 7: TTL
+	(*Zone)(nil),                  // 8: Zone
+	(*Empty)(nil),                 // 9: Empty
+	(*PluginVersionResponse)(nil), // 10: PluginVersionResponse
+	(*PluginTypesResponse)(nil),   // 11: PluginTypesResponse
+	(*ConfigureRequest)(nil),      // 12: ConfigureRequest
+	(*NormalizeRequest)(nil),      // 13: NormalizeRequest
+	(*NormalizeResponse)(nil),     // 14: NormalizeResponse
+	(*ValidateZoneRequest)(nil),   // 15: ValidateZoneRequest
+	(*ValidateZoneProblem)(nil),   // 16: ValidateZoneProblem
+	(*ValidateZoneResponse)(nil),  // 17: ValidateZoneResponse
+	(*RenderRequest)(nil),         // 18: RenderRequest
+	(*RenderResponse)(nil),        // 19: RenderResponse
+	nil,                           // 20: Zone.ResourceRecordsEntry
 }
 var file_plugins_proto_zonemgrplugin_proto_depIdxs = []int32{
-	1,  // 0: ResourceRecord.values:type_name -> ResourceRecordValue
-	0,  // 1: Zone.config:type_name -> Config
-	14, // 2: Zone.resource_records:type_name -> Zone.ResourceRecordsEntry
-	3,  // 3: Zone.ttl:type_name -> TTL
-	0,  // 4: ConfigureRequest.config:type_name -> Config
-	2,  // 5: NormalizeRequest.resource_record:type_name -> ResourceRecord
-	2,  // 6: NormalizeResponse.resource_record:type_name -> ResourceRecord
-	4,  // 7: ValidateZoneRequest.zone:type_name -> Zone
-	2,  // 8: RenderRequest.resource_record:type_name -> ResourceRecord
-	2,  // 9: Zone.ResourceRecordsEntry.value:type_name -> ResourceRecord
-	5,  // 10: ZonemgrPlugin.PluginVersion:input_type -> Empty
-	5,  // 11: ZonemgrPlugin.PluginTypes:input_type -> Empty
-	8,  // 12: ZonemgrPlugin.Configure:input_type -> ConfigureRequest
-	9,  // 13: ZonemgrPlugin.Normalize:input_type -> NormalizeRequest
-	11, // 14: ZonemgrPlugin.ValidateZone:input_type -> ValidateZoneRequest
-	12, // 15: ZonemgrPlugin.Render:input_type -> RenderRequest
-	6,  // 16: ZonemgrPlugin.PluginVersion:output_type -> PluginVersionResponse
-	7,  // 17: ZonemgrPlugin.PluginTypes:output_type -> PluginTypesResponse
-	5,  // 18: ZonemgrPlugin.Configure:output_type -> Empty
-	10, // 19: ZonemgrPlugin.Normalize:output_type -> NormalizeResponse
-	5,  // 20: ZonemgrPlugin.ValidateZone:output_type -> Empty
-	13, // 21: ZonemgrPlugin.Render:output_type -> RenderResponse
-	16, // [16:22] is the sub-list for method output_type
-	10, // [10:16] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	2,  // 0: SOAFields.mname:type_name -> SOAField
+	2,  // 1: SOAFields.rname:type_name -> SOAField
+	2,  // 2: SOAFields.serial:type_name -> SOAField
+	2,  // 3: SOAFields.refresh:type_name -> SOAField
+	2,  // 4: SOAFields.retry:type_name -> SOAField
+	2,  // 5: SOAFields.expire:type_name -> SOAField
+	2,  // 6: SOAFields.minimum:type_name -> SOAField
+	1,  // 7: ResourceRecord.values:type_name -> ResourceRecordValue
+	3,  // 8: ResourceRecord.soa:type_name -> SOAFields
+	4,  // 9: ResourceRecord.mx:type_name -> MXFields
+	5,  // 10: ResourceRecord.generate:type_name -> Generate
+	0,  // 11: Zone.config:type_name -> Config
+	20, // 12: Zone.resource_records:type_name -> Zone.ResourceRecordsEntry
+	7,  // 13: Zone.ttl:type_name -> TTL
+	0,  // 14: ConfigureRequest.config:type_name -> Config
+	6,  // 15: NormalizeRequest.resource_record:type_name -> ResourceRecord
+	6,  // 16: NormalizeResponse.resource_record:type_name -> ResourceRecord
+	8,  // 17: ValidateZoneRequest.zone:type_name -> Zone
+	16, // 18: ValidateZoneResponse.problems:type_name -> ValidateZoneProblem
+	6,  // 19: RenderRequest.resource_record:type_name -> ResourceRecord
+	6,  // 20: Zone.ResourceRecordsEntry.value:type_name -> ResourceRecord
+	9,  // 21: ZonemgrPlugin.PluginVersion:input_type -> Empty
+	9,  // 22: ZonemgrPlugin.PluginTypes:input_type -> Empty
+	12, // 23: ZonemgrPlugin.Configure:input_type -> ConfigureRequest
+	13, // 24: ZonemgrPlugin.Normalize:input_type -> NormalizeRequest
+	15, // 25: ZonemgrPlugin.ValidateZone:input_type -> ValidateZoneRequest
+	18, // 26: ZonemgrPlugin.Render:input_type -> RenderRequest
+	10, // 27: ZonemgrPlugin.PluginVersion:output_type -> PluginVersionResponse
+	11, // 28: ZonemgrPlugin.PluginTypes:output_type -> PluginTypesResponse
+	9,  // 29: ZonemgrPlugin.Configure:output_type -> Empty
+	14, // 30: ZonemgrPlugin.Normalize:output_type -> NormalizeResponse
+	17, // 31: ZonemgrPlugin.ValidateZone:output_type -> ValidateZoneResponse
+	19, // 32: ZonemgrPlugin.Render:output_type -> RenderResponse
+	27, // [27:33] is the sub-list for method output_type
+	21, // [21:27] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_plugins_proto_zonemgrplugin_proto_init() }
@@ -918,16 +1460,17 @@ func file_plugins_proto_zonemgrplugin_proto_init() {
 	if File_plugins_proto_zonemgrplugin_proto != nil {
 		return
 	}
-	file_plugins_proto_zonemgrplugin_proto_msgTypes[2].OneofWrappers = []any{}
 	file_plugins_proto_zonemgrplugin_proto_msgTypes[3].OneofWrappers = []any{}
-	file_plugins_proto_zonemgrplugin_proto_msgTypes[4].OneofWrappers = []any{}
+	file_plugins_proto_zonemgrplugin_proto_msgTypes[6].OneofWrappers = []any{}
+	file_plugins_proto_zonemgrplugin_proto_msgTypes[7].OneofWrappers = []any{}
+	file_plugins_proto_zonemgrplugin_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_plugins_proto_zonemgrplugin_proto_rawDesc), len(file_plugins_proto_zonemgrplugin_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -52,7 +52,7 @@ type ZonemgrPluginClient interface {
 	PluginTypes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PluginTypesResponse, error)
 	Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*Empty, error)
 	Normalize(ctx context.Context, in *NormalizeRequest, opts ...grpc.CallOption) (*NormalizeResponse, error)
-	ValidateZone(ctx context.Context, in *ValidateZoneRequest, opts ...grpc.CallOption) (*Empty, error)
+	ValidateZone(ctx context.Context, in *ValidateZoneRequest, opts ...grpc.CallOption) (*ValidateZoneResponse, error)
 	Render(ctx context.Context, in *RenderRequest, opts ...grpc.CallOption) (*RenderResponse, error)
 }
 
@@ -104,9 +104,9 @@ func (c *zonemgrPluginClient) Normalize(ctx context.Context, in *NormalizeReques
 	return out, nil
 }
 
-func (c *zonemgrPluginClient) ValidateZone(ctx context.Context, in *ValidateZoneRequest, opts ...grpc.CallOption) (*Empty, error) {
+func (c *zonemgrPluginClient) ValidateZone(ctx context.Context, in *ValidateZoneRequest, opts ...grpc.CallOption) (*ValidateZoneResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
+	out := new(ValidateZoneResponse)
 	err := c.cc.Invoke(ctx, ZonemgrPlugin_ValidateZone_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -132,7 +132,7 @@ type ZonemgrPluginServer interface {
 	PluginTypes(context.Context, *Empty) (*PluginTypesResponse, error)
 	Configure(context.Context, *ConfigureRequest) (*Empty, error)
 	Normalize(context.Context, *NormalizeRequest) (*NormalizeResponse, error)
-	ValidateZone(context.Context, *ValidateZoneRequest) (*Empty, error)
+	ValidateZone(context.Context, *ValidateZoneRequest) (*ValidateZoneResponse, error)
 	Render(context.Context, *RenderRequest) (*RenderResponse, error)
 }
 
@@ -155,7 +155,7 @@ func (UnimplementedZonemgrPluginServer) Configure(context.Context, *ConfigureReq
 func (UnimplementedZonemgrPluginServer) Normalize(context.Context, *NormalizeRequest) (*NormalizeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Normalize not implemented")
 }
-func (UnimplementedZonemgrPluginServer) ValidateZone(context.Context, *ValidateZoneRequest) (*Empty, error) {
+func (UnimplementedZonemgrPluginServer) ValidateZone(context.Context, *ValidateZoneRequest) (*ValidateZoneResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ValidateZone not implemented")
 }
 func (UnimplementedZonemgrPluginServer) Render(context.Context, *RenderRequest) (*RenderResponse, error) {
@@ -0,0 +1,79 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package plugins
+
+import (
+	"strings"
+
+	"github.com/bcurnow/zonemgr/models"
+)
+
+// A problem with a single resource record found by ValidateZone, the identifier lets the problem be reported at the
+// record instead of the zone. ValidateZone returns more than one by joining them with errors.Join.
+type RecordError struct {
+	Identifier string
+	// Empty is treated as models.SeverityError, a warning is reported but doesn't make the zone invalid
+	Severity models.Severity
+	Err      error
+}
+
+func NewRecordError(identifier string, err error) *RecordError {
+	return &RecordError{Identifier: identifier, Err: err}
+}
+
+func NewRecordWarning(identifier string, err error) *RecordError {
+	return &RecordError{Identifier: identifier, Severity: models.SeverityWarning, Err: err}
+}
+
+func (e *RecordError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// Splits an error returned by ValidateZone into the problems it reports, an error joined with errors.Join is split
+// into the errors it joins, any other error is a single problem
+func Problems(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+
+	// fmt.Errorf with more than one %w unwraps the same way, its message isn't just the messages it wraps
+	messages := make([]string, 0, len(joined.Unwrap()))
+	for _, e := range joined.Unwrap() {
+		messages = append(messages, e.Error())
+	}
+	if err.Error() != strings.Join(messages, "\n") {
+		return []error{err}
+	}
+
+	var problems []error
+	for _, e := range joined.Unwrap() {
+		problems = append(problems, Problems(e)...)
+	}
+	return problems
+}
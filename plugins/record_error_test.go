@@ -0,0 +1,84 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bcurnow/zonemgr/models"
+)
+
+func TestRecordError(t *testing.T) {
+	cause := errors.New("testing-err")
+	err := NewRecordError("www", cause)
+
+	if err.Identifier != "www" {
+		t.Errorf("incorrect identifier: '%s', want: 'www'", err.Identifier)
+	}
+	if err.Error() != "testing-err" {
+		t.Errorf("incorrect error: '%s', want: 'testing-err'", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("expected the record error to wrap its cause")
+	}
+	if err.Severity != "" {
+		t.Errorf("incorrect severity: '%s', want: ''", err.Severity)
+	}
+
+	warning := NewRecordWarning("www", cause)
+	if warning.Identifier != "www" || warning.Severity != models.SeverityWarning || warning.Err != cause {
+		t.Errorf("incorrect warning: %+v", warning)
+	}
+}
+
+func TestProblems(t *testing.T) {
+	one := errors.New("one")
+	two := NewRecordError("two", errors.New("two"))
+	three := errors.New("three")
+	wrapped := fmt.Errorf("%w and %w", one, three)
+
+	testCases := []struct {
+		name string
+		err  error
+		want []error
+	}{
+		{name: "nil"},
+		{name: "single", err: one, want: []error{one}},
+		{name: "record", err: two, want: []error{two}},
+		{name: "joined", err: errors.Join(one, two), want: []error{one, two}},
+		{name: "nested", err: errors.Join(errors.Join(one, two), three), want: []error{one, two, three}},
+		{name: "wrapped", err: wrapped, want: []error{wrapped}},
+	}
+
+	for _, tc := range testCases {
+		got := Problems(tc.err)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s - incorrect number of problems: %d, want: %d", tc.name, len(got), len(tc.want))
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s - incorrect problem %d: '%s', want: '%s'", tc.name, i, got[i], tc.want[i])
+			}
+		}
+	}
+}
@@ -40,6 +40,11 @@ const dnsNameRegexRFC1035String = `^((?:[A-Za-z0-9-]|\\.){1,63})(\.(?:[A-Za-z0-9
 
 var dnsNameRegexRFC1035 = regexp.MustCompile(dnsNameRegexRFC1035String)
 
+// The underscore labels defined by RFC8552 (e.g. _sip, _tcp), these can only be used as the leftmost labels of a name
+const underscoreLabelRegexString = `^_[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?$`
+
+var underscoreLabelRegex = regexp.MustCompile(underscoreLabelRegexString)
+
 type Validator interface {
 	// Performs the standard validations for resource records
 	// This includes:
@@ -53,8 +58,11 @@ type Validator interface {
 	// Validates that the name provided matches the RFC1035 regex for valid names according to RFC1035
 	// and is less then or equal to 255 total characters
 	EnsureValidRFC1035Name(identifier string, name string, rrType models.ResourceRecordType) error
-	// Checks if the name provide is either the wildcard ('@') or is a valid name
+	// Checks if the name provide is either the zone itself ('@'), a wildcard ('*' as the leftmost label, e.g. * or *.apps) or is a valid name
 	EnsureValidNameOrWildcard(identifier string, name string, rrType models.ResourceRecordType) error
+	// Checks if the name is valid while also allowing the underscore labels used by service records (e.g. _sip._tcp, see RFC2782 and RFC8552)
+	// the underscore labels must be the leftmost labels of the name, the rest of the name must be a valid name (or the wildcard ('@') when there are no underscore labels)
+	EnsureValidUnderscoreName(identifier string, name string, rrType models.ResourceRecordType) error
 	// Formats and email address according to RFC1035
 	FormatEmail(identifier string, email string, rrType models.ResourceRecordType) (string, error)
 	// Most DNS names in a zone file need to be fully qualified domain names, while we can't validate if the entire name itself is valid,
@@ -66,7 +74,7 @@ type Validator interface {
 	EnsureIP(identifier string, s string, rrType models.ResourceRecordType) error
 	// Ensure that the string is NOT an IP address
 	EnsureNotIP(identifier string, s string, rrType models.ResourceRecordType) error
-	//  Ensurre that the string is a 32-bit integer which is positive and greater than zero
+	//  Ensurre that the string is a 32-bit integer which is positive and greater than zero or a time interval with units
 	EnsurePositive(identifier string, s string, fieldName string, rrType models.ResourceRecordType) error
 }
 
@@ -105,6 +113,19 @@ func (v *validator) CommonValidations(identifier string, rr *models.ResourceReco
 		return fmt.Errorf("invalid %s record, both comment and values are set, identifier: '%s'", rr.Type, identifier)
 	}
 
+	// Only address records are reversed
+	if (rr.Reverse != nil || rr.PTRName != "") && rr.Type != models.A && rr.Type != models.AAAA {
+		return fmt.Errorf("invalid %s record, reverse and ptr_name can only be used on A and AAAA records, identifier: '%s'", rr.Type, identifier)
+	}
+
+	if rr.MX != nil && rr.Type != models.MX {
+		return fmt.Errorf("invalid %s record, mx can only be used on MX records, identifier: '%s'", rr.Type, identifier)
+	}
+
+	if rr.Reverse != nil && !*rr.Reverse && rr.PTRName != "" {
+		return fmt.Errorf("invalid %s record, ptr_name can't be used when reverse is false, identifier: '%s'", rr.Type, identifier)
+	}
+
 	return nil
 }
 
@@ -137,15 +158,54 @@ func (v *validator) EnsureValidRFC1035Name(identifier string, name string, rrTyp
 	return nil
 }
 
-// Checks if the name provide is either the wildcard ('@') or is a valid name
+// Checks if the name provide is either the zone itself ('@'), a wildcard ('*' as the leftmost label, e.g. * or *.apps) or is a valid name
 func (v *validator) EnsureValidNameOrWildcard(identifier string, name string, rrType models.ResourceRecordType) error {
 	// Check if the name matches the regex or is a wildcard
-	if name == "@" {
+	if name == "@" || name == "*" {
 		return nil
 	}
+
+	// RFC4592 only allows the asterisk as the entire leftmost label, the rest of the name must still be valid
+	if rest, ok := strings.CutPrefix(name, "*."); ok {
+		if len(name) > 255 {
+			return fmt.Errorf("invalid %s record, must be less than 255 characters: '%s', identifier: '%s'", rrType, name, identifier)
+		}
+		return v.EnsureValidRFC1035Name(identifier, rest, rrType)
+	}
 	return v.EnsureValidRFC1035Name(identifier, name, rrType)
 }
 
+// Checks if the name is valid while also allowing the underscore labels used by service records (e.g. _sip._tcp, see RFC2782 and RFC8552)
+// the underscore labels must be the leftmost labels of the name, the rest of the name must be a valid name (or the wildcard ('@') when there are no underscore labels)
+func (v *validator) EnsureValidUnderscoreName(identifier string, name string, rrType models.ResourceRecordType) error {
+	if len(name) > 255 {
+		return fmt.Errorf("invalid %s record, must be less than 255 characters: '%s', identifier: '%s'", rrType, name, identifier)
+	}
+
+	labels := strings.Split(name, ".")
+	underscoreLabels := 0
+	for _, label := range labels {
+		if !strings.HasPrefix(label, "_") {
+			break
+		}
+		if !underscoreLabelRegex.MatchString(label) {
+			return fmt.Errorf("invalid %s record, label '%s' does not match regexp '%s': '%s', identifier: '%s'", rrType, label, underscoreLabelRegexString, name, identifier)
+		}
+		underscoreLabels++
+	}
+
+	if underscoreLabels == 0 {
+		return v.EnsureValidNameOrWildcard(identifier, name, rrType)
+	}
+
+	// Anything after the underscore labels must be a normal name, a name made up of only underscore labels is relative to the zone
+	rest := strings.Join(labels[underscoreLabels:], ".")
+	if rest == "" {
+		return nil
+	}
+	return v.EnsureValidRFC1035Name(identifier, rest, rrType)
+}
+
 // Formats and email address according to RFC1035
 func (v *validator) FormatEmail(identifier string, email string, rrType models.ResourceRecordType) (string, error) {
 	if strings.Contains(email, "@") {
@@ -220,11 +280,15 @@ func (v *validator) EnsureNotIP(identifier string, s string, rrType models.Resou
 	return nil
 }
 
-// Ensure that the string is a 32-bit integer which is a positive number greater than zero
+// Ensure that the string is a 32-bit integer which is a positive number greater than zero, it can also be a time
+// interval with units (e.g. 1h or 1w3d) which is always positive
 func (v *validator) EnsurePositive(identifier string, s string, fieldName string, rrType models.ResourceRecordType) error {
 	value, err := strconv.ParseInt(s, 10, 32)
 	if err != nil {
-		return err
+		if _, err := models.ParseTimeInterval(s); err != nil {
+			return fmt.Errorf("%s must be a number of seconds or a time interval with units (e.g. 1h) on a %s record, was '%s', identifier: '%s'", fieldName, rrType, s, identifier)
+		}
+		return nil
 	}
 
 	if value < 0 {
@@ -34,6 +34,7 @@ func TestCommonValidations(t *testing.T) {
 		rr         *models.ResourceRecord
 		name       string
 		identifier string
+		pluginType Type
 		err        string
 	}{
 		{identifier: "ValidRecord", rr: &models.ResourceRecord{Type: models.A, Value: "1.2.3.4"}, name: "ValidRecordWithoutAName"},
@@ -41,10 +42,19 @@ func TestCommonValidations(t *testing.T) {
 		{identifier: "Wrong class", rr: &models.ResourceRecord{Type: models.A, Class: "bogus", Value: "1.2.3.4"}, err: "invalid A record, 'bogus' is not a valid class, identifier: 'Wrong class'"},
 		{identifier: "Value set twice", rr: &models.ResourceRecord{Type: models.A, Values: []*models.ResourceRecordValue{{Value: "value once"}}, Value: "value again"}, err: "invalid A record, both value and values are set, identifier: 'Value set twice'"},
 		{identifier: "Comment set twice", rr: &models.ResourceRecord{Type: models.A, Values: []*models.ResourceRecordValue{{Comment: "comment again"}}, Comment: "comment once"}, err: "invalid A record, both comment and values are set, identifier: 'Comment set twice'"},
+		{identifier: "Reverse", rr: &models.ResourceRecord{Type: models.A, Value: "1.2.3.4", Reverse: toBoolPtr(true), PTRName: "www.example.com."}},
+		{identifier: "Reverse on CNAME", rr: &models.ResourceRecord{Type: models.CNAME, Value: "www", Reverse: toBoolPtr(false)}, pluginType: CNAME, err: "invalid CNAME record, reverse and ptr_name can only be used on A and AAAA records, identifier: 'Reverse on CNAME'"},
+		{identifier: "PTR name on CNAME", rr: &models.ResourceRecord{Type: models.CNAME, Value: "www", PTRName: "www"}, pluginType: CNAME, err: "invalid CNAME record, reverse and ptr_name can only be used on A and AAAA records, identifier: 'PTR name on CNAME'"},
+		{identifier: "MX on A", rr: &models.ResourceRecord{Type: models.A, MX: &models.MXFields{Preference: "10", Exchange: "mail.example.com."}}, err: "invalid A record, mx can only be used on MX records, identifier: 'MX on A'"},
+		{identifier: "PTR name without reverse", rr: &models.ResourceRecord{Type: models.A, Value: "1.2.3.4", Reverse: toBoolPtr(false), PTRName: "www"}, err: "invalid A record, ptr_name can't be used when reverse is false, identifier: 'PTR name without reverse'"},
 	}
 
 	for _, tc := range testCases {
-		err := validations.CommonValidations(tc.identifier, tc.rr, A)
+		pluginType := tc.pluginType
+		if pluginType == "" {
+			pluginType = A
+		}
+		err := validations.CommonValidations(tc.identifier, tc.rr, pluginType)
 		if err != nil {
 			if tc.err == "" {
 				t.Errorf("unexpected error: %s", err)
@@ -53,6 +63,8 @@ func TestCommonValidations(t *testing.T) {
 					t.Errorf("incorrect error: %s, want %s", err, tc.err)
 				}
 			}
+		} else if tc.err != "" {
+			t.Errorf("%s: expected an error, found none", tc.identifier)
 		}
 	}
 }
@@ -124,6 +136,28 @@ func TestEnsureValidNameOrWildcard(t *testing.T) {
 		t.Errorf("unexpected error")
 	}
 
+	for _, name := range []string{"*", "*.apps", "*.apps.example.com."} {
+		if err := validations.EnsureValidNameOrWildcard("testing", name, models.A); err != nil {
+			t.Errorf("%s - unexpected error: %s", name, err)
+		}
+	}
+
+	for _, name := range []string{"*apps", "apps.*", "www.*.example.com.", "*.-apps", "*."} {
+		if err := validations.EnsureValidNameOrWildcard("testing", name, models.A); err == nil {
+			t.Errorf("%s - expected an error, found none", name)
+		}
+	}
+
+	longWildcard := "*." + strings.Repeat("a.", 127)
+	if err := validations.EnsureValidNameOrWildcard("testing", longWildcard, models.A); err != nil {
+		want := fmt.Sprintf("invalid A record, must be less than 255 characters: '%s', identifier: 'testing'", longWildcard)
+		if err.Error() != want {
+			t.Errorf("incorrrect error: '%s', want: '%s'", err, want)
+		}
+	} else {
+		t.Error("expected an error, found none")
+	}
+
 	if err := validations.EnsureValidNameOrWildcard("testing", "$bogus", models.A); err != nil {
 		want := fmt.Sprintf("invalid A record, does not match regexp '%s': '$bogus', identifier: 'testing'", dnsNameRegexRFC1035String)
 		if err.Error() != want {
@@ -134,6 +168,41 @@ func TestEnsureValidNameOrWildcard(t *testing.T) {
 	}
 }
 
+func TestEnsureValidUnderscoreName(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  string
+	}{
+		{name: "_sip._tcp"},
+		{name: "_ldap._tcp.example.com."},
+		{name: "_kerberos._udp.dc"},
+		{name: "_25._tcp.mail.example.com."},
+		{name: "_sip._tcp."},
+		{name: "www.example.com."},
+		{name: "@"},
+		{name: "_-sip._tcp", err: fmt.Sprintf("invalid SRV record, label '_-sip' does not match regexp '%s': '_-sip._tcp', identifier: 'testing'", underscoreLabelRegexString)},
+		{name: "_sip-._tcp", err: fmt.Sprintf("invalid SRV record, label '_sip-' does not match regexp '%s': '_sip-._tcp', identifier: 'testing'", underscoreLabelRegexString)},
+		{name: "_._tcp", err: fmt.Sprintf("invalid SRV record, label '_' does not match regexp '%s': '_._tcp', identifier: 'testing'", underscoreLabelRegexString)},
+		{name: "sip._tcp", err: fmt.Sprintf("invalid SRV record, does not match regexp '%s': 'sip._tcp', identifier: 'testing'", dnsNameRegexRFC1035String)},
+		{name: "_sip.tcp._udp", err: fmt.Sprintf("invalid SRV record, does not match regexp '%s': 'tcp._udp', identifier: 'testing'", dnsNameRegexRFC1035String)},
+		{name: "_sip._tcp.@", err: fmt.Sprintf("invalid SRV record, does not match regexp '%s': '@', identifier: 'testing'", dnsNameRegexRFC1035String)},
+		{name: "_sip._tcp." + strings.Repeat("a.", 125), err: "invalid SRV record, must be less than 255 characters: '_sip._tcp." + strings.Repeat("a.", 125) + "', identifier: 'testing'"},
+	}
+
+	for _, tc := range testCases {
+		err := validations.EnsureValidUnderscoreName("testing", tc.name, models.SRV)
+		if err != nil {
+			if tc.err == "" {
+				t.Errorf("%s - unexpected error: %s", tc.name, err)
+			} else if err.Error() != tc.err {
+				t.Errorf("%s - incorrect error: %s, want %s", tc.name, err, tc.err)
+			}
+		} else if tc.err != "" {
+			t.Errorf("%s - expected an error, found none", tc.name)
+		}
+	}
+}
+
 func TestFormatEmail(t *testing.T) {
 
 	testCases := []struct {
@@ -256,7 +325,10 @@ func TestEnsurePositive(t *testing.T) {
 		s   string
 		err string
 	}{
-		{s: "bogus", err: "strconv.ParseInt: parsing \"bogus\": invalid syntax"},
+		{s: "bogus", err: "retry must be a number of seconds or a time interval with units (e.g. 1h) on a SOA record, was 'bogus', identifier: 'testing'"},
+		{s: "1h"},
+		{s: "1w3d"},
+		{s: "1y", err: "retry must be a number of seconds or a time interval with units (e.g. 1h) on a SOA record, was '1y', identifier: 'testing'"},
 		{s: "0"},
 		{s: "1"},
 		{s: "1234567890"},
@@ -279,3 +351,7 @@ func TestEnsurePositive(t *testing.T) {
 		}
 	}
 }
+
+func toBoolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,187 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Writes a set of files as a unit, every file is written to a temporary file next to its final location and
+// only moved into place when the transaction is committed. Until then, the existing files are never touched.
+type FileTransaction interface {
+	// Calls contentFn and writes the content to a temporary file with the specified mode, the file at path is not changed until Commit is called
+	StageFile(path string, mode os.FileMode, contentFn func() ([]byte, error)) error
+	// Moves every staged file into place, if any file can't be moved, the files already moved are restored to their original content
+	Commit() error
+	// Removes the temporary files of a transaction that hasn't been committed, this is a nop once the transaction is finished
+	// which makes it safe to defer right after the transaction is created
+	Rollback()
+}
+
+var (
+	createTempFile = os.CreateTemp
+	remove         = os.Remove
+	rename         = os.Rename
+
+	// Make sure we implement the interface
+	_ FileTransaction = &fileTransaction{}
+)
+
+type stagedFile struct {
+	path     string
+	tempPath string
+	// Used to restore the original file if the transaction fails part way through the commit
+	existed      bool
+	original     []byte
+	originalMode os.FileMode
+}
+
+type fileTransaction struct {
+	staged   []*stagedFile
+	finished bool
+}
+
+func (tx *fileTransaction) StageFile(path string, mode os.FileMode, contentFn func() ([]byte, error)) error {
+	if tx.finished {
+		return fmt.Errorf("unable to stage '%s', the transaction has already finished", path)
+	}
+
+	for _, sf := range tx.staged {
+		if sf.path == path {
+			return fmt.Errorf("unable to stage '%s', it has already been staged", path)
+		}
+	}
+
+	// Generate the content first, if this fails there is nothing to clean up
+	content, err := contentFn()
+	if err != nil {
+		return fmt.Errorf("error generating content for output file '%s': %w", path, err)
+	}
+
+	// The temporary file is created in the same directory so the rename in Commit is atomic
+	tempFile, err := createTempFile(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for output file '%s': %w", path, err)
+	}
+
+	if err := writeTempFile(tempFile, path, mode, content); err != nil {
+		if removeErr := remove(tempFile.Name()); removeErr != nil {
+			logger().Warn("unable to remove temporary file", "tempFile", tempFile.Name(), "err", removeErr)
+		}
+		return err
+	}
+
+	logger().Trace("staged output file", "outputFile", path, "tempFile", tempFile.Name(), "bytesWritten", len(content))
+	tx.staged = append(tx.staged, &stagedFile{path: path, tempPath: tempFile.Name()})
+	return nil
+}
+
+func (tx *fileTransaction) Commit() error {
+	if tx.finished {
+		return errors.New("unable to commit, the transaction has already finished")
+	}
+	tx.finished = true
+
+	for i, sf := range tx.staged {
+		if err := commitFile(sf); err != nil {
+			restoreFiles(tx.staged[:i])
+			removeTempFiles(tx.staged[i:])
+			return fmt.Errorf("failed to move output file '%s' into place, no output files were changed: %w", sf.path, err)
+		}
+		logger().Trace("moved output file into place", "outputFile", sf.path)
+	}
+	return nil
+}
+
+func (tx *fileTransaction) Rollback() {
+	if tx.finished {
+		return
+	}
+	tx.finished = true
+	removeTempFiles(tx.staged)
+}
+
+func writeTempFile(tempFile *os.File, path string, mode os.FileMode, content []byte) error {
+	defer tempFile.Close()
+
+	// Set the mode on the file
+	if err := chmod(tempFile.Name(), mode); err != nil {
+		return fmt.Errorf("error with chmod of '%s' to '%o': %s", tempFile.Name(), mode, err)
+	}
+
+	if _, err := tempFile.Write(content); err != nil {
+		return fmt.Errorf("error writing content for output file '%s': %w", path, err)
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("error writing content for output file '%s': %w", path, err)
+	}
+	return nil
+}
+
+// Keeps a copy of the existing file (if any) and then atomically replaces it with the staged file
+func commitFile(sf *stagedFile) error {
+	info, err := stat(sf.path)
+	switch {
+	case err == nil:
+		original, err := readFile(sf.path)
+		if err != nil {
+			return err
+		}
+		sf.existed = true
+		sf.original = original
+		sf.originalMode = info.Mode().Perm()
+	case !errors.Is(err, os.ErrNotExist):
+		return err
+	}
+
+	return rename(sf.tempPath, sf.path)
+}
+
+// Puts the files that were already moved into place back the way they were, this is best effort as there is
+// nothing more that can be done if this fails too
+func restoreFiles(committed []*stagedFile) {
+	for _, sf := range committed {
+		var err error
+		if sf.existed {
+			tx := &fileTransaction{}
+			if err = tx.StageFile(sf.path, sf.originalMode, func() ([]byte, error) { return sf.original, nil }); err == nil {
+				err = tx.Commit()
+			}
+		} else {
+			err = remove(sf.path)
+		}
+
+		if err != nil {
+			logger().Error("unable to restore output file", "outputFile", sf.path, "err", err)
+		}
+	}
+}
+
+func removeTempFiles(staged []*stagedFile) {
+	for _, sf := range staged {
+		if err := remove(sf.tempPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger().Warn("unable to remove temporary file", "tempFile", sf.tempPath, "err", err)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func content(c string) func() ([]byte, error) {
+	return func() ([]byte, error) { return []byte(c), nil }
+}
+
+func assertContent(t *testing.T, path string, want string) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("unable to read '%s': %s", path, err)
+		return
+	}
+	if string(got) != want {
+		t.Errorf("incorrect content in '%s': '%s', want: '%s'", path, got, want)
+	}
+}
+
+func assertNoTempFiles(t *testing.T, dir string, want int) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unable to read '%s': %s", dir, err)
+	}
+	if len(entries) != want {
+		t.Errorf("incorrect number of files in '%s': %d, want: %d (%v)", dir, len(entries), want, entries)
+	}
+}
+
+func TestFileTransaction_Commit(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing")
+	newFile := filepath.Join(dir, "new")
+	if err := os.WriteFile(existing, []byte("original"), 0600); err != nil {
+		t.Fatalf("unable to create existing file: %s", err)
+	}
+
+	tx := (&FileSystem{}).NewTransaction()
+	if err := tx.StageFile(existing, 0640, content("replaced")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := tx.StageFile(newFile, 0644, content("created")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Nothing changes until the commit
+	assertContent(t, existing, "original")
+	if _, err := os.Stat(newFile); !os.IsNotExist(err) {
+		t.Errorf("expected '%s' to not exist before the commit, stat returned: %v", newFile, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assertContent(t, existing, "replaced")
+	assertContent(t, newFile, "created")
+	assertNoTempFiles(t, dir, 2)
+
+	info, err := os.Stat(newFile)
+	if err != nil {
+		t.Fatalf("unable to stat '%s': %s", newFile, err)
+	}
+	if info.Mode().Perm() != 0644 {
+		t.Errorf("incorrect mode: %o, want: %o", info.Mode().Perm(), 0644)
+	}
+
+	// Rollback after a commit is a nop and a second commit is an error
+	tx.Rollback()
+	assertContent(t, existing, "replaced")
+	if err := tx.Commit(); err == nil || err.Error() != "unable to commit, the transaction has already finished" {
+		t.Errorf("incorrect error: '%v'", err)
+	}
+	if err := tx.StageFile(existing, 0640, content("again")); err == nil || err.Error() != fmt.Sprintf("unable to stage '%s', the transaction has already finished", existing) {
+		t.Errorf("incorrect error: '%v'", err)
+	}
+}
+
+func TestFileTransaction_Rollback(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing")
+	if err := os.WriteFile(existing, []byte("original"), 0600); err != nil {
+		t.Fatalf("unable to create existing file: %s", err)
+	}
+
+	tx := (&FileSystem{}).NewTransaction()
+	if err := tx.StageFile(existing, 0640, content("replaced")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := tx.StageFile(filepath.Join(dir, "new"), 0640, content("created")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tx.Rollback()
+
+	assertContent(t, existing, "original")
+	assertNoTempFiles(t, dir, 1)
+}
+
+func TestFileTransaction_CommitErr(t *testing.T) {
+	defer func() { rename = os.Rename }()
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "a-existing")
+	newFile := filepath.Join(dir, "b-new")
+	failing := filepath.Join(dir, "c-failing")
+	if err := os.WriteFile(existing, []byte("original"), 0600); err != nil {
+		t.Fatalf("unable to create existing file: %s", err)
+	}
+
+	tx := (&FileSystem{}).NewTransaction()
+	for _, path := range []string{existing, newFile, failing} {
+		if err := tx.StageFile(path, 0640, content("staged")); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	rename = func(oldPath string, newPath string) error {
+		if newPath == failing {
+			return errors.New("renameErr")
+		}
+		return os.Rename(oldPath, newPath)
+	}
+
+	err := tx.Commit()
+	want := fmt.Sprintf("failed to move output file '%s' into place, no output files were changed: renameErr", failing)
+	if err == nil || err.Error() != want {
+		t.Fatalf("incorrect error: '%v', want: '%s'", err, want)
+	}
+
+	// The files moved into place before the failure are put back the way they were
+	assertContent(t, existing, "original")
+	if _, err := os.Stat(newFile); !os.IsNotExist(err) {
+		t.Errorf("expected '%s' to be removed, stat returned: %v", newFile, err)
+	}
+	assertNoTempFiles(t, dir, 1)
+}
+
+func TestFileTransaction_StageFileErr(t *testing.T) {
+	defer func() {
+		createTempFile = os.CreateTemp
+		chmod = os.Chmod
+	}()
+
+	testCases := []struct {
+		name          string
+		duplicate     bool
+		contentErr    bool
+		createTempErr bool
+		chmodErr      bool
+		writeErr      bool
+	}{
+		{name: "duplicate", duplicate: true},
+		{name: "content", contentErr: true},
+		{name: "create-temp", createTempErr: true},
+		{name: "chmod", chmodErr: true},
+		{name: "write", writeErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			createTempFile = os.CreateTemp
+			chmod = os.Chmod
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, "testing")
+			tx := &fileTransaction{}
+			contentFn := content("content")
+			want := ""
+
+			switch {
+			case tc.duplicate:
+				if err := tx.StageFile(path, 0640, contentFn); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				want = fmt.Sprintf("unable to stage '%s', it has already been staged", path)
+			case tc.contentErr:
+				contentFn = func() ([]byte, error) { return nil, errors.New("contentErr") }
+				want = fmt.Sprintf("error generating content for output file '%s': contentErr", path)
+			case tc.createTempErr:
+				createTempFile = func(_ string, _ string) (*os.File, error) { return nil, errors.New("createTempErr") }
+				want = fmt.Sprintf("failed to create temporary file for output file '%s': createTempErr", path)
+			case tc.chmodErr:
+				chmod = func(_ string, _ os.FileMode) error { return errors.New("chmodErr") }
+			case tc.writeErr:
+				createTempFile = func(dir string, pattern string) (*os.File, error) {
+					f, err := os.CreateTemp(dir, pattern)
+					if err == nil {
+						err = f.Close()
+					}
+					return f, err
+				}
+			}
+
+			err := tx.StageFile(path, 0640, contentFn)
+			if err == nil {
+				t.Fatal("expected an error, found none")
+			}
+			if want != "" && err.Error() != want {
+				t.Errorf("incorrect error: '%s', want: '%s'", err, want)
+			}
+
+			// Only the successfully staged duplicate may leave a temporary file behind
+			wantFiles := 0
+			if tc.duplicate {
+				wantFiles = 1
+			}
+			assertNoTempFiles(t, dir, wantFiles)
+		})
+	}
+}
@@ -37,7 +37,6 @@ var (
 	// This will allow us to override these methods when we are testing
 	abs         = filepath.Abs
 	chmod       = os.Chmod
-	create      = os.Create
 	getWd       = os.Getwd
 	homeDir     string
 	mkdirAll    = os.MkdirAll
@@ -52,6 +51,7 @@ var (
 
 type FileSystemOperations interface {
 	// Creates the path specified, sets the mode and calls the contentFn to generate the file content
+	// The content is written to a temporary file first, an existing file is only replaced once the content has been written
 	CreateFile(path string, mode os.FileMode, contentFn func() ([]byte, error)) error
 	// Returns true if the path exists, false otherwise
 	Exists(path string) bool
@@ -61,6 +61,8 @@ type FileSystemOperations interface {
 	HomeDir() string
 	// Creates the directory and any missing parents using the supplied permissions for any directory it creates, if the directory exists, this is a nop
 	MkdirAll(path string, mode os.FileMode) error
+	// Starts a new transaction for writing several files as a unit
+	NewTransaction() FileTransaction
 	// Takes a path name and returns the absolute path value
 	// This method is similar to filepath.Abs but also handles
 	// paths that start with '~' and will automatically expand this to the
@@ -75,29 +77,13 @@ type FileSystem struct {
 }
 
 func (fs *FileSystem) CreateFile(path string, mode os.FileMode, contentFn func() ([]byte, error)) error {
-	outputFile, err := create(path)
-	if err != nil {
-		return fmt.Errorf("failed to create output file '%s': %w", path, err)
-	}
-	defer outputFile.Close()
-
-	// Set the mode on the file
-	if err := chmod(path, mode); err != nil {
-		return fmt.Errorf("error with chmod of '%s' to '%o': %s", path, mode, err)
-	}
+	tx := fs.NewTransaction()
+	defer tx.Rollback()
 
-	content, err := contentFn()
-	if err != nil {
-		return fmt.Errorf("error generating content for output file '%s': %w", path, err)
+	if err := tx.StageFile(path, mode, contentFn); err != nil {
+		return err
 	}
-
-	bytesWritten, err := outputFile.Write(content)
-	if err != nil {
-		return fmt.Errorf("error writing content for output file '%s': %w", path, err)
-	}
-	logger().Trace("wrote content to output file", "outputFile", path, "bytesWritten", bytesWritten)
-
-	return nil
+	return tx.Commit()
 }
 
 func (fs *FileSystem) Exists(path string) bool {
@@ -133,6 +119,10 @@ func (fs *FileSystem) MkdirAll(path string, mode os.FileMode) error {
 	return mkdirAll(path, mode)
 }
 
+func (fs *FileSystem) NewTransaction() FileTransaction {
+	return &fileTransaction{}
+}
+
 func (fs *FileSystem) ToAbsoluteFilePath(path string) (string, error) {
 	//go doesn't automatically handle the ~ expansion, do this manually
 	if strings.HasPrefix(path, "~") {
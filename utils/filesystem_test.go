@@ -25,7 +25,6 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
-	"slices"
 	"testing"
 
 	"github.com/gofrs/flock"
@@ -53,88 +52,58 @@ func (d *infoErrorDirEntry) Info() (fs.FileInfo, error) {
 }
 
 func TestCreateFile(t *testing.T) {
-	defer tempTeardown(t)
 	testCases := []struct {
-		createErr  bool
-		chmodErr   bool
+		existing   bool
 		contentErr bool
-		writeErr   bool
 	}{
 		{},
-		{createErr: true},
-		{chmodErr: true},
+		{existing: true},
 		{contentErr: true},
-		{writeErr: true},
+		{existing: true, contentErr: true},
 	}
 
 	for _, tc := range testCases {
-		createTemp(t)
-		defer tempTeardown(t)
-		if tc.createErr {
-			create = func(name string) (*os.File, error) {
-				if name != testFile.Name() {
-					return nil, errors.New("wrong name: " + name)
-				} else {
-					return nil, errors.New("createErr")
-				}
-			}
-		} else {
-			create = func(_ string) (*os.File, error) { return testFile, nil }
-		}
-
-		if tc.chmodErr {
-			chmod = func(name string, mode os.FileMode) error {
-				if name != testFile.Name() && mode != 0755 {
-					return fmt.Errorf("incorrect chmod: name=%s, mode=%o", name, mode)
-				}
-				return errors.New("chmodErr")
+		path := filepath.Join(t.TempDir(), "testing")
+		if tc.existing {
+			if err := os.WriteFile(path, []byte("original"), 0600); err != nil {
+				t.Fatalf("unable to create existing file: %s", err)
 			}
-		} else {
-			chmod = func(_ string, _ os.FileMode) error { return nil }
 		}
 
 		contentFn := func() ([]byte, error) {
 			return []byte("content"), nil
 		}
-
 		if tc.contentErr {
 			contentFn = func() ([]byte, error) { return nil, errors.New("contentErr") }
 		}
 
-		if tc.writeErr {
-			if err := testFile.Close(); err != nil {
-				t.Errorf("error closing file to create a writeErr: %s", err)
+		want := "content"
+		if err := (&FileSystem{}).CreateFile(path, 0640, contentFn); err != nil {
+			if !tc.contentErr {
+				t.Errorf("unexpected error: %s", err)
+				continue
 			}
+			wantErr := fmt.Sprintf("error generating content for output file '%s': contentErr", path)
+			if err.Error() != wantErr {
+				t.Errorf("incorrect error: '%s', want: '%s'", err, wantErr)
+			}
+			// A failure must never leave an empty or partial file behind
+			want = "original"
+		} else if tc.contentErr {
+			t.Error("expected an error, found none")
 		}
 
-		if err := (&FileSystem{}).CreateFile(testFile.Name(), 0755, contentFn); err != nil {
-			want := ""
-			if tc.createErr {
-				want = fmt.Sprintf("failed to create output file '%s': createErr", testFile.Name())
-			} else if tc.chmodErr {
-				want = fmt.Sprintf("error with chmod of '%s' to '755': chmodErr", testFile.Name())
-			} else if tc.contentErr {
-				want = fmt.Sprintf("error generating content for output file '%s': contentErr", testFile.Name())
-			} else if tc.writeErr {
-				want = fmt.Sprintf("error writing content for output file '%s': write %s: file already closed", testFile.Name(), testFile.Name())
+		content, err := os.ReadFile(path)
+		if tc.contentErr && !tc.existing {
+			if !os.IsNotExist(err) {
+				t.Errorf("expected '%s' to not exist, read returned: %v", path, err)
 			}
+		} else if string(content) != want {
+			t.Errorf("incorrect content in '%s': '%s', want: '%s'", path, content, want)
+		}
 
-			if err.Error() != want {
-				t.Errorf("incorrect error: '%s', want: '%s'", err, want)
-			}
-		} else {
-			if tc.createErr || tc.chmodErr || tc.contentErr || tc.writeErr {
-				t.Error("expected an error, found none")
-			} else {
-				content, err := os.ReadFile(testFile.Name())
-				if err != nil {
-					t.Errorf("unable to read test file '%s': %s", testFile.Name(), err)
-				} else {
-					if !slices.Equal(content, []byte("content")) {
-						t.Errorf("incorrect content in test file '%s': '%s', want '%s'", testFile.Name(), content, "content")
-					}
-				}
-			}
+		if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) > 1 {
+			t.Errorf("expected the temporary file to be removed, found: %v", entries)
 		}
 	}
 }
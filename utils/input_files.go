@@ -0,0 +1,98 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var (
+	glob = filepath.Glob
+
+	// The extensions of the files read from a directory
+	yamlExtensions = []string{".yaml", ".yml"}
+)
+
+// Expands the inputs into the list of files to read, in order and without duplicates. An input can be a file,
+// a glob (e.g. zones/*.yaml) or a directory, every YAML file below a directory is included in lexical order.
+// A file that doesn't exist is returned as is so the error reading it names the file.
+func ExpandInputFiles(inputs []string) ([]string, error) {
+	var files []string
+	add := func(file string) {
+		if !slices.Contains(files, file) {
+			files = append(files, file)
+		}
+	}
+
+	for _, input := range inputs {
+		if strings.ContainsAny(input, "*?[") {
+			matches, err := glob(input)
+			if err != nil {
+				return nil, fmt.Errorf("invalid input pattern '%s': %w", input, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no input files match '%s'", input)
+			}
+			for _, match := range matches {
+				add(match)
+			}
+			continue
+		}
+
+		info, err := stat(input)
+		if err != nil || !info.IsDir() {
+			add(input)
+			continue
+		}
+
+		dirFiles, err := yamlFilesIn(input)
+		if err != nil {
+			return nil, err
+		}
+		if len(dirFiles) == 0 {
+			return nil, fmt.Errorf("no YAML files found in directory '%s'", input)
+		}
+		for _, file := range dirFiles {
+			add(file)
+		}
+	}
+	return files, nil
+}
+
+func yamlFilesIn(dir string) ([]string, error) {
+	var files []string
+	err := walkDir(dir, func(path string, d os.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !d.IsDir() && slices.Contains(yamlExtensions, strings.ToLower(filepath.Ext(path))) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input directory '%s': %w", dir, err)
+	}
+	return files, nil
+}
@@ -0,0 +1,85 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExpandInputFiles(t *testing.T) {
+	// Other tests replace these without restoring them
+	stat = os.Stat
+	walkDir = filepath.WalkDir
+
+	dir := t.TempDir()
+	for _, file := range []string{"a.yaml", "b.yml", "notes.txt", "sub/c.yaml", "sub/d.YAML", "empty/.keep"} {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	in := func(files ...string) []string {
+		paths := make([]string, len(files))
+		for i, file := range files {
+			paths[i] = filepath.Join(dir, file)
+		}
+		return paths
+	}
+
+	testCases := []struct {
+		name   string
+		inputs []string
+		want   []string
+		err    string
+	}{
+		{name: "file", inputs: in("a.yaml"), want: in("a.yaml")},
+		{name: "missing-file", inputs: in("missing.yaml"), want: in("missing.yaml")},
+		{name: "glob", inputs: in("*.y*ml"), want: in("a.yaml", "b.yml")},
+		{name: "directory", inputs: []string{dir}, want: in("a.yaml", "b.yml", "sub/c.yaml", "sub/d.YAML")},
+		{name: "no-duplicates", inputs: in("sub/c.yaml", "sub", "a.yaml", "*.yaml"), want: in("sub/c.yaml", "sub/d.YAML", "a.yaml")},
+		{name: "glob-without-matches", inputs: in("*.json"), err: "no input files match"},
+		{name: "invalid-glob", inputs: in("[.yaml"), err: "invalid input pattern"},
+		{name: "directory-without-yaml", inputs: in("empty"), err: "no YAML files found in directory"},
+	}
+
+	for _, tc := range testCases {
+		files, err := ExpandInputFiles(tc.inputs)
+		if tc.err != "" {
+			if err == nil || !strings.HasPrefix(err.Error(), tc.err) {
+				t.Errorf("%s - incorrect error: '%v', want prefix: '%s'", tc.name, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s - unexpected error: %s", tc.name, err)
+			continue
+		}
+		if !slices.Equal(files, tc.want) {
+			t.Errorf("%s - incorrect files: %v, want: %v", tc.name, files, tc.want)
+		}
+	}
+}
@@ -21,6 +21,7 @@ package utils
 
 import (
 	"fmt"
+	"math/big"
 	"net/netip"
 	"slices"
 	"strings"
@@ -40,38 +41,25 @@ func ParseIP(ip string) (IP, error) {
 	return IP{ip: ipAddr}, nil
 }
 
+// The name of the reverse lookup zone of the IP when no other zone is configured, see DefaultReverseZone
 func (i IP) ReverseZoneName() string {
-	if i.ip.Is4() {
-		octets := i.ip.AsSlice()
-		// Reverse zone names for ipv4 are the first three octets in reverse order.
-		return fmt.Sprintf("%d.%d.%d.in-addr.arpa.", octets[2], octets[1], octets[0])
-	}
-
-	// Reverse zone names for ipv6 are the first 64 bytes reversed and with a dot in between each value. For example:
-	// AAAA Record Value: fdda:5cc1:23:4::1f
-	// Without zero compression: fdda:5cc1:0023:0004:0000:0000:0000:001f
-	// Reversed: f100:0000:0000:0000:4000:3200:1cc5:addf
-	// Dotted notation: f.1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.4.0.0.0.3.2.0.0.1.c.c.5.a.d.d.f
-	// Reverse Zone Name: 4.0.0.0.3.2.0.0.1.c.c.5.a.d.d.f.ip6.arpa
-	// PTR Record Value: f.1.0.0.0.0.0.0.0.0.0.0.0.0.0.0
-	return i.toReverseDottedNotation()[32:] + ".ip6.arpa."
+	return DefaultReverseZone(i).Name()
 }
 
+// The name of the PTR record of the IP relative to the zone returned by ReverseZoneName
 func (i IP) PTRRecordValue() string {
-	if i.ip.Is4() {
-		octets := i.ip.AsSlice()
-		// PTR record values for ipv4 are the last octet.
-		return fmt.Sprintf("%d", octets[3])
-	}
+	return DefaultReverseZone(i).PTRRecordName(i)
+}
 
-	// Reverse zone names for ipv6 are the first 64 bytes reversed and with a dot in between each value. For example:
-	// AAAA Record Value: fdda:5cc1:23:4::1f
-	// Without zero compression: fdda:5cc1:0023:0004:0000:0000:0000:001f
-	// Reversed: f100:0000:0000:0000:4000:3200:1cc5:addf
-	// Dotted notation: f.1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.4.0.0.0.3.2.0.0.1.c.c.5.a.d.d.f
-	// Reverse Zone Name: 4.0.0.0.3.2.0.0.1.c.c.5.a.d.d.f.ip6.arpa
-	// PTR Record Value: f.1.0.0.0.0.0.0.0.0.0.0.0.0.0.0
-	return i.toReverseDottedNotation()[0:31]
+// Returns the address n addresses after the IP, e.g. 10.0.0.250 + 10 is 10.0.1.4
+func (i IP) Add(n uint64) (IP, error) {
+	bytes := i.ip.AsSlice()
+	sum := new(big.Int).Add(new(big.Int).SetBytes(bytes), new(big.Int).SetUint64(n))
+	if sum.BitLen() > len(bytes)*8 {
+		return IP{}, fmt.Errorf("'%s' + %d is past the end of the address space", i, n)
+	}
+	ip, _ := netip.AddrFromSlice(sum.FillBytes(make([]byte, len(bytes))))
+	return IP{ip: ip}, nil
 }
 
 func (i IP) StringExpanded() string {
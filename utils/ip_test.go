@@ -90,3 +90,39 @@ func TestPTRRecordValue(t *testing.T) {
 		}
 	}
 }
+
+func TestAdd(t *testing.T) {
+	testCases := []struct {
+		input   string
+		n       uint64
+		want    string
+		wantErr string
+	}{
+		{input: "10.0.0.200", n: 0, want: "10.0.0.200"},
+		{input: "10.0.0.200", n: 98, want: "10.0.1.42"},
+		{input: "10.255.255.255", n: 1, want: "11.0.0.0"},
+		{input: "2001:db8::ff", n: 1, want: "2001:db8::100"},
+		{input: "255.255.255.254", n: 2, wantErr: "'255.255.255.254' + 2 is past the end of the address space"},
+		{input: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", n: 1, wantErr: "'ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff' + 1 is past the end of the address space"},
+	}
+
+	for _, tc := range testCases {
+		ip, err := ParseIP(tc.input)
+		if err != nil {
+			t.Fatalf("Failed to parse IP: %v", err)
+		}
+		sum, err := ip.Add(tc.n)
+		if err != nil {
+			if err.Error() != tc.wantErr {
+				t.Errorf("incorrect error: '%s', want: '%s'", err, tc.wantErr)
+			}
+			continue
+		}
+		if tc.wantErr != "" {
+			t.Errorf("expected error '%s', found none", tc.wantErr)
+		}
+		if sum.String() != tc.want {
+			t.Errorf("incorrect sum of '%s' + %d: '%s', want '%s'", tc.input, tc.n, sum, tc.want)
+		}
+	}
+}
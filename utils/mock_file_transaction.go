@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: utils/file_transaction.go
+//
+// Generated by this command:
+//
+//	mockgen -source=utils/file_transaction.go -package utils -self_package github.com/bcurnow/zonemgr/utils
+//
+
+// Package utils is a generated GoMock package.
+package utils
+
+import (
+	os "os"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFileTransaction is a mock of FileTransaction interface.
+type MockFileTransaction struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileTransactionMockRecorder
+	isgomock struct{}
+}
+
+// MockFileTransactionMockRecorder is the mock recorder for MockFileTransaction.
+type MockFileTransactionMockRecorder struct {
+	mock *MockFileTransaction
+}
+
+// NewMockFileTransaction creates a new mock instance.
+func NewMockFileTransaction(ctrl *gomock.Controller) *MockFileTransaction {
+	mock := &MockFileTransaction{ctrl: ctrl}
+	mock.recorder = &MockFileTransactionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileTransaction) EXPECT() *MockFileTransactionMockRecorder {
+	return m.recorder
+}
+
+// Commit mocks base method.
+func (m *MockFileTransaction) Commit() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockFileTransactionMockRecorder) Commit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockFileTransaction)(nil).Commit))
+}
+
+// Rollback mocks base method.
+func (m *MockFileTransaction) Rollback() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Rollback")
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockFileTransactionMockRecorder) Rollback() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockFileTransaction)(nil).Rollback))
+}
+
+// StageFile mocks base method.
+func (m *MockFileTransaction) StageFile(path string, mode os.FileMode, contentFn func() ([]byte, error)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StageFile", path, mode, contentFn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StageFile indicates an expected call of StageFile.
+func (mr *MockFileTransactionMockRecorder) StageFile(path, mode, contentFn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StageFile", reflect.TypeOf((*MockFileTransaction)(nil).StageFile), path, mode, contentFn)
+}
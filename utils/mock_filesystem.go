@@ -1,5 +1,10 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: utils/filesystem.go
+//
+// Generated by this command:
+//
+//	mockgen -source=utils/filesystem.go -package utils -self_package github.com/bcurnow/zonemgr/utils
+//
 
 // Package utils is a generated GoMock package.
 package utils
@@ -16,6 +21,7 @@ import (
 type MockFileSystemOperations struct {
 	ctrl     *gomock.Controller
 	recorder *MockFileSystemOperationsMockRecorder
+	isgomock struct{}
 }
 
 // MockFileSystemOperationsMockRecorder is the mock recorder for MockFileSystemOperations.
@@ -44,7 +50,7 @@ func (m *MockFileSystemOperations) CreateFile(path string, mode os.FileMode, con
 }
 
 // CreateFile indicates an expected call of CreateFile.
-func (mr *MockFileSystemOperationsMockRecorder) CreateFile(path, mode, contentFn interface{}) *gomock.Call {
+func (mr *MockFileSystemOperationsMockRecorder) CreateFile(path, mode, contentFn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFile", reflect.TypeOf((*MockFileSystemOperations)(nil).CreateFile), path, mode, contentFn)
 }
@@ -58,7 +64,7 @@ func (m *MockFileSystemOperations) Exists(path string) bool {
 }
 
 // Exists indicates an expected call of Exists.
-func (mr *MockFileSystemOperationsMockRecorder) Exists(path interface{}) *gomock.Call {
+func (mr *MockFileSystemOperationsMockRecorder) Exists(path any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockFileSystemOperations)(nil).Exists), path)
 }
@@ -73,7 +79,7 @@ func (m *MockFileSystemOperations) Flock(path string) (*flock.Flock, error) {
 }
 
 // Flock indicates an expected call of Flock.
-func (mr *MockFileSystemOperationsMockRecorder) Flock(path interface{}) *gomock.Call {
+func (mr *MockFileSystemOperationsMockRecorder) Flock(path any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flock", reflect.TypeOf((*MockFileSystemOperations)(nil).Flock), path)
 }
@@ -101,11 +107,25 @@ func (m *MockFileSystemOperations) MkdirAll(path string, mode os.FileMode) error
 }
 
 // MkdirAll indicates an expected call of MkdirAll.
-func (mr *MockFileSystemOperationsMockRecorder) MkdirAll(path, mode interface{}) *gomock.Call {
+func (mr *MockFileSystemOperationsMockRecorder) MkdirAll(path, mode any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MkdirAll", reflect.TypeOf((*MockFileSystemOperations)(nil).MkdirAll), path, mode)
 }
 
+// NewTransaction mocks base method.
+func (m *MockFileSystemOperations) NewTransaction() FileTransaction {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewTransaction")
+	ret0, _ := ret[0].(FileTransaction)
+	return ret0
+}
+
+// NewTransaction indicates an expected call of NewTransaction.
+func (mr *MockFileSystemOperationsMockRecorder) NewTransaction() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewTransaction", reflect.TypeOf((*MockFileSystemOperations)(nil).NewTransaction))
+}
+
 // ToAbsoluteFilePath mocks base method.
 func (m *MockFileSystemOperations) ToAbsoluteFilePath(path string) (string, error) {
 	m.ctrl.T.Helper()
@@ -116,7 +136,7 @@ func (m *MockFileSystemOperations) ToAbsoluteFilePath(path string) (string, erro
 }
 
 // ToAbsoluteFilePath indicates an expected call of ToAbsoluteFilePath.
-func (mr *MockFileSystemOperationsMockRecorder) ToAbsoluteFilePath(path interface{}) *gomock.Call {
+func (mr *MockFileSystemOperationsMockRecorder) ToAbsoluteFilePath(path any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToAbsoluteFilePath", reflect.TypeOf((*MockFileSystemOperations)(nil).ToAbsoluteFilePath), path)
 }
@@ -131,7 +151,7 @@ func (m *MockFileSystemOperations) WalkExecutables(root string, includeSubDirs b
 }
 
 // WalkExecutables indicates an expected call of WalkExecutables.
-func (mr *MockFileSystemOperationsMockRecorder) WalkExecutables(root, includeSubDirs interface{}) *gomock.Call {
+func (mr *MockFileSystemOperationsMockRecorder) WalkExecutables(root, includeSubDirs any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalkExecutables", reflect.TypeOf((*MockFileSystemOperations)(nil).WalkExecutables), root, includeSubDirs)
 }
@@ -0,0 +1,163 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// A network whose addresses share a reverse lookup zone. IPv4 networks on an octet boundary (/8, /16 or /24) and IPv6
+// networks on a nibble boundary (a multiple of 4) map directly onto a reverse lookup zone. IPv4 networks smaller than a
+// /24 use the classless delegation from RFC 2317, the zone is named after the range of addresses it contains (e.g.
+// 0-63.2.0.192.in-addr.arpa.) and the parent /24 zone needs a CNAME record for each address pointing into it.
+type ReverseZone struct {
+	// The network, always masked so the host bits are zero
+	prefix netip.Prefix
+}
+
+func ParseReverseZone(s string) (ReverseZone, error) {
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return ReverseZone{}, err
+	}
+	prefix = prefix.Masked()
+
+	bits := prefix.Bits()
+	if prefix.Addr().Is4() {
+		if bits == 0 || bits == 32 || (bits < 24 && bits%8 != 0) {
+			return ReverseZone{}, fmt.Errorf("invalid reverse zone '%s', IPv4 reverse zones must be a /8, /16, /24 or between a /25 and a /31", s)
+		}
+	} else if bits == 0 || bits == 128 || bits%4 != 0 {
+		return ReverseZone{}, fmt.Errorf("invalid reverse zone '%s', IPv6 reverse zones must be a multiple of 4 between a /4 and a /124", s)
+	}
+	return ReverseZone{prefix: prefix}, nil
+}
+
+// The reverse zone used when no other zone is configured, a /24 for IPv4 and a /64 for IPv6
+func DefaultReverseZone(ip IP) ReverseZone {
+	bits := 64
+	if ip.Is4() {
+		bits = 24
+	}
+	// This can't fail, the number of bits is always valid for the address family
+	prefix, _ := ip.ip.Prefix(bits)
+	return ReverseZone{prefix: prefix}
+}
+
+func (rz ReverseZone) Contains(ip IP) bool {
+	return rz.prefix.Contains(ip.ip)
+}
+
+// The length of the network prefix in bits
+func (rz ReverseZone) Bits() int {
+	return rz.prefix.Bits()
+}
+
+func (rz ReverseZone) String() string {
+	return rz.prefix.String()
+}
+
+// True when this is an IPv4 zone smaller than a /24 which is delegated from its parent using RFC 2317
+func (rz ReverseZone) IsClassless() bool {
+	return rz.prefix.Addr().Is4() && rz.prefix.Bits() > 24
+}
+
+// The fully qualified name of the reverse lookup zone
+func (rz ReverseZone) Name() string {
+	if rz.prefix.Addr().Is4() {
+		octets := rz.prefix.Addr().AsSlice()
+		if rz.IsClassless() {
+			first, last := rz.classlessRange()
+			return fmt.Sprintf("%d-%d.%d.%d.%d.in-addr.arpa.", first, last, octets[2], octets[1], octets[0])
+		}
+		// The network octets in reverse order
+		return reverseOctets(octets[:rz.prefix.Bits()/8]) + ".in-addr.arpa."
+	}
+
+	// The network nibbles reversed and with a dot in between each value. For example, for a /64:
+	// Network: fdda:5cc1:23:4::/64
+	// Without zero compression: fdda:5cc1:0023:0004:0000:0000:0000:0000
+	// Dotted notation: 0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.4.0.0.0.3.2.0.0.1.c.c.5.a.d.d.f
+	// Reverse Zone Name: 4.0.0.0.3.2.0.0.1.c.c.5.a.d.d.f.ip6.arpa.
+	return IP{ip: rz.prefix.Addr()}.toReverseDottedNotation()[rz.hostNibbles()*2:] + ".ip6.arpa."
+}
+
+// The name of the PTR record of ip relative to the reverse lookup zone, ip must be in the zone
+func (rz ReverseZone) PTRRecordName(ip IP) string {
+	if ip.Is4() {
+		octets := ip.ip.AsSlice()
+		if rz.IsClassless() {
+			// The zone name already holds the first three octets, the PTR records are named after the last one
+			return fmt.Sprintf("%d", octets[3])
+		}
+		// The host octets in reverse order
+		return reverseOctets(octets[rz.prefix.Bits()/8:])
+	}
+
+	// The host nibbles of the dotted notation (see Name)
+	return ip.toReverseDottedNotation()[0 : rz.hostNibbles()*2-1]
+}
+
+// The fully qualified name of the /24 zone a classless zone is delegated from, empty unless the zone is classless
+func (rz ReverseZone) ParentName() string {
+	if !rz.IsClassless() {
+		return ""
+	}
+	octets := rz.prefix.Addr().AsSlice()
+	return reverseOctets(octets[:3]) + ".in-addr.arpa."
+}
+
+// The CNAME record the parent zone of a classless zone needs for ip, its name relative to the parent zone and the
+// fully qualified name of the PTR record in this zone it points at. Empty unless the zone is classless, ip must be in the
+// zone.
+func (rz ReverseZone) ClasslessCNAME(ip IP) (string, string) {
+	if !rz.IsClassless() {
+		return "", ""
+	}
+	name := rz.PTRRecordName(ip)
+	return name, name + "." + rz.Name()
+}
+
+// The first and last values of the last octet of a classless zone
+func (rz ReverseZone) classlessRange() (int, int) {
+	first := int(rz.prefix.Addr().As4()[3])
+	return first, first + (1 << (32 - rz.prefix.Bits())) - 1
+}
+
+// The number of nibbles of an IPv6 address that aren't part of the network
+func (rz ReverseZone) hostNibbles() int {
+	return 32 - rz.prefix.Bits()/4
+}
+
+func reverseOctets(octets []byte) string {
+	labels := make([]string, len(octets))
+	for i, octet := range octets {
+		labels[len(octets)-1-i] = fmt.Sprintf("%d", octet)
+	}
+	return strings.Join(labels, ".")
+}
+
+// True when name is in one of the reverse lookup domains (in-addr.arpa. or ip6.arpa.), the trailing dot is optional
+func IsReverseZoneName(name string) bool {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	return strings.HasSuffix(name, ".in-addr.arpa") || strings.HasSuffix(name, ".ip6.arpa")
+}
@@ -0,0 +1,189 @@
+/**
+ * Copyright (C) 2025 Brian Curnow
+ *
+ * This file is part of zonemgr.
+ *
+ * zonemgr is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * zonemgr is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with zonemgr.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package utils
+
+import (
+	"testing"
+)
+
+func TestParseReverseZone(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  string
+		err   string
+	}{
+		{input: "10.0.0.0/8", want: "10.0.0.0/8"},
+		{input: "10.1.0.0/16", want: "10.1.0.0/16"},
+		{input: "192.0.2.0/24", want: "192.0.2.0/24"},
+		{input: "192.0.2.64/26", want: "192.0.2.64/26"},
+		{input: "192.0.2.70/26", want: "192.0.2.64/26"},
+		{input: "192.0.2.0/31", want: "192.0.2.0/31"},
+		{input: "2001:db8::/48", want: "2001:db8::/48"},
+		{input: "2001:db8::/124", want: "2001:db8::/124"},
+		{input: "10.0.0.0/12", err: "invalid reverse zone '10.0.0.0/12', IPv4 reverse zones must be a /8, /16, /24 or between a /25 and a /31"},
+		{input: "10.0.0.0/0", err: "invalid reverse zone '10.0.0.0/0', IPv4 reverse zones must be a /8, /16, /24 or between a /25 and a /31"},
+		{input: "10.0.0.1/32", err: "invalid reverse zone '10.0.0.1/32', IPv4 reverse zones must be a /8, /16, /24 or between a /25 and a /31"},
+		{input: "2001:db8::/50", err: "invalid reverse zone '2001:db8::/50', IPv6 reverse zones must be a multiple of 4 between a /4 and a /124"},
+		{input: "2001:db8::1/128", err: "invalid reverse zone '2001:db8::1/128', IPv6 reverse zones must be a multiple of 4 between a /4 and a /124"},
+		{input: "invalid", err: "netip.ParsePrefix(\"invalid\"): no '/'"},
+	}
+
+	for _, tc := range testCases {
+		rz, err := ParseReverseZone(tc.input)
+		if err != nil {
+			if err.Error() != tc.err {
+				t.Errorf("incorrect error for '%s': '%v', want: '%s'", tc.input, err, tc.err)
+			}
+			continue
+		}
+
+		if tc.err != "" {
+			t.Errorf("expected error for '%s': '%s', found none", tc.input, tc.err)
+		}
+
+		if rz.String() != tc.want {
+			t.Errorf("incorrect reverse zone for '%s': '%s', want: '%s'", tc.input, rz, tc.want)
+		}
+	}
+}
+
+func TestDefaultReverseZone(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  string
+	}{
+		{input: "1.2.3.4", want: "1.2.3.0/24"},
+		{input: "fdda:5cc1:23:4::1f", want: "fdda:5cc1:23:4::/64"},
+	}
+
+	for _, tc := range testCases {
+		ip, err := ParseIP(tc.input)
+		if err != nil {
+			t.Fatalf("Failed to parse IP: %v", err)
+		}
+
+		rz := DefaultReverseZone(ip)
+		if rz.String() != tc.want {
+			t.Errorf("incorrect reverse zone for '%s': '%s', want: '%s'", tc.input, rz, tc.want)
+		}
+
+		if !rz.Contains(ip) {
+			t.Errorf("reverse zone '%s' doesn't contain '%s'", rz, tc.input)
+		}
+	}
+}
+
+func TestReverseZone_Names(t *testing.T) {
+	testCases := []struct {
+		zone      string
+		ip        string
+		name      string
+		ptrName   string
+		classless bool
+		parent    string
+	}{
+		{zone: "10.0.0.0/8", ip: "10.1.2.3", name: "10.in-addr.arpa.", ptrName: "3.2.1"},
+		{zone: "10.1.0.0/16", ip: "10.1.2.3", name: "1.10.in-addr.arpa.", ptrName: "3.2"},
+		{zone: "10.1.2.0/24", ip: "10.1.2.3", name: "2.1.10.in-addr.arpa.", ptrName: "3"},
+		{zone: "192.0.2.0/26", ip: "192.0.2.5", name: "0-63.2.0.192.in-addr.arpa.", ptrName: "5", classless: true, parent: "2.0.192.in-addr.arpa."},
+		{zone: "192.0.2.128/25", ip: "192.0.2.200", name: "128-255.2.0.192.in-addr.arpa.", ptrName: "200", classless: true, parent: "2.0.192.in-addr.arpa."},
+		{zone: "fdda:5cc1:23::/48", ip: "fdda:5cc1:23:4::1f", name: "3.2.0.0.1.c.c.5.a.d.d.f.ip6.arpa.", ptrName: "f.1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.4.0.0.0"},
+		{zone: "fdda:5cc1:23:4::/64", ip: "fdda:5cc1:23:4::1f", name: "4.0.0.0.3.2.0.0.1.c.c.5.a.d.d.f.ip6.arpa.", ptrName: "f.1.0.0.0.0.0.0.0.0.0.0.0.0.0.0"},
+		{zone: "fdda:5cc1:23:4::/68", ip: "fdda:5cc1:23:4::1f", name: "0.4.0.0.0.3.2.0.0.1.c.c.5.a.d.d.f.ip6.arpa.", ptrName: "f.1.0.0.0.0.0.0.0.0.0.0.0.0.0"},
+	}
+
+	for _, tc := range testCases {
+		rz, err := ParseReverseZone(tc.zone)
+		if err != nil {
+			t.Fatalf("Failed to parse reverse zone: %v", err)
+		}
+		ip, err := ParseIP(tc.ip)
+		if err != nil {
+			t.Fatalf("Failed to parse IP: %v", err)
+		}
+
+		if !rz.Contains(ip) {
+			t.Errorf("reverse zone '%s' doesn't contain '%s'", tc.zone, tc.ip)
+		}
+
+		if rz.Name() != tc.name {
+			t.Errorf("incorrect name for '%s': '%s', want: '%s'", tc.zone, rz.Name(), tc.name)
+		}
+
+		if rz.PTRRecordName(ip) != tc.ptrName {
+			t.Errorf("incorrect PTR record name for '%s' in '%s': '%s', want: '%s'", tc.ip, tc.zone, rz.PTRRecordName(ip), tc.ptrName)
+		}
+
+		if rz.IsClassless() != tc.classless {
+			t.Errorf("incorrect classless for '%s': %t, want: %t", tc.zone, rz.IsClassless(), tc.classless)
+		}
+
+		if rz.ParentName() != tc.parent {
+			t.Errorf("incorrect parent name for '%s': '%s', want: '%s'", tc.zone, rz.ParentName(), tc.parent)
+		}
+	}
+}
+
+func TestReverseZone_ClasslessCNAME(t *testing.T) {
+	testCases := []struct {
+		zone   string
+		ip     string
+		name   string
+		target string
+	}{
+		{zone: "192.0.2.0/24", ip: "192.0.2.9"},
+		{zone: "192.0.2.8/30", ip: "192.0.2.9", name: "9", target: "9.8-11.2.0.192.in-addr.arpa."},
+		{zone: "192.0.2.128/25", ip: "192.0.2.200", name: "200", target: "200.128-255.2.0.192.in-addr.arpa."},
+	}
+
+	for _, tc := range testCases {
+		rz, err := ParseReverseZone(tc.zone)
+		if err != nil {
+			t.Fatalf("Failed to parse reverse zone: %v", err)
+		}
+		ip, err := ParseIP(tc.ip)
+		if err != nil {
+			t.Fatalf("Failed to parse IP: %v", err)
+		}
+
+		if name, target := rz.ClasslessCNAME(ip); name != tc.name || target != tc.target {
+			t.Errorf("incorrect CNAME record for '%s' in '%s': '%s CNAME %s', want: '%s CNAME %s'", tc.ip, tc.zone, name, target, tc.name, tc.target)
+		}
+	}
+}
+
+func TestIsReverseZoneName(t *testing.T) {
+	testCases := []struct {
+		name string
+		want bool
+	}{
+		{name: "2.0.192.in-addr.arpa.", want: true},
+		{name: "0-63.2.0.192.IN-ADDR.ARPA", want: true},
+		{name: "8.b.d.0.1.0.0.2.ip6.arpa.", want: true},
+		{name: "example.com.", want: false},
+		{name: "in-addr.arpa.example.com.", want: false},
+	}
+
+	for _, tc := range testCases {
+		if got := IsReverseZoneName(tc.name); got != tc.want {
+			t.Errorf("incorrect result for '%s': %t, want: %t", tc.name, got, tc.want)
+		}
+	}
+}
@@ -25,6 +25,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
 
 	"github.com/bcurnow/zonemgr/models"
 	"github.com/go-playground/validator/v10"
@@ -47,34 +50,281 @@ var (
 	_               YamlFile[map[string]*models.Zone] = &ZoneYamlFile{}
 	_               YamlFile[*models.SerialIndex]     = &SerialIndexYamlFile{}
 	unmarshal                                         = strictUnmarshal
-	marshal                                           = yaml.Marshal
+	marshal                                           = indentedMarshal
 	openFile                                          = os.OpenFile
 	marshalFileMode                                   = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
 	validate                                          = validator.New()
 )
 
+// Neither yaml.v3 nor the validator report structured positions, they are found in the error messages instead
+var (
+	syntaxErrorRegex             = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+	typeErrorRegex               = regexp.MustCompile(`^line (\d+): (.*)$`)
+	resourceRecordNamespaceRegex = regexp.MustCompile(`^(?:Zone|Template)\.ResourceRecords\[(.*?)\]\.`)
+)
+
+// The top level key of the templates, every other top level key is a zone
+const templatesKey = "templates"
+
+// Reads the zones of the file, the templates are ignored, use ReadZoneFile to read both
 func (yr *ZoneYamlFile) Read(path string) (map[string]*models.Zone, error) {
-	zones, err := unmarshalYaml[map[string]*models.Zone](path)
+	zoneFile, err := yr.ReadZoneFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return zoneFile.Zones, nil
+}
+
+// Reads the zones and the templates of the file
+func (yr *ZoneYamlFile) ReadZoneFile(path string) (*models.ZoneFile, error) {
+	logger().Debug("opening file", "path", path)
+	inputBytes, err := readFile(path)
 	if err != nil {
+		return nil, fmt.Errorf("failed to open '%s': %w", path, err)
+	}
+
+	// The nodes keep the line and column of everything in the file, the zones don't
+	var root yaml.Node
+	if err := yaml.Unmarshal(inputBytes, &root); err != nil {
+		if matches := syntaxErrorRegex.FindStringSubmatch(err.Error()); matches != nil {
+			line, _ := strconv.Atoi(matches[1])
+			return nil, models.ValidationErrors{{Position: &models.SourcePosition{File: path, Line: line}, Rule: models.RuleYAMLSyntax, Err: errors.New(matches[2])}}
+		}
+		return nil, fmt.Errorf("failed to parse input YAML: %w", err)
+	}
+
+	logger().Debug("unmarshaling YAML", "path", path)
+	zoneFile := &models.ZoneFile{}
+	if err := unmarshal(inputBytes, zoneFile); err != nil {
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			return nil, typeErrors(path, &root, typeErr)
+		}
+		return nil, fmt.Errorf("failed to parse input YAML: %w", err)
+	}
+
+	zonePositions, templatePositions := setPositions(path, &root, zoneFile)
+
+	// Validate the templates and the zones
+	var errs models.ValidationErrors
+	for _, name := range sortedKeys(zoneFile.Templates) {
+		template := zoneFile.Templates[name]
+		if template == nil {
+			errs = append(errs, &models.ValidationError{Position: templatePositions[name], Template: name, Rule: models.RuleTemplate, Err: errors.New("no template information for template")})
+			continue
+		}
+		errs = append(errs, validateTemplate(name, template)...)
+	}
+	if err := models.WithSortedZones(zoneFile.Zones, func(zoneName string, zone *models.Zone) error {
+		// It is possible for the zone itself to be nil, this happens if a file only contains the name of the zone and no other info
+		if zone == nil {
+			errs = append(errs, &models.ValidationError{Position: zonePositions[zoneName], Zone: zoneName, Rule: models.RuleMissingZone, Err: errors.New("no zone information for zone")})
+			return nil
+		}
+		errs = append(errs, validateZone(zoneName, zone)...)
+		return nil
+	}); err != nil {
 		return nil, err
 	}
+	if len(errs) > 0 {
+		errs.SortByPosition()
+		return nil, errs
+	}
 
-	// Validate the zones
-	for zoneName, zone := range zones {
+	return zoneFile, nil
+}
+
+// Turns each of the "line N: message" errors into an error with the position, zone and identifier it belongs to
+func typeErrors(path string, root *yaml.Node, typeErr *yaml.TypeError) models.ValidationErrors {
+	errs := make(models.ValidationErrors, 0, len(typeErr.Errors))
+	for _, message := range typeErr.Errors {
+		matches := typeErrorRegex.FindStringSubmatch(message)
+		if matches == nil {
+			errs = append(errs, &models.ValidationError{Position: &models.SourcePosition{File: path}, Rule: models.RuleYAMLType, Err: errors.New(message)})
+			continue
+		}
+
+		line, _ := strconv.Atoi(matches[1])
+		zoneName, templateName, identifier, column := locate(root, line)
+		errs = append(errs, &models.ValidationError{
+			Position:   &models.SourcePosition{File: path, Line: line, Column: column},
+			Zone:       zoneName,
+			Template:   templateName,
+			Identifier: identifier,
+			Rule:       models.RuleYAMLType,
+			Err:        errors.New(matches[2]),
+		})
+	}
+	return errs
+}
+
+// Records where each zone, template and resource record starts, the zones are the top level keys (except for templates)
+// and the resource records are the keys of each zone's or template's resource_records. Returns the position of every zone
+// and template including the ones without any information.
+func setPositions(path string, root *yaml.Node, zoneFile *models.ZoneFile) (map[string]*models.SourcePosition, map[string]*models.SourcePosition) {
+	zonePositions := make(map[string]*models.SourcePosition)
+	templatePositions := make(map[string]*models.SourcePosition)
+	forEachKey(documentMapping(root), func(zoneKey *yaml.Node, zoneValue *yaml.Node) {
+		if zoneKey.Value == templatesKey {
+			forEachKey(zoneValue, func(templateKey *yaml.Node, templateValue *yaml.Node) {
+				templatePositions[templateKey.Value] = position(path, templateKey)
+				template := zoneFile.Templates[templateKey.Value]
+				if template == nil {
+					return
+				}
+				template.SetPosition(templatePositions[templateKey.Value])
+
+				forEachKey(resourceRecordsMapping(templateValue), func(identifierKey *yaml.Node, _ *yaml.Node) {
+					template.SetResourceRecordPosition(identifierKey.Value, position(path, identifierKey))
+				})
+			})
+			return
+		}
+
+		zonePositions[zoneKey.Value] = position(path, zoneKey)
+		zone := zoneFile.Zones[zoneKey.Value]
 		if zone == nil {
-			continue // Skip nil zones, they will be handled by the parser
+			return
+		}
+		zone.SetPosition(zonePositions[zoneKey.Value])
+
+		forEachKey(resourceRecordsMapping(zoneValue), func(identifierKey *yaml.Node, _ *yaml.Node) {
+			zone.SetResourceRecordPosition(identifierKey.Value, position(path, identifierKey))
+		})
+	})
+	return zonePositions, templatePositions
+}
+
+// Returns the zone or template and the identifier that the line is part of along with the column of the first thing on the line
+func locate(root *yaml.Node, line int) (string, string, string, int) {
+	var zoneName, templateName, identifier string
+	var zoneValue *yaml.Node
+	forEachKey(documentMapping(root), func(key *yaml.Node, value *yaml.Node) {
+		if key.Line <= line {
+			zoneName = key.Value
+			zoneValue = value
+		}
+	})
+
+	if zoneName == templatesKey {
+		zoneName = ""
+		templatesValue := zoneValue
+		zoneValue = nil
+		forEachKey(templatesValue, func(key *yaml.Node, value *yaml.Node) {
+			if key.Line <= line {
+				templateName = key.Value
+				zoneValue = value
+			}
+		})
+	}
+
+	forEachKey(resourceRecordsMapping(zoneValue), func(key *yaml.Node, _ *yaml.Node) {
+		if key.Line <= line {
+			identifier = key.Value
 		}
-		if err := validate.Struct(zone); err != nil {
-			return nil, fmt.Errorf("validation failed for zone '%s': %w", zoneName, err)
+	})
+
+	return zoneName, templateName, identifier, firstColumn(root, line)
+}
+
+func firstColumn(node *yaml.Node, line int) int {
+	if node.Line == line && node.Kind != yaml.DocumentNode {
+		return node.Column
+	}
+	for _, child := range node.Content {
+		if column := firstColumn(child, line); column != 0 {
+			return column
+		}
+	}
+	return 0
+}
+
+func documentMapping(root *yaml.Node) *yaml.Node {
+	if root.Kind == yaml.DocumentNode && len(root.Content) == 1 {
+		return root.Content[0]
+	}
+	return nil
+}
+
+func resourceRecordsMapping(zoneValue *yaml.Node) *yaml.Node {
+	var resourceRecords *yaml.Node
+	forEachKey(zoneValue, func(key *yaml.Node, value *yaml.Node) {
+		if key.Value == "resource_records" {
+			resourceRecords = value
+		}
+	})
+	return resourceRecords
+}
+
+// Calls fn with each key and value of the mapping, does nothing when node isn't a mapping
+func forEachKey(node *yaml.Node, fn func(key *yaml.Node, value *yaml.Node)) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		fn(node.Content[i], node.Content[i+1])
+	}
+}
+
+func position(path string, node *yaml.Node) *models.SourcePosition {
+	return &models.SourcePosition{File: path, Line: node.Line, Column: node.Column}
+}
+
+// A zone or template, the struct validated and where it and its resource records were defined
+type recordMap interface {
+	Position() *models.SourcePosition
+	ResourceRecordPosition(identifier string) *models.SourcePosition
+}
+
+func validateZone(zoneName string, zone *models.Zone) models.ValidationErrors {
+	return validateRecordMap(zone, models.ValidationError{Zone: zoneName})
+}
+
+func validateTemplate(templateName string, template *models.Template) models.ValidationErrors {
+	return validateRecordMap(template, models.ValidationError{Template: templateName})
+}
+
+// Validates the zone or template, each failed field is reported against the resource record it belongs to when there
+// is one. The errors are copies of base with the rule, position and identifier set.
+func validateRecordMap(value recordMap, base models.ValidationError) models.ValidationErrors {
+	err := validate.Struct(value)
+	if err == nil {
+		return nil
+	}
+
+	base.Position = value.Position()
+	base.Rule = models.RuleSchema
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		validationErr := base
+		validationErr.Err = fmt.Errorf("validation failed: %w", err)
+		return models.ValidationErrors{&validationErr}
+	}
+
+	errs := make(models.ValidationErrors, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErr := base
+		validationErr.Err = fmt.Errorf("validation failed for '%s' on the '%s' tag", fieldErr.Namespace(), fieldErr.Tag())
+		if matches := resourceRecordNamespaceRegex.FindStringSubmatch(fieldErr.Namespace()); matches != nil {
+			validationErr.Identifier = matches[1]
+			validationErr.Position = value.ResourceRecordPosition(matches[1])
 		}
+		errs = append(errs, &validationErr)
 	}
+	return errs
+}
 
-	return zones, nil
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
-// We don't need to write out a Zone back to a file (or do we?)
 func (yr *ZoneYamlFile) Write(path string, content map[string]*models.Zone) error {
-	return errors.New("not implemented")
+	return marshalYaml(path, content)
 }
 
 func (sir *SerialIndexYamlFile) Read(path string) (*models.SerialIndex, error) {
@@ -98,6 +348,21 @@ func strictUnmarshal(data []byte, out interface{}) error {
 	return nil
 }
 
+// indentedMarshal behaves like yaml.Marshal but indents with two spaces, matching the YAML files zonemgr
+// reads, instead of yaml.Marshal's four. Map keys are always sorted so the output is deterministic.
+func indentedMarshal(in interface{}) ([]byte, error) {
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(in); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
 func unmarshalYaml[T any](path string) (T, error) {
 	var nilT T
 	logger().Debug("opening file", "path", path)
@@ -47,16 +47,226 @@ func TestRead_ZoneYamlFile(t *testing.T) {
 	}
 }
 
-func TestWrite_ZoneYamlFile(t *testing.T) {
-	if err := (&ZoneYamlFile{}).Write("testing", nil); err == nil {
-		t.Error("expected an error, found none")
-	} else {
-		if err.Error() != "not implemented" {
-			t.Errorf("incorrect error: '%s', want: 'not implemented'", err)
+func TestRead_ZoneYamlFile_Positions(t *testing.T) {
+	defer func() { unmarshal = strictUnmarshal }()
+	unmarshal = strictUnmarshal
+
+	content := `example.com.:
+  resource_records:
+    www:
+      type: A
+      value: 1.2.3.4
+    mail:
+      type: A
+      value: 1.2.3.5
+`
+	readFile = func(_ string) ([]byte, error) { return []byte(content), nil }
+
+	zones, err := (&ZoneYamlFile{}).Read("zones.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	zone := zones["example.com."]
+	if want := "zones.yaml:1:1"; zone.Position().String() != want {
+		t.Errorf("incorrect zone position: '%s', want: '%s'", zone.Position(), want)
+	}
+	if want := "zones.yaml:3:5"; zone.ResourceRecordPosition("www").String() != want {
+		t.Errorf("incorrect www position: '%s', want: '%s'", zone.ResourceRecordPosition("www"), want)
+	}
+	if want := "zones.yaml:6:5"; zone.ResourceRecordPosition("mail").String() != want {
+		t.Errorf("incorrect mail position: '%s', want: '%s'", zone.ResourceRecordPosition("mail"), want)
+	}
+}
+
+func TestRead_ZoneYamlFile_Errors(t *testing.T) {
+	defer func() { unmarshal = strictUnmarshal }()
+	unmarshal = strictUnmarshal
+
+	testCases := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name: "type-errors",
+			content: `example.com.:
+  config:
+    generate_serial: hello
+  resource_records:
+    www:
+      type: A
+      ttl: soon
+`,
+			want: "found 2 errors:\n" +
+				"  zones.yaml:3:5: zone 'example.com.': cannot unmarshal !!str `hello` into bool\n" +
+				"  zones.yaml:7:7: zone 'example.com.', identifier 'www': invalid time interval 'soon', expected a number of seconds or numbers with a unit (w, d, h, m or s) e.g. 1h30m",
+		},
+		{
+			name: "validation-errors",
+			content: `example.com.:
+  resource_records:
+    www:
+      value: 1.2.3.4
+other.com.:
+  resource_records:
+    mail:
+      value: 1.2.3.5
+`,
+			want: "found 2 errors:\n" +
+				"  zones.yaml:3:5: zone 'example.com.', identifier 'www': validation failed for 'Zone.ResourceRecords[www].Type' on the 'required' tag\n" +
+				"  zones.yaml:7:5: zone 'other.com.', identifier 'mail': validation failed for 'Zone.ResourceRecords[mail].Type' on the 'required' tag",
+		},
+		{
+			name: "template-errors",
+			content: `templates:
+  common:
+    resource_records:
+      ns1:
+        value: ns1.example.com.
+  empty:
+example.com.:
+  include: [common]
+  resource_records:
+`,
+			want: "found 2 errors:\n" +
+				"  zones.yaml:4:7: template 'common', identifier 'ns1': validation failed for 'Template.ResourceRecords[ns1].Type' on the 'required' tag\n" +
+				"  zones.yaml:6:3: template 'empty': no template information for template",
+		},
+		{
+			name: "template-type-errors",
+			content: `templates:
+  common:
+    resource_records:
+      mx:
+        type: MX
+        ttl: soon
+`,
+			want: "zones.yaml:6:9: template 'common', identifier 'mx': invalid time interval 'soon', expected a number of seconds or numbers with a unit (w, d, h, m or s) e.g. 1h30m",
+		},
+		{
+			name:    "no-zone-information",
+			content: "example.com.:\n",
+			want:    "zones.yaml:1:1: zone 'example.com.': no zone information for zone",
+		},
+		{
+			name:    "syntax-error",
+			content: "example.com.: [\n",
+			want:    "zones.yaml:1: did not find expected node content",
+		},
+	}
+
+	for _, tc := range testCases {
+		readFile = func(_ string) ([]byte, error) { return []byte(tc.content), nil }
+
+		_, err := (&ZoneYamlFile{}).Read("zones.yaml")
+		if err == nil || err.Error() != tc.want {
+			t.Errorf("%s - incorrect error: '%v', want: '%s'", tc.name, err, tc.want)
 		}
 	}
 }
 
+func TestReadZoneFile_ZoneYamlFile(t *testing.T) {
+	defer func() { unmarshal = strictUnmarshal }()
+	unmarshal = strictUnmarshal
+	readFile = func(_ string) ([]byte, error) {
+		return []byte(`templates:
+  common:
+    resource_records:
+      ns1:
+        type: NS
+        value: ns1.example.com.
+example.com.:
+  include: [common]
+  resource_records:
+    www:
+      type: A
+      value: 192.0.2.1
+`), nil
+	}
+
+	zoneFile, err := (&ZoneYamlFile{}).ReadZoneFile("zones.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	template := zoneFile.Templates["common"]
+	if template == nil || template.ResourceRecords["ns1"] == nil {
+		t.Fatalf("incorrect templates: %v", zoneFile.Templates)
+	}
+	if got := template.ResourceRecordPosition("ns1").String(); got != "zones.yaml:4:7" {
+		t.Errorf("incorrect template record position: '%s', want: 'zones.yaml:4:7'", got)
+	}
+
+	zone := zoneFile.Zones["example.com."]
+	if len(zoneFile.Zones) != 1 || zone == nil || !slices.Equal(zone.Include, []string{"common"}) {
+		t.Fatalf("incorrect zones: %v", zoneFile.Zones)
+	}
+	if got := zone.ResourceRecordPosition("www").String(); got != "zones.yaml:10:5" {
+		t.Errorf("incorrect zone record position: '%s', want: 'zones.yaml:10:5'", got)
+	}
+}
+
+func TestWrite_ZoneYamlFile(t *testing.T) {
+	createTemp(t)
+	defer tempTeardown(t)
+	openFile = func(name string, flag int, perm os.FileMode) (*os.File, error) { return testFile, nil }
+	marshal = indentedMarshal
+
+	ttl := int32(300)
+	zones := map[string]*models.Zone{
+		"example.com.": {
+			ResourceRecords: map[string]*models.ResourceRecord{
+				"www": {Name: "www", Type: models.A, TTL: &ttl, Value: "192.0.2.1"},
+				"example.com.": {
+					Type:   models.SOA,
+					Values: []*models.ResourceRecordValue{{Value: "ns1.example.com.", Comment: "primary"}, {Value: "admin@example.com"}},
+				},
+			},
+		},
+	}
+
+	if err := (&ZoneYamlFile{}).Write("testing", zones); err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	content, err := os.ReadFile(testFile.Name())
+	if err != nil {
+		t.Fatalf("unable to read test file '%s': %s", testFile.Name(), err)
+	}
+
+	// Empty fields are left out and keys are sorted so the output is stable
+	want := `example.com.:
+  resource_records:
+    example.com.:
+      type: SOA
+      values:
+        - value: ns1.example.com.
+          comment: primary
+        - value: admin@example.com
+    www:
+      name: www
+      type: A
+      ttl: 300
+      value: 192.0.2.1
+`
+	if string(content) != want {
+		t.Errorf("incorrect file contents:\n%s\nwant:\n%s", content, want)
+	}
+}
+
+func TestIndentedMarshal(t *testing.T) {
+	content, err := indentedMarshal(map[string]map[string]string{"outer": {"inner": "value"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := "outer:\n  inner: value\n"
+	if string(content) != want {
+		t.Errorf("incorrect content: '%s', want: '%s'", content, want)
+	}
+}
+
 func TestRead_SerialIndexYamlFile(t *testing.T) {
 
 	readFile = func(_ string) ([]byte, error) { return []byte("testing"), nil }